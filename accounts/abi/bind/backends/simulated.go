@@ -323,6 +323,15 @@ func (fb *filterBackend) SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEve
 func (fb *filterBackend) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription {
 	return fb.bc.SubscribeLogsEvent(ch)
 }
+func (fb *filterBackend) SubscribeNewRoundEvent(ch chan<- core.NewRoundEvent) event.Subscription {
+	return fb.bc.SubscribeNewRoundEvent(ch)
+}
+func (fb *filterBackend) SubscribeVotesEvent(ch chan<- core.VotesEvent) event.Subscription {
+	return fb.bc.SubscribeVotesEvent(ch)
+}
+func (fb *filterBackend) SubscribeTextsEvent(ch chan<- core.TextsEvent) event.Subscription {
+	return fb.bc.SubscribeTextsEvent(ch)
+}
 func (fb *filterBackend) BloomStatus() (uint64, uint64) { return 4096, 0 }
 func (fb *filterBackend) ServiceFilter(ctx context.Context, ms *bloombits.MatcherSession) {
 	panic("not supported")
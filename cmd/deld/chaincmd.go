@@ -176,12 +176,7 @@ func predugBlock(ctx *cli.Context) error {
 	})
 	blockchain, _ := core.NewBlockChain(database, nil, genesis.Config, engine, vm.Config{})
 	parent_block := blockchain.CurrentBlock()
-	core.GenerateChainAndSave(genesis.Config, parent_block, engine, database, 10000, func(i int, b *core.BlockGen) {
-		producers := b.SetProducers(nil)
-		b.OffsetTime(common.SLOT_BASE)
-		slot := common.GetCurrentSlotByBigInt(b.GetTime())
-		b.SetCoinbase(producers[slot % int64(len(producers))].Addr)
-	}, func(parent *types.Block, block *types.Block){
+	core.GenerateChainAndSave(genesis.Config, parent_block, engine, database, 10000, nil, func(parent *types.Block, block *types.Block){
 		statedb, _ := blockchain.StateAt(parent.Header().Root)
 		_, _ = blockchain.WriteBlockWithState(block, nil, statedb)
 	})
@@ -13,6 +13,7 @@ import (
 	"github.com/DEL-ORG/del/eth"
 	"github.com/DEL-ORG/del/node"
 	"github.com/DEL-ORG/del/params"
+	"github.com/DEL-ORG/del/restrpc/restapi"
 	whisper "github.com/DEL-ORG/del/whisper/whisperv5"
 	"github.com/naoina/toml"
 	"github.com/DEL-ORG/del/common"
@@ -56,6 +57,7 @@ type gethConfig struct {
 	Node      node.Config
 	Ethstats  ethstatsConfig
 	Dashboard dashboard.Config
+	REST      restapi.Config
 }
 func loadConfig(file string, cfg *gethConfig) error {
 	f, err := os.Open(file)
@@ -84,6 +86,7 @@ func makeConfigNode(ctx *cli.Context) (*node.Node, gethConfig) {
 		Shh:       whisper.DefaultConfig,
 		Node:      defaultNodeConfig(),
 		Dashboard: dashboard.DefaultConfig,
+		REST:      restapi.DefaultConfig,
 	}
 	if file := ctx.GlobalString(configFileFlag.Name); file != "" {
 		if err := loadConfig(file, &cfg); err != nil {
@@ -101,6 +104,8 @@ func makeConfigNode(ctx *cli.Context) (*node.Node, gethConfig) {
 	}
 	utils.SetShhConfig(ctx, stack, &cfg.Shh)
 	utils.SetDashboardConfig(ctx, &cfg.Dashboard)
+	utils.SetRESTConfig(ctx, &cfg.REST)
+	cfg.REST.JWTSecret, cfg.REST.Policy = cfg.Node.JWTSecret, cfg.Node.RPCPolicy
 	return stack, cfg
 }
 func enableWhisper(ctx *cli.Context) bool {
@@ -128,6 +133,9 @@ func makeFullNode(ctx *cli.Context) *node.Node {
 		}
 		utils.RegisterShhService(stack, &cfg.Shh)
 	}
+	if ctx.GlobalBool(utils.RESTEnabledFlag.Name) {
+		utils.RegisterRESTService(stack, &cfg.REST)
+	}
 	if cfg.Ethstats.URL != "" {
 		utils.RegisterEthStatsService(stack, cfg.Ethstats.URL)
 	}
@@ -39,6 +39,7 @@ var (
 		utils.DashboardPortFlag,
 		utils.DashboardRefreshFlag,
 		utils.DashboardAssetsFlag,
+		utils.DashboardSlotsFlag,
 		
 		utils.TxPoolNoLocalsFlag,
 		utils.TxPoolJournalFlag,
@@ -51,6 +52,7 @@ var (
 		utils.TxPoolGlobalQueueFlag,
 		utils.TxPoolLifetimeFlag,
 		utils.GCModeFlag,
+		utils.CheckpointFlag,
 		
 		utils.CacheFlag,
 		utils.CacheDatabaseFlag,
@@ -92,6 +94,11 @@ var (
 		utils.WSPortFlag,
 		utils.WSApiFlag,
 		utils.WSAllowedOriginsFlag,
+		utils.RPCJWTSecretFlag,
+		utils.RESTEnabledFlag,
+		utils.RESTListenAddrFlag,
+		utils.RESTPortFlag,
+		utils.RESTCORSDomainFlag,
 		utils.IPCDisabledFlag,
 		utils.IPCPathFlag,
 	}
@@ -45,6 +45,7 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.TestnetFlag,
 			
 			utils.GCModeFlag,
+			utils.CheckpointFlag,
 			utils.IdentityFlag,
 			
 		},
@@ -98,6 +99,11 @@ var AppHelpFlagGroups = []flagGroup{
 			utils.IPCPathFlag,
 			utils.RPCCORSDomainFlag,
 			utils.RPCVirtualHostsFlag,
+			utils.RPCJWTSecretFlag,
+			utils.RESTEnabledFlag,
+			utils.RESTListenAddrFlag,
+			utils.RESTPortFlag,
+			utils.RESTCORSDomainFlag,
 			utils.JSpathFlag,
 			utils.ExecFlag,
 			utils.PreloadJSFlag,
@@ -9,15 +9,18 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"time"
 	"github.com/DEL-ORG/del/accounts"
 	"github.com/DEL-ORG/del/accounts/keystore"
 	"github.com/DEL-ORG/del/common"
 	"github.com/DEL-ORG/del/common/fdlimit"
+	"github.com/DEL-ORG/del/common/math"
 	"github.com/DEL-ORG/del/consensus"
 	"github.com/DEL-ORG/del/consensus/clique"
 	"github.com/DEL-ORG/del/consensus/ethash"
 	"github.com/DEL-ORG/del/core"
 	"github.com/DEL-ORG/del/core/state"
+	"github.com/DEL-ORG/del/core/types"
 	"github.com/DEL-ORG/del/core/vm"
 	"github.com/DEL-ORG/del/crypto"
 	"github.com/DEL-ORG/del/dashboard"
@@ -38,6 +41,7 @@ import (
 	"github.com/DEL-ORG/del/params"
 	whisper "github.com/DEL-ORG/del/whisper/whisperv5"
 	"gopkg.in/urfave/cli.v1"
+	"github.com/DEL-ORG/del/restrpc/restapi"
 	"github.com/DEL-ORG/del/restrpc/rpcydoon"
 	"context"
 )
@@ -107,7 +111,7 @@ var (
 	}
 	DeveloperFlag = cli.BoolFlag{
 		Name:  "dev",
-		Usage: "Ephemeral proof-of-authority network with a pre-funded developer account, mining enabled",
+		Usage: "Ephemeral single-producer DPoS network with a pre-funded developer account, mining enabled",
 	}
 	DeveloperPeriodFlag = cli.IntFlag{
 		Name:  "dev.period",
@@ -136,6 +140,10 @@ var (
 		Usage: `Blockchain sync mode ("fast", "full", or "light")`,
 		Value: &defaultSyncMode,
 	}
+	CheckpointFlag = cli.StringFlag{
+		Name:  "checkpoint",
+		Usage: "Trusted checkpoint fast and light sync start from (<number>:<hash>:<td>)",
+	}
 	GCModeFlag = cli.StringFlag{
 		Name:  "gcmode",
 		Usage: `Blockchain garbage collection mode ("full", "archive")`,
@@ -179,6 +187,11 @@ var (
 		Usage: "Developer flag to serve the dashboard from the local file system",
 		Value: dashboard.DefaultConfig.Assets,
 	}
+	DashboardSlotsFlag = cli.IntFlag{
+		Name:  "dashboard.slots",
+		Usage: "Number of upcoming producer slots shown on the dashboard",
+		Value: dashboard.DefaultConfig.Slots,
+	}
 	EthashCacheDirFlag = DirectoryFlag{
 		Name:  "ethash.cachedir",
 		Usage: "Directory to store the ethash verification caches (default = inside the datadir)",
@@ -359,6 +372,29 @@ var (
 		Usage: "Comma separated list of virtual hostnames from which to accept requests (server enforced). Accepts '*' wildcard.",
 		Value: "localhost",
 	}
+	RPCJWTSecretFlag = cli.StringFlag{
+		Name:  "rpcjwtsecret",
+		Usage: "Path to a hex encoded HS256 secret; HTTP, WS and REST requests must then carry a JWT bearer token with an unexpired exp claim or an iat within 60s of the node clock",
+	}
+	RESTEnabledFlag = cli.BoolFlag{
+		Name:  "rest",
+		Usage: "Enable the HTTP REST server",
+	}
+	RESTListenAddrFlag = cli.StringFlag{
+		Name:  "rest.addr",
+		Usage: "HTTP REST server listening interface",
+		Value: restapi.DefaultConfig.Host,
+	}
+	RESTPortFlag = cli.IntFlag{
+		Name:  "rest.port",
+		Usage: "HTTP REST server listening port",
+		Value: restapi.DefaultConfig.Port,
+	}
+	RESTCORSDomainFlag = cli.StringFlag{
+		Name:  "rest.corsdomain",
+		Usage: "Comma separated list of domains from which to accept cross origin REST requests (browser enforced)",
+		Value: "*",
+	}
 	RPCApiFlag = cli.StringFlag{
 		Name:  "rpcapi",
 		Usage: "API's offered over the HTTP-RPC interface",
@@ -698,6 +734,28 @@ func setEtherbase(ctx *cli.Context, ks *keystore.KeyStore, cfg *eth.Config) {
 		cfg.Etherbase = account.Address
 	}
 }
+func setCheckpoint(ctx *cli.Context, cfg *eth.Config) {
+	if !ctx.GlobalIsSet(CheckpointFlag.Name) {
+		return
+	}
+	value := ctx.GlobalString(CheckpointFlag.Name)
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		Fatalf("Option %q: invalid checkpoint %q, want <number>:<hash>:<td>", CheckpointFlag.Name, value)
+	}
+	number, err := strconv.ParseUint(parts[0], 10, 64)
+	if err != nil {
+		Fatalf("Option %q: invalid checkpoint number: %v", CheckpointFlag.Name, err)
+	}
+	if len(common.FromHex(parts[1])) != common.HashLength {
+		Fatalf("Option %q: invalid checkpoint hash %q", CheckpointFlag.Name, parts[1])
+	}
+	td, ok := math.ParseBig256(parts[2])
+	if !ok || td.Sign() <= 0 {
+		Fatalf("Option %q: invalid checkpoint total difficulty %q", CheckpointFlag.Name, parts[2])
+	}
+	cfg.Checkpoint = &types.Checkpoint{Number: number, BlockHash: common.HexToHash(parts[1]), Td: td}
+}
 func MakePasswordList(ctx *cli.Context) []string {
 	path := ctx.GlobalString(PasswordFileFlag.Name)
 	if path == "" {
@@ -771,6 +829,9 @@ func SetNodeConfig(ctx *cli.Context, cfg *node.Config) {
 	setIPC(ctx, cfg)
 	setHTTP(ctx, cfg)
 	setWS(ctx, cfg)
+	if ctx.GlobalIsSet(RPCJWTSecretFlag.Name) {
+		cfg.JWTSecret = ctx.GlobalString(RPCJWTSecretFlag.Name)
+	}
 	setNodeUserIdent(ctx, cfg)
 	switch {
 	case ctx.GlobalIsSet(DataDirFlag.Name):
@@ -898,6 +959,7 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *eth.Config) {
 	setGPO(ctx, &cfg.GPO)
 	setTxPool(ctx, &cfg.TxPool)
 	setEthash(ctx, cfg)
+	setCheckpoint(ctx, cfg)
 	switch {
 	case ctx.GlobalIsSet(SyncModeFlag.Name):
 		cfg.SyncMode = *GlobalTextMarshaler(ctx, SyncModeFlag.Name).(*downloader.SyncMode)
@@ -969,7 +1031,12 @@ func SetEthConfig(ctx *cli.Context, stack *node.Node, cfg *eth.Config) {
 			Fatalf("Failed to unlock developer account: %v", err)
 		}
 		log.Info("Using developer account", "address", developer.Address)
-		cfg.Genesis = core.DeveloperGenesisBlock(uint64(ctx.GlobalInt(DeveloperPeriodFlag.Name)), developer.Address)
+		cfg.Genesis = core.DeveloperGenesisBlock(developer.Address)
+		cfg.Ethash.PowMode = ethash.ModeDev
+		cfg.Ethash.DevPeriod = time.Duration(ctx.GlobalInt(DeveloperPeriodFlag.Name)) * time.Second
+		if !ctx.GlobalIsSet(EtherbaseFlag.Name) {
+			cfg.Etherbase = developer.Address
+		}
 		if !ctx.GlobalIsSet(GasPriceFlag.Name) {
 			cfg.GasPrice = big.NewInt(1)
 		}
@@ -983,6 +1050,18 @@ func SetDashboardConfig(ctx *cli.Context, cfg *dashboard.Config) {
 	cfg.Port = ctx.GlobalInt(DashboardPortFlag.Name)
 	cfg.Refresh = ctx.GlobalDuration(DashboardRefreshFlag.Name)
 	cfg.Assets = ctx.GlobalString(DashboardAssetsFlag.Name)
+	cfg.Slots = ctx.GlobalInt(DashboardSlotsFlag.Name)
+}
+func SetRESTConfig(ctx *cli.Context, cfg *restapi.Config) {
+	if ctx.GlobalIsSet(RESTListenAddrFlag.Name) {
+		cfg.Host = ctx.GlobalString(RESTListenAddrFlag.Name)
+	}
+	if ctx.GlobalIsSet(RESTPortFlag.Name) {
+		cfg.Port = ctx.GlobalInt(RESTPortFlag.Name)
+	}
+	if ctx.GlobalIsSet(RESTCORSDomainFlag.Name) {
+		cfg.Cors = splitAndTrim(ctx.GlobalString(RESTCORSDomainFlag.Name))
+	}
 }
 func RegisterEthService(stack *node.Node, cfg *eth.Config) {
 	var err error
@@ -1006,9 +1085,28 @@ func RegisterEthService(stack *node.Node, cfg *eth.Config) {
 }
 func RegisterDashboardService(stack *node.Node, cfg *dashboard.Config, commit string) {
 	stack.Register(func(ctx *node.ServiceContext) (node.Service, error) {
-		return dashboard.New(cfg, commit)
+		var ethServ *eth.Ethereum
+		ctx.Service(&ethServ)
+		return dashboard.New(cfg, commit, ethServ)
 	})
 }
+func RegisterRESTService(stack *node.Node, cfg *restapi.Config) {
+	if err := stack.Register(func(ctx *node.ServiceContext) (node.Service, error) {
+		var ethServ *eth.Ethereum
+		ctx.Service(&ethServ)
+		var lesServ *les.LightEthereum
+		ctx.Service(&lesServ)
+		if ethServ != nil {
+			return restapi.New(cfg, ethServ.ApiBackend)
+		}
+		if lesServ != nil {
+			return restapi.New(cfg, lesServ.ApiBackend)
+		}
+		return restapi.New(cfg, nil)
+	}); err != nil {
+		Fatalf("Failed to register the REST service: %v", err)
+	}
+}
 func RegisterShhService(stack *node.Node, cfg *whisper.Config) {
 	if err := stack.Register(func(n *node.ServiceContext) (node.Service, error) {
 		return whisper.New(cfg), nil
@@ -7,3 +7,18 @@ type AbsTime time.Duration
 func Now() AbsTime {
 	return AbsTime(monotime.Now())
 }
+type Clock interface {
+	Now() time.Time
+	Sleep(d time.Duration)
+	After(d time.Duration) <-chan time.Time
+}
+type System struct{}
+func (System) Now() time.Time {
+	return time.Now()
+}
+func (System) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+func (System) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
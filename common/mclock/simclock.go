@@ -0,0 +1,76 @@
+package mclock
+import (
+	"sort"
+	"sync"
+	"time"
+)
+type Simulated struct {
+	now       time.Time
+	scheduled []event
+	mu        sync.RWMutex
+	cond      *sync.Cond
+}
+type event struct {
+	do func(now time.Time)
+	at time.Time
+}
+func NewSimulated(now time.Time) *Simulated {
+	s := &Simulated{now: now}
+	s.cond = sync.NewCond(&s.mu)
+	return s
+}
+func (s *Simulated) Run(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	end := s.now.Add(d)
+	for len(s.scheduled) > 0 {
+		ev := s.scheduled[0]
+		if ev.at.After(end) {
+			break
+		}
+		s.now = ev.at
+		s.scheduled = s.scheduled[1:]
+		ev.do(s.now)
+	}
+	s.now = end
+}
+func (s *Simulated) ActiveTimers() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.scheduled)
+}
+func (s *Simulated) WaitForTimers(n int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for len(s.scheduled) < n {
+		s.cond.Wait()
+	}
+}
+func (s *Simulated) Now() time.Time {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.now
+}
+func (s *Simulated) Sleep(d time.Duration) {
+	<-s.After(d)
+}
+func (s *Simulated) After(d time.Duration) <-chan time.Time {
+	after := make(chan time.Time, 1)
+	s.insert(d, func(now time.Time) {
+		after <- now
+	})
+	return after
+}
+func (s *Simulated) insert(d time.Duration, do func(now time.Time)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	at := s.now.Add(d)
+	l := len(s.scheduled)
+	i := sort.Search(l, func(i int) bool {
+		return s.scheduled[i].at.After(at)
+	})
+	s.scheduled = append(s.scheduled, event{})
+	copy(s.scheduled[i+1:], s.scheduled[i:l])
+	s.scheduled[i] = event{do: do, at: at}
+	s.cond.Broadcast()
+}
@@ -0,0 +1,55 @@
+package mclock
+import (
+	"testing"
+	"time"
+)
+func TestSimulatedAfter(t *testing.T) {
+	start := time.Unix(1530342720, 0)
+	clock := NewSimulated(start)
+	late := clock.After(10 * time.Second)
+	early := clock.After(5 * time.Second)
+	if clock.ActiveTimers() != 2 {
+		t.Fatalf("wrong active timers: have %d, want 2", clock.ActiveTimers())
+	}
+	clock.Run(5 * time.Second)
+	select {
+	case now := <-early:
+		if want := start.Add(5 * time.Second); !now.Equal(want) {
+			t.Errorf("early timer fired at %v, want %v", now, want)
+		}
+	default:
+		t.Fatal("early timer did not fire")
+	}
+	select {
+	case <-late:
+		t.Fatal("late timer fired too soon")
+	default:
+	}
+	clock.Run(time.Hour)
+	select {
+	case now := <-late:
+		if want := start.Add(10 * time.Second); !now.Equal(want) {
+			t.Errorf("late timer fired at %v, want %v", now, want)
+		}
+	default:
+		t.Fatal("late timer did not fire")
+	}
+	if want := start.Add(time.Hour + 5*time.Second); !clock.Now().Equal(want) {
+		t.Errorf("wrong time after run: have %v, want %v", clock.Now(), want)
+	}
+}
+func TestSimulatedSleep(t *testing.T) {
+	clock := NewSimulated(time.Unix(1530342720, 0))
+	done := make(chan struct{})
+	go func() {
+		clock.Sleep(5 * time.Second)
+		close(done)
+	}()
+	clock.WaitForTimers(1)
+	clock.Run(5 * time.Second)
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("sleep did not return after the clock advanced")
+	}
+}
@@ -1,6 +1,7 @@
 package common
 import (
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"math/big"
 	"math/rand"
@@ -17,7 +18,6 @@ const (
 var OFFICIAL = HexToAddress("0x24aa4f9961078c788c397dd27a71b0c5211c2931")
 const (
 	LEADER_LIMIT = 303
-	LEADER_NUMBER = LEADER_LIMIT
 	SUPER_COINBASE_RANK = 23
 	SLOT_BASE = 5
 	MINER_TIMEOUT = SLOT_BASE * 6
@@ -25,9 +25,17 @@ const (
 	RELEASE_NUMBER = 5184
 	RELEASE_TIMES = 1000
 	BLOCKS_PER_DAY = 17280
+	CHECKPOINT_ROUNDS = 12
+	GOVERNANCE_VOTING_ROUNDS = 3
+	GOVERNANCE_DELAY_ROUNDS = 1
+	ALIAS_EXPIRY_BLOCKS = BLOCKS_PER_DAY * 365
+	ALIAS_MIN_LENGTH = 3
+	ALIAS_MAX_LENGTH = 32
 )
+const	LEADER_NUMBER uint64 = LEADER_LIMIT
 var	ONE_COIN = new(big.Int).SetUint64(1e18)
 var	VOTE_MONEY_LIMIT = new(big.Int).Mul(ONE_COIN, big.NewInt(4096))
+var	ALIAS_FEE = new(big.Int).Set(ONE_COIN)
 const (
 	ClientIdentifier = "deld" 
 )
@@ -47,15 +55,53 @@ func GetCurrentSlot(t time.Time) (int64) {
 	slot := tstamp / SLOT_BASE
 	return slot
 }
+func GetChildSlot(t time.Time, parentTime *big.Int) (int64) {
+	slot := GetCurrentSlot(t)
+	if parent_slot := GetCurrentSlotByBigInt(parentTime); slot <= parent_slot {
+		slot = parent_slot + 1
+	}
+	return slot
+}
+func GetSlotTime(slot int64) time.Time {
+	return time.Unix(slot*SLOT_BASE, 0)
+}
 const (
 	DataProtocolMessageID_TEXT = 1000
 	DataProtocolMessageID_VOTE = 1001
 	DataProtocolMessageID_PARENT = 1002
+	DataProtocolMessageID_LOCK = 1003
+	DataProtocolMessageID_DELEGATE = 1004
+	DataProtocolMessageID_PROPOSE = 1005
+	DataProtocolMessageID_APPROVE = 1006
+	DataProtocolMessageID_ALIAS = 1007
 )
 const (
 	TXTYPE_TRANSFER = "transfer"
 	TXTYPE_TEXT = "text"
 	TXTYPE_VOTE = "vote"
+	TXTYPE_LOCK = "lock"
+	TXTYPE_DELEGATE = "delegate"
+	TXTYPE_PROPOSE = "propose"
+	TXTYPE_APPROVE = "approve"
+	TXTYPE_ALIAS = "alias"
+)
+const MaxDelegations = 32
+const MaxGovernanceTickets = 16
+var (
+	ErrTooManyDelegations  = errors.New("too many delegations")
+	ErrDelegationAddress   = errors.New("invalid delegation address")
+	ErrDelegationAmount    = errors.New("delegation amount must be positive")
+	ErrDelegationDuplicate = errors.New("duplicate delegation producer")
+	ErrEmptyProposal       = errors.New("proposal without parameter changes")
+	ErrTooManyChanges      = errors.New("too many parameter changes")
+	ErrProposalParam       = errors.New("invalid proposal parameter")
+	ErrProposalValue       = errors.New("proposal value must not be negative")
+	ErrProposalDuplicate   = errors.New("duplicate proposal parameter")
+	ErrEmptyApproval       = errors.New("approval without proposals")
+	ErrTooManyApprovals    = errors.New("too many approvals")
+	ErrApprovalID          = errors.New("proposal id must be positive")
+	ErrApprovalDuplicate   = errors.New("duplicate approval")
+	ErrAliasName           = errors.New("invalid alias name")
 )
 type DataProtocolVote struct {
 	Addr string `json:"addr" gencodec:"required"`
@@ -66,6 +112,7 @@ type DataProtocol struct {
 	MessageID uint16 `json:"message_id" gencodec:"required"`
 	Text *string `json:"text,omitempty" gencodec:"required"`
 	Tickets DataProtocolTickets `json:"tickets,omitempty" gencodec:"required"`
+	Vesting []VestingSchedule `json:"vesting,omitempty" rlp:"tail"`
 }
 func NewDataProtocol(data []byte) (ret *DataProtocol, err error) {
 	ret = &DataProtocol{}
@@ -92,6 +139,82 @@ func (self DataProtocolTickets)TotalAmount() (total *big.Int) {
 	}
 	return total
 }
+func (self DataProtocolTickets)ValidateDelegation() error {
+	if len(self) > MaxDelegations {
+		return ErrTooManyDelegations
+	}
+	seen := make(map[Address]struct{})
+	for _, ticket := range self {
+		if !IsHexAddress(ticket.Addr) {
+			return ErrDelegationAddress
+		}
+		if ticket.Amount == nil || ticket.Amount.Sign() <= 0 {
+			return ErrDelegationAmount
+		}
+		addr := HexToAddress(ticket.Addr)
+		if _, ok := seen[addr]; ok {
+			return ErrDelegationDuplicate
+		}
+		seen[addr] = struct{}{}
+	}
+	return nil
+}
+func (self DataProtocolTickets)ValidateProposal() error {
+	if len(self) == 0 {
+		return ErrEmptyProposal
+	}
+	if len(self) > MaxGovernanceTickets {
+		return ErrTooManyChanges
+	}
+	seen := make(map[string]struct{})
+	for _, ticket := range self {
+		if ticket.Addr == "" {
+			return ErrProposalParam
+		}
+		if ticket.Amount == nil || ticket.Amount.Sign() < 0 {
+			return ErrProposalValue
+		}
+		if _, ok := seen[ticket.Addr]; ok {
+			return ErrProposalDuplicate
+		}
+		seen[ticket.Addr] = struct{}{}
+	}
+	return nil
+}
+func (self DataProtocolTickets)ValidateApproval() error {
+	if len(self) == 0 {
+		return ErrEmptyApproval
+	}
+	if len(self) > MaxGovernanceTickets {
+		return ErrTooManyApprovals
+	}
+	seen := make(map[uint64]struct{})
+	for _, ticket := range self {
+		if ticket.Amount == nil || ticket.Amount.Sign() <= 0 || !ticket.Amount.IsUint64() {
+			return ErrApprovalID
+		}
+		id := ticket.Amount.Uint64()
+		if _, ok := seen[id]; ok {
+			return ErrApprovalDuplicate
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
+func ValidateAlias(name string) error {
+	if len(name) < ALIAS_MIN_LENGTH || len(name) > ALIAS_MAX_LENGTH {
+		return ErrAliasName
+	}
+	for i, c := range name {
+		switch {
+		case c >= 'a' && c <= 'z':
+		case i > 0 && (c >= '0' && c <= '9' || c == '-'):
+		default:
+			return ErrAliasName
+		}
+	}
+	return nil
+}
 func (self *DataProtocolVote)GetAmount() *big.Int{
 	if self.Amount == nil {
 		return big.NewInt(0)
@@ -101,23 +224,23 @@ func (self *DataProtocolVote)GetAmount() *big.Int{
 func (self *DataProtocol)GetTickets() []DataProtocolVote{
 	return self.Tickets
 }
-func GetRoundNumberByBlockNumber(number uint64) uint64 {
+func GetRoundNumberByBlockNumber(number uint64, length uint64) uint64 {
 	if number <= 0 {
 		return 0
 	}
-	number = (number - 1) / LEADER_NUMBER
+	number = (number - 1) / length
 	return number + 1
 }
-func GetBeginBlockNumberByRoundNumber(round_number uint64) (number uint64) {
-	end := GetEndBlockNumberByRoundNumber(round_number)
-	if end >= LEADER_NUMBER {
-		return end - LEADER_NUMBER + 1
+func GetBeginBlockNumberByRoundNumber(round_number uint64, length uint64) (number uint64) {
+	end := GetEndBlockNumberByRoundNumber(round_number, length)
+	if end >= length {
+		return end - length + 1
 	}else {
 		return 0
 	}
 }
-func GetEndBlockNumberByRoundNumber(round_number uint64) (number uint64) {
-	return round_number * LEADER_NUMBER
+func GetEndBlockNumberByRoundNumber(round_number uint64, length uint64) (number uint64) {
+	return round_number * length
 }
 var (
 	hashT    = reflect.TypeOf(Hash{})
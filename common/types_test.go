@@ -1,24 +1,27 @@
 package common
 import (
 	"encoding/json"
+	"fmt"
 	"math/big"
 	"strings"
 	"testing"
 )
 func TestGetRoundNumberByBlockNumber(t *testing.T) {
-	for i:=0; i < 10000; i++ {
-		expected_round := GetRoundNumberByBlockNumber(uint64(i))
-		begin := GetBeginBlockNumberByRoundNumber(expected_round)
-		end := GetEndBlockNumberByRoundNumber(expected_round)
-		if i > 0 {
-			if(end - begin + 1 != LEADER_NUMBER) {
-				t.Errorf("expected %d got %d", LEADER_NUMBER, end - begin +1)
+	for _, length := range []uint64{LEADER_NUMBER, 12, 1} {
+		for i:=0; i < 10000; i++ {
+			expected_round := GetRoundNumberByBlockNumber(uint64(i), length)
+			begin := GetBeginBlockNumberByRoundNumber(expected_round, length)
+			end := GetEndBlockNumberByRoundNumber(expected_round, length)
+			if i > 0 {
+				if(end - begin + 1 != length) {
+					t.Errorf("expected %d got %d", length, end - begin +1)
+				}
 			}
-		}
-		for blockNumber := begin; blockNumber <= end; blockNumber++ {
-			round := GetRoundNumberByBlockNumber(blockNumber)
-			if expected_round != round {
-				t.Errorf("expected %d got %d", expected_round, round)
+			for blockNumber := begin; blockNumber <= end; blockNumber++ {
+				round := GetRoundNumberByBlockNumber(blockNumber, length)
+				if expected_round != round {
+					t.Errorf("expected %d got %d", expected_round, round)
+				}
 			}
 		}
 	}
@@ -139,3 +142,87 @@ func BenchmarkAddressHex(b *testing.B) {
 		testAddr.Hex()
 	}
 }
+func TestValidateDelegation(t *testing.T) {
+	var (
+		alice = "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"
+		bob   = "0xfb6916095ca1df60bb79ce92ce3ea74c37c5d359"
+	)
+	tooMany := make(DataProtocolTickets, MaxDelegations+1)
+	tests := []struct {
+		tickets DataProtocolTickets
+		err     error
+	}{
+		{nil, nil},
+		{DataProtocolTickets{{alice, big.NewInt(1)}, {bob, big.NewInt(2)}}, nil},
+		{DataProtocolTickets{{"alice", big.NewInt(1)}}, ErrDelegationAddress},
+		{DataProtocolTickets{{alice, nil}}, ErrDelegationAmount},
+		{DataProtocolTickets{{alice, big.NewInt(0)}}, ErrDelegationAmount},
+		{DataProtocolTickets{{alice, big.NewInt(1)}, {strings.ToUpper(alice[2:]), big.NewInt(1)}}, ErrDelegationDuplicate},
+		{tooMany, ErrTooManyDelegations},
+	}
+	for i, tt := range tests {
+		if err := tt.tickets.ValidateDelegation(); err != tt.err {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
+func TestValidateGovernance(t *testing.T) {
+	tooMany := make(DataProtocolTickets, MaxGovernanceTickets+1)
+	for i := range tooMany {
+		tooMany[i] = DataProtocolVote{fmt.Sprintf("param%d", i), big.NewInt(int64(i + 1))}
+	}
+	proposals := []struct {
+		tickets DataProtocolTickets
+		err     error
+	}{
+		{nil, ErrEmptyProposal},
+		{DataProtocolTickets{{"gasLimitTarget", big.NewInt(0)}, {"feeBurn", big.NewInt(20)}}, nil},
+		{DataProtocolTickets{{"", big.NewInt(1)}}, ErrProposalParam},
+		{DataProtocolTickets{{"feeBurn", nil}}, ErrProposalValue},
+		{DataProtocolTickets{{"feeBurn", big.NewInt(-1)}}, ErrProposalValue},
+		{DataProtocolTickets{{"feeBurn", big.NewInt(1)}, {"feeBurn", big.NewInt(2)}}, ErrProposalDuplicate},
+		{tooMany, ErrTooManyChanges},
+	}
+	for i, tt := range proposals {
+		if err := tt.tickets.ValidateProposal(); err != tt.err {
+			t.Errorf("test %d: proposal error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+	approvals := []struct {
+		tickets DataProtocolTickets
+		err     error
+	}{
+		{nil, ErrEmptyApproval},
+		{DataProtocolTickets{{"", big.NewInt(1)}, {"", big.NewInt(2)}}, nil},
+		{DataProtocolTickets{{"", big.NewInt(0)}}, ErrApprovalID},
+		{DataProtocolTickets{{"", nil}}, ErrApprovalID},
+		{DataProtocolTickets{{"", big.NewInt(3)}, {"", big.NewInt(3)}}, ErrApprovalDuplicate},
+		{tooMany, ErrTooManyApprovals},
+	}
+	for i, tt := range approvals {
+		if err := tt.tickets.ValidateApproval(); err != tt.err {
+			t.Errorf("test %d: approval error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
+func TestValidateAlias(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+	}{
+		{"alice", nil},
+		{"bob-42", nil},
+		{"al", ErrAliasName},
+		{strings.Repeat("a", ALIAS_MAX_LENGTH+1), ErrAliasName},
+		{"Alice", ErrAliasName},
+		{"1alice", ErrAliasName},
+		{"-alice", ErrAliasName},
+		{"0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed", ErrAliasName},
+		{"ali ce", ErrAliasName},
+	}
+	for i, tt := range tests {
+		if err := ValidateAlias(tt.name); err != tt.err {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package common
+import (
+	"errors"
+	"math"
+	"math/big"
+)
+const (
+	MaxVestingSchedules = 16
+	MaxVestingTranches  = 64
+)
+var (
+	ErrVestingAmount   = errors.New("vesting amount must be positive")
+	ErrVestingPeriod   = errors.New("linear vesting needs a period and a release count")
+	ErrVestingOverflow = errors.New("vesting schedule exceeds the block range")
+	ErrVestingMixed    = errors.New("custom vesting tranches cannot be combined with a linear schedule")
+	ErrVestingTranches = errors.New("too many vesting tranches")
+	ErrVestingOrder    = errors.New("vesting tranches must be in ascending block order")
+)
+type VestingTranche struct {
+	Block  uint64   `json:"block"`
+	Amount *big.Int `json:"amount"`
+}
+type VestingSchedule struct {
+	Start    uint64           `json:"start"`
+	Cliff    uint64           `json:"cliff"`
+	Period   uint64           `json:"period"`
+	Releases uint64           `json:"releases"`
+	Amount   *big.Int         `json:"amount,omitempty"`
+	Tranches []VestingTranche `json:"tranches,omitempty"`
+}
+func (self *VestingSchedule) Custom() bool {
+	return len(self.Tranches) > 0
+}
+func (self *VestingSchedule) Total() *big.Int {
+	if !self.Custom() {
+		if self.Amount == nil {
+			return new(big.Int)
+		}
+		return new(big.Int).Set(self.Amount)
+	}
+	total := new(big.Int)
+	for _, tranche := range self.Tranches {
+		total.Add(total, tranche.Amount)
+	}
+	return total
+}
+func (self *VestingSchedule) Vested(number uint64) *big.Int {
+	if self.Custom() {
+		vested := new(big.Int)
+		for _, tranche := range self.Tranches {
+			if tranche.Block <= number {
+				vested.Add(vested, tranche.Amount)
+			}
+		}
+		return vested
+	}
+	if self.Period == 0 || self.Releases == 0 || number < self.Start+self.Cliff {
+		return new(big.Int)
+	}
+	steps := (number - self.Start) / self.Period
+	if steps >= self.Releases {
+		return self.Total()
+	}
+	vested := new(big.Int).Mul(self.Total(), new(big.Int).SetUint64(steps))
+	return vested.Div(vested, new(big.Int).SetUint64(self.Releases))
+}
+func (self *VestingSchedule) NextRelease(number uint64) (uint64, bool) {
+	if self.Custom() {
+		for _, tranche := range self.Tranches {
+			if tranche.Block > number {
+				return tranche.Block, true
+			}
+		}
+		return 0, false
+	}
+	if self.Period == 0 || self.Releases == 0 {
+		return 0, false
+	}
+	first := self.Start + self.Cliff
+	if step := self.Start + self.Period; step > first {
+		first = step
+	}
+	if number < first {
+		return first, true
+	}
+	steps := (number - self.Start) / self.Period
+	if steps >= self.Releases {
+		return 0, false
+	}
+	return self.Start + (steps+1)*self.Period, true
+}
+func (self *VestingSchedule) Validate() error {
+	if self.Custom() {
+		if self.Start != 0 || self.Cliff != 0 || self.Period != 0 || self.Releases != 0 {
+			return ErrVestingMixed
+		}
+		if len(self.Tranches) > MaxVestingTranches {
+			return ErrVestingTranches
+		}
+		for i, tranche := range self.Tranches {
+			if tranche.Amount == nil || tranche.Amount.Sign() <= 0 {
+				return ErrVestingAmount
+			}
+			if i > 0 && tranche.Block <= self.Tranches[i-1].Block {
+				return ErrVestingOrder
+			}
+		}
+		if self.Amount != nil && self.Amount.Cmp(self.Total()) != 0 {
+			return ErrVestingMixed
+		}
+		return nil
+	}
+	if self.Amount == nil || self.Amount.Sign() <= 0 {
+		return ErrVestingAmount
+	}
+	if self.Period == 0 || self.Releases == 0 {
+		return ErrVestingPeriod
+	}
+	if self.Cliff > math.MaxUint64-self.Start || self.Releases > (math.MaxUint64-self.Start)/self.Period {
+		return ErrVestingOverflow
+	}
+	return nil
+}
+func VestingTotal(schedules []VestingSchedule) *big.Int {
+	total := new(big.Int)
+	for i := range schedules {
+		total.Add(total, schedules[i].Total())
+	}
+	return total
+}
@@ -0,0 +1,106 @@
+package common
+import (
+	"math/big"
+	"testing"
+	"github.com/DEL-ORG/del/rlp"
+)
+func TestVestingSchedule(t *testing.T) {
+	var (
+		linear = VestingSchedule{Start: 100, Period: 10, Releases: 4, Amount: big.NewInt(1000)}
+		cliff  = VestingSchedule{Start: 100, Cliff: 25, Period: 10, Releases: 4, Amount: big.NewInt(1000)}
+		single = VestingSchedule{Start: 100, Cliff: 50, Period: 50, Releases: 1, Amount: big.NewInt(1000)}
+		custom = VestingSchedule{Tranches: []VestingTranche{{5, big.NewInt(1)}, {20, big.NewInt(2)}}}
+	)
+	tests := []struct {
+		schedule VestingSchedule
+		number   uint64
+		vested   int64
+		next     uint64
+		ok       bool
+	}{
+		{linear, 0, 0, 110, true},
+		{linear, 109, 0, 110, true},
+		{linear, 110, 250, 120, true},
+		{linear, 135, 750, 140, true},
+		{linear, 140, 1000, 0, false},
+		{cliff, 110, 0, 125, true},
+		{cliff, 124, 0, 125, true},
+		{cliff, 125, 500, 130, true},
+		{cliff, 130, 750, 140, true},
+		{single, 149, 0, 150, true},
+		{single, 150, 1000, 0, false},
+		{custom, 0, 0, 5, true},
+		{custom, 5, 1, 20, true},
+		{custom, 19, 1, 20, true},
+		{custom, 20, 3, 0, false},
+	}
+	for i, tt := range tests {
+		if vested := tt.schedule.Vested(tt.number); vested.Int64() != tt.vested {
+			t.Errorf("test %d: vested mismatch at %d: have %v, want %d", i, tt.number, vested, tt.vested)
+		}
+		if next, ok := tt.schedule.NextRelease(tt.number); next != tt.next || ok != tt.ok {
+			t.Errorf("test %d: next release mismatch at %d: have (%d, %v), want (%d, %v)", i, tt.number, next, ok, tt.next, tt.ok)
+		}
+	}
+	if total := custom.Total(); total.Int64() != 3 {
+		t.Errorf("custom total mismatch: have %v, want 3", total)
+	}
+}
+func TestVestingScheduleValidate(t *testing.T) {
+	tests := []struct {
+		schedule VestingSchedule
+		err      error
+	}{
+		{VestingSchedule{Period: 1, Releases: 1, Amount: big.NewInt(1)}, nil},
+		{VestingSchedule{Period: 1, Releases: 1}, ErrVestingAmount},
+		{VestingSchedule{Period: 1, Releases: 1, Amount: big.NewInt(-1)}, ErrVestingAmount},
+		{VestingSchedule{Releases: 1, Amount: big.NewInt(1)}, ErrVestingPeriod},
+		{VestingSchedule{Period: 1, Amount: big.NewInt(1)}, ErrVestingPeriod},
+		{VestingSchedule{Start: 1 << 63, Period: 1 << 62, Releases: 3, Amount: big.NewInt(1)}, ErrVestingOverflow},
+		{VestingSchedule{Start: 1 << 63, Cliff: 1 << 63, Period: 1, Releases: 1, Amount: big.NewInt(1)}, ErrVestingOverflow},
+		{VestingSchedule{Tranches: []VestingTranche{{1, big.NewInt(1)}, {2, big.NewInt(1)}}}, nil},
+		{VestingSchedule{Amount: big.NewInt(2), Tranches: []VestingTranche{{1, big.NewInt(1)}, {2, big.NewInt(1)}}}, nil},
+		{VestingSchedule{Amount: big.NewInt(3), Tranches: []VestingTranche{{1, big.NewInt(1)}, {2, big.NewInt(1)}}}, ErrVestingMixed},
+		{VestingSchedule{Period: 1, Tranches: []VestingTranche{{1, big.NewInt(1)}}}, ErrVestingMixed},
+		{VestingSchedule{Tranches: []VestingTranche{{2, big.NewInt(1)}, {2, big.NewInt(1)}}}, ErrVestingOrder},
+		{VestingSchedule{Tranches: []VestingTranche{{1, big.NewInt(0)}}}, ErrVestingAmount},
+		{VestingSchedule{Tranches: make([]VestingTranche, MaxVestingTranches+1)}, ErrVestingTranches},
+	}
+	for i, tt := range tests {
+		if err := tt.schedule.Validate(); err != tt.err {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
+func TestDataProtocolVestingEncoding(t *testing.T) {
+	text := "hello"
+	legacy := struct {
+		MessageID uint16
+		Text      *string
+		Tickets   DataProtocolTickets
+	}{DataProtocolMessageID_TEXT, &text, nil}
+	enc, err := rlp.EncodeToBytes(legacy)
+	if err != nil {
+		t.Fatal(err)
+	}
+	message, err := NewDataProtocol(enc)
+	if err != nil {
+		t.Fatalf("failed to decode legacy message: %v", err)
+	}
+	if len(message.Vesting) != 0 || *message.Text != text {
+		t.Errorf("legacy message mismatch: %+v", message)
+	}
+	if reenc, _ := message.Encode(); string(reenc) != string(enc) {
+		t.Errorf("legacy message encoding changed: have %x, want %x", reenc, enc)
+	}
+	lock := &DataProtocol{MessageID: DataProtocolMessageID_LOCK, Vesting: []VestingSchedule{{Start: 1, Period: 2, Releases: 3, Amount: big.NewInt(4)}}}
+	if enc, err = lock.Encode(); err != nil {
+		t.Fatal(err)
+	}
+	if message, err = NewDataProtocol(enc); err != nil {
+		t.Fatalf("failed to decode lock message: %v", err)
+	}
+	if len(message.Vesting) != 1 || message.Vesting[0].Releases != 3 || message.Vesting[0].Amount.Int64() != 4 {
+		t.Errorf("lock message mismatch: %+v", message.Vesting)
+	}
+}
@@ -14,8 +14,10 @@ type ChainReader interface {
 	GetHeaderByNumber(number uint64) *types.Header
 	GetHeaderByHash(hash common.Hash) *types.Header
 	GetBlock(hash common.Hash, number uint64) *types.Block
+	GetReceiptsByHash(hash common.Hash) types.Receipts
 	GetVoters(header *types.Header) types.Voters
 	GetVotersState(header *types.Header) types.VotersMap
+	GetRandaoMix(header *types.Header) (common.Hash, error)
 	GetGenesisBlock() *types.Block
 }
 type Engine interface {
@@ -36,6 +36,7 @@ var (
 	errInvalidPoW        = errors.New("invalid proof-of-work")
 	errInvalidProducer       = errors.New("invalid producer")
 	errFailedToLoadHeader        = errors.New("failed to load header")
+	errNoProducers       = errors.New("no producers scheduled")
 )
 func (ethash *Ethash) Author(header *types.Header) (common.Address, error) {
 	return header.Coinbase, nil
@@ -160,11 +161,14 @@ func (ethash *Ethash) VerifyProducers(chain consensus.ChainReader, block *types.
 	if block_hash != expected_hash {
 		return fmt.Errorf("Miscalculation of producers", "producers_hash", block_hash, "expected", expected_hash)
 	}
+	if len(producers) == 0 {
+		return errNoProducers
+	}
 	slot := common.GetCurrentSlotByBigInt(block.Time())
 	producer := producers[slot % int64(len(producers))]
 	if producer.Empty() || block.Header().Coinbase != producer.Addr {
 		parent_header := chain.GetHeader(block.Header().ParentHash, block.Number().Uint64() - 1)
-		if block.Time().Int64() - parent_header.Time.Int64() < common.MINER_TIMEOUT {
+		if chain.Config().IsProducerCycle(block.Number()) || block.Time().Int64() - parent_header.Time.Int64() < common.MINER_TIMEOUT {
 			return fmt.Errorf("producer mismatch: have %s, want %s", block.Header().Coinbase.Hex(), producer.Addr.Hex())
 		} else {
 			genesis_body := chain.GetGenesisBlock()
@@ -227,14 +231,14 @@ func (ethash *Ethash) verifyHeader(chain consensus.ChainReader, header, parent *
 			return errLargeBlockTime
 		}
 	} else {
-		if header.Time.Cmp(big.NewInt(time.Now().Add(allowedFutureBlockTime).Unix())) > 0 {
+		if header.Time.Cmp(big.NewInt(ethash.Clock().Now().Add(allowedFutureBlockTime).Unix())) > 0 {
 			return consensus.ErrFutureBlock
 		}
 	}
 	if header.Time.Cmp(parent.Time) < 0 {
 		return errZeroBlockTime
 	}
-	if header.Time.Cmp(parent.Time) == 0 {
+	if header.Time.Cmp(parent.Time) == 0 && ethash.config.PowMode != ModeDev {
 		log.Error("Zero blockTime", "header", header.Hash().Hex(), "number", header.Number.Uint64())
 	}
 	slot := common.GetCurrentSlotByBigInt(parent.Time)
@@ -441,13 +445,24 @@ func (ethash *Ethash) CalProducersWithoutParent(chain consensus.ChainReader, hea
 	if votersMap != nil {
 		vproducers = votersMap.GetProducers()
 	}
+	next := new(big.Int).Add(header.Number, common.Big1)
+	cycle := chain.Config().IsProducerCycle(next)
+	if len(vproducers) <= 0 && cycle {
+		if block := chain.GetBlock(header.Hash(), header.Number.Uint64()); block != nil {
+			vproducers = block.Producers().GetWithOutEmpty().Distinct()
+		}
+	}
 	if vproducers == nil || len(vproducers) <= 0 {
 		genesis_header := chain.GetHeaderByNumber(0)
 		vproducers = append(vproducers, types.Producer{Addr: genesis_header.Coinbase, Vote: common.Big0})
 	}
 	if len(vproducers) > common.LEADER_LIMIT {
 		producers = vproducers[0:common.LEADER_LIMIT]
-	}else {
+	} else if cycle {
+		for i := 0; i < common.LEADER_LIMIT; i++ {
+			producers = append(producers, vproducers[i%len(vproducers)])
+		}
+	} else {
 		l := len(vproducers)
 		jmp := (common.LEADER_LIMIT - l) / l
 		remain := common.LEADER_LIMIT - l*jmp - l
@@ -469,10 +484,17 @@ func (ethash *Ethash) CalProducersWithoutParent(chain consensus.ChainReader, hea
 	if len(producers) != common.LEADER_LIMIT {
 		log.Crit("len(producers) error", "len", len(producers))
 	}
+	if chain.Config().IsRandao(next) {
+		mix, err := chain.GetRandaoMix(header)
+		if err != nil {
+			return nil, err
+		}
+		producers = producers.Shuffle(types.RandaoSeed(mix, common.GetRoundNumberByBlockNumber(next.Uint64(), chain.Config().GetRoundLength())))
+	}
 	return producers, nil
 }
 func (ethash *Ethash) CalProducers(chain consensus.ChainReader, header *types.Header) (producers types.Producers, err error) {
-	round := common.GetRoundNumberByBlockNumber(header.Number.Uint64())
+	round := common.GetRoundNumberByBlockNumber(header.Number.Uint64(), chain.Config().GetRoundLength())
 	if header.Number.Uint64() <= 0 || round <= 1 {
 		genesis_header := chain.GetHeaderByNumber(0)
 		genesis_block := chain.GetBlock(genesis_header.Hash(), 0)
@@ -482,7 +504,7 @@ func (ethash *Ethash) CalProducers(chain consensus.ChainReader, header *types.He
 	if parent_header == nil {
 		return nil, consensus.ErrUnknownAncestor
 	}
-	begin_block_number := common.GetBeginBlockNumberByRoundNumber(round)
+	begin_block_number := common.GetBeginBlockNumberByRoundNumber(round, chain.Config().GetRoundLength())
 	if begin_block_number != header.Number.Uint64() {
 		parent_block := chain.GetBlock(parent_header.Hash(), header.Number.Uint64() - 1)
 		return parent_block.Producers(), nil
@@ -5,6 +5,7 @@ import (
 	"os"
 	"path/filepath"
 	"testing"
+	"github.com/DEL-ORG/del/common"
 	"github.com/DEL-ORG/del/common/math"
 	"github.com/DEL-ORG/del/core/types"
 	"github.com/DEL-ORG/del/params"
@@ -87,3 +88,49 @@ func TestEthash_CalProducers(t *testing.T) {
 		}
 	}
 }
+func TestEthash_CalProducersCycle(t *testing.T) {
+	var (
+		one    = common.Address{0x01}
+		two    = common.Address{0x02}
+		parent = &types.Header{Number: new(big.Int).SetUint64(common.LEADER_NUMBER), Coinbase: common.Address{0xff}}
+	)
+	previous := types.Producers{}
+	for i := 0; i < common.LEADER_LIMIT; i++ {
+		previous = append(previous, types.Producer{Addr: []common.Address{one, two}[i%2], Vote: big.NewInt(1)})
+	}
+	tests := []struct {
+		voters types.VotersMap
+		block  *types.Block
+		counts map[common.Address]int
+	}{
+		{types.VotersMap{one: big.NewInt(2), two: big.NewInt(1)}, nil, map[common.Address]int{one: 152, two: 151}},
+		{types.VotersMap{two: big.NewInt(1)}, nil, map[common.Address]int{two: common.LEADER_LIMIT}},
+		{nil, types.NewBlockWithHeader(parent).WithBody(nil, nil, previous, nil), map[common.Address]int{one: 152, two: 151}},
+	}
+	ethash := NewFaker()
+	for i, tt := range tests {
+		chain := &testChain{parent: parent, block: tt.block, voters: tt.voters}
+		producers, err := ethash.CalProducersWithoutParent(chain, parent)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		if len(producers) != common.LEADER_LIMIT {
+			t.Fatalf("test %d: schedule length mismatch: have %d, want %d", i, len(producers), common.LEADER_LIMIT)
+		}
+		counts := make(map[common.Address]int)
+		for _, producer := range producers {
+			if producer.Empty() {
+				t.Fatalf("test %d: empty slot in schedule", i)
+			}
+			counts[producer.Addr]++
+		}
+		if len(counts) != len(tt.counts) {
+			t.Errorf("test %d: producer set mismatch: have %v, want %v", i, counts, tt.counts)
+		}
+		for addr, want := range tt.counts {
+			if counts[addr] != want {
+				t.Errorf("test %d: slot count mismatch for %x: have %d, want %d", i, addr, counts[addr], want)
+			}
+		}
+	}
+}
@@ -14,6 +14,7 @@ import (
 	"time"
 	"unsafe"
 	"github.com/edsrzf/mmap-go"
+	"github.com/DEL-ORG/del/common/mclock"
 	"github.com/DEL-ORG/del/consensus"
 	"github.com/DEL-ORG/del/log"
 	"github.com/DEL-ORG/del/rpc"
@@ -24,7 +25,7 @@ import (
 var ErrInvalidDumpMagic = errors.New("invalid dump magic")
 var (
 	maxUint256 = new(big.Int).Exp(big.NewInt(2), big.NewInt(256), big.NewInt(0))
-	sharedEthash = New(Config{"", 3, 0, "", 1, 0, ModeNormal})
+	sharedEthash = New(Config{"", 3, 0, "", 1, 0, ModeNormal, 0, nil})
 	algorithmRevision = 23
 	dumpMagic = []uint32{0xbaddcafe, 0xfee1dead}
 )
@@ -266,6 +267,7 @@ const (
 	ModeTest
 	ModeFake
 	ModeFullFake
+	ModeDev
 )
 type Config struct {
 	CacheDir       string
@@ -275,6 +277,8 @@ type Config struct {
 	DatasetsInMem  int
 	DatasetsOnDisk int
 	PowMode        Mode
+	DevPeriod      time.Duration
+	Clock          mclock.Clock `toml:"-"`
 }
 type SignerFn func(accounts.Account, []byte) ([]byte, error)
 type Ethash struct {
@@ -307,6 +311,15 @@ func New(config Config) *Ethash {
 		hashrate: metrics.NewMeter(),
 	}
 }
+func (ethash *Ethash) Clock() mclock.Clock {
+	if ethash.config.Clock == nil {
+		return mclock.System{}
+	}
+	return ethash.config.Clock
+}
+func (ethash *Ethash) Instant() bool {
+	return ethash.config.PowMode == ModeDev
+}
 func NewTester() *Ethash {
 	return New(Config{CachesInMem: 1, PowMode: ModeTest})
 }
@@ -333,6 +346,9 @@ func NewFakeDelayer(delay time.Duration) *Ethash {
 		fakeDelay: delay,
 	}
 }
+func NewDeveloper(period time.Duration, clock mclock.Clock) *Ethash {
+	return New(Config{CachesInMem: 1, PowMode: ModeDev, DevPeriod: period, Clock: clock})
+}
 func NewFullFaker() *Ethash {
 	return &Ethash{
 		config: Config{
@@ -91,8 +91,8 @@ func GetCoinbaseReward(chain consensus.ChainReader, number uint64, address commo
 	if reward == nil {
 		return common.Big0
 	}
-	round_number := common.GetRoundNumberByBlockNumber(number)
-	block_number := common.GetBeginBlockNumberByRoundNumber(round_number)
+	round_number := common.GetRoundNumberByBlockNumber(number, chain.Config().GetRoundLength())
+	block_number := common.GetBeginBlockNumberByRoundNumber(round_number, chain.Config().GetRoundLength())
 	producer_header := chain.GetHeaderByNumber(block_number)
 	if producer_header == nil {
 		log.Error("Can not find header", "number", block_number)
@@ -135,8 +135,8 @@ func GetSuperCoinbaseReward(chain consensus.ChainReader, number uint64, address
 	if reward == nil {
 		return common.Big0
 	}
-	round_number := common.GetRoundNumberByBlockNumber(number)
-	block_number := common.GetBeginBlockNumberByRoundNumber(round_number)
+	round_number := common.GetRoundNumberByBlockNumber(number, chain.Config().GetRoundLength())
+	block_number := common.GetBeginBlockNumberByRoundNumber(round_number, chain.Config().GetRoundLength())
 	producer_header := chain.GetHeaderByNumber(block_number)
 	if producer_header == nil {
 		log.Error("Can not find header", "number", block_number)
@@ -187,10 +187,10 @@ func GetSuperCoinbaseReward(chain consensus.ChainReader, number uint64, address
 }
 func accumulateRewards(chain consensus.ChainReader, state *state.StateDB, header *types.Header, producers types.Producers, voters types.Voters) {
 	reward := GetRewardByNumber(header.Number.Uint64())
-	current_round_number := common.GetRoundNumberByBlockNumber(header.Number.Uint64())
-	current_round_end_block_number := common.GetEndBlockNumberByRoundNumber(current_round_number)
+	current_round_number := common.GetRoundNumberByBlockNumber(header.Number.Uint64(), chain.Config().GetRoundLength())
+	current_round_end_block_number := common.GetEndBlockNumberByRoundNumber(current_round_number, chain.Config().GetRoundLength())
 	if header.Number.Uint64() == current_round_end_block_number {
-		current_round_begin_block_number := common.GetBeginBlockNumberByRoundNumber(current_round_number)
+		current_round_begin_block_number := common.GetBeginBlockNumberByRoundNumber(current_round_number, chain.Config().GetRoundLength())
 		total := 0
 		for i := 0; i < len(producers); i++ {
 			if producers[i].Empty() {
@@ -24,7 +24,7 @@ var (
 func (ethash *Ethash) Seal(chain consensus.ChainReader, block *types.Block, stop <-chan struct{}) (*types.Block, error) {
 	if ethash.config.PowMode == ModeFake || ethash.config.PowMode == ModeFullFake {
 		header := block.Header()
-		header.Nonce, header.MixDigest = types.BlockNonce{}, common.Hash{}
+		header.Nonce = types.BlockNonce{}
 		return block.WithSeal(header), nil
 	}
 	if ethash.shared != nil {
@@ -51,7 +51,11 @@ func (ethash *Ethash) Seal(chain consensus.ChainReader, block *types.Block, stop
 	}
 	var pend sync.WaitGroup
 	
-	go ethash.mine_pos(chain, block, abort, found)
+	if ethash.config.PowMode == ModeDev {
+		go ethash.mine_dev(block, abort, found)
+	} else {
+		go ethash.mine_pos(chain, block, abort, found)
+	}
 	var result *types.Block
 	select {
 	case <-stop:
@@ -84,35 +88,60 @@ func (ethash *Ethash) mine_pos(chain consensus.ChainReader, block *types.Block,
 		return
 	}
 	parent := chain.GetHeader(header.ParentHash, header.Number.Uint64() - 1)
-	parent_slot := common.GetCurrentSlotByBigInt(parent.Time)
-search:
-	for {
+	if parent == nil {
+		logger.Error("Unknown parent", "number", header.Number.Uint64())
+		return
+	}
+	clock := ethash.Clock()
+	slot, ok := producers.NextSlot(block.Coinbase(), common.GetChildSlot(clock.Now(), parent.Time))
+	if !ok {
+		logger.Trace("Coinbase not scheduled.", "coinbase", block.Coinbase())
+		return
+	}
+	start := common.GetSlotTime(slot)
+	header.Time = big.NewInt(start.Unix())
+	header.Difficulty = ethash.CalcDifficulty(chain, header, block.Transactions())
+	if wait := start.Sub(clock.Now()); wait > 0 {
 		select {
 		case <-abort:
-			logger.Trace("Pos block.")
-			break search
-		default:
-			cstart := time.Now()
-			header.Time = big.NewInt(cstart.Unix())
-			header.Difficulty = ethash.CalcDifficulty(chain, header, block.Transactions())
-			slot := common.GetCurrentSlotByBigInt(header.Time)
-			if slot <= parent_slot {
-				time.Sleep(200 * time.Millisecond)
-				continue
-			}
-			leader_num := slot % int64(len(producers))
-			leader := producers[leader_num]
-			if leader.Empty() || leader.Addr != block.Coinbase() {
-				time.Sleep(200 * time.Millisecond)
-				continue
-			}
-			select {
-			case found <- block.WithSeal(header):
-				logger.Trace("Ethash nonce found and reported ","slot", slot)
-			case <-abort:
-				logger.Trace("Ethash nonce found but discarded ","slot", slot)
-			}
-			break search
+			logger.Trace("Pos block aborted.", "slot", slot)
+			return
+		case <-clock.After(wait):
 		}
 	}
+	select {
+	case found <- block.WithSeal(header):
+		logger.Trace("Ethash nonce found and reported ","slot", slot)
+	case <-abort:
+		logger.Trace("Ethash nonce found but discarded ","slot", slot)
+	}
+}
+func (ethash *Ethash) mine_dev(block *types.Block, abort chan struct{}, found chan *types.Block) {
+	logger := log.New("miner:dev")
+	if delay := time.Unix(block.Time().Int64(), 0).Sub(ethash.Clock().Now()); delay > 0 {
+		select {
+		case <-abort:
+			logger.Trace("Dev block aborted before its time.", "number", block.Number())
+			return
+		case <-ethash.Clock().After(delay):
+		}
+	}
+	if len(block.Transactions()) == 0 {
+		var period <-chan time.Time
+		if ethash.config.DevPeriod > 0 {
+			period = ethash.Clock().After(ethash.config.DevPeriod)
+		}
+		select {
+		case <-abort:
+			logger.Trace("Dev block aborted, no transactions.", "number", block.Number())
+			return
+		case <-period:
+		}
+	}
+	select {
+	case found <- block.WithSeal(block.Header()):
+		logger.Trace("Dev block sealed.", "number", block.Number(), "txs", len(block.Transactions()))
+	case <-abort:
+		logger.Trace("Dev block sealed but discarded.", "number", block.Number())
+	}
 }
@@ -0,0 +1,206 @@
+package ethash
+import (
+	"math/big"
+	"testing"
+	"time"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/common/mclock"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/params"
+)
+type testChain struct {
+	parent *types.Header
+	block  *types.Block
+	voters types.VotersMap
+}
+func (c *testChain) Config() *params.ChainConfig  { return params.TestChainConfig }
+func (c *testChain) CurrentHeader() *types.Header { return c.parent }
+func (c *testChain) GetHeaderByNumber(number uint64) *types.Header {
+	return c.GetHeader(common.Hash{}, number)
+}
+func (c *testChain) GetHeaderByHash(hash common.Hash) *types.Header {
+	return c.GetHeader(hash, c.parent.Number.Uint64())
+}
+func (c *testChain) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if number == c.parent.Number.Uint64() {
+		return c.parent
+	}
+	return nil
+}
+func (c *testChain) GetBlock(hash common.Hash, number uint64) *types.Block { return c.block }
+func (c *testChain) GetReceiptsByHash(hash common.Hash) types.Receipts     { return nil }
+func (c *testChain) GetVoters(header *types.Header) types.Voters           { return nil }
+func (c *testChain) GetVotersState(header *types.Header) types.VotersMap   { return c.voters }
+func (c *testChain) GetRandaoMix(header *types.Header) (common.Hash, error) { return common.Hash{}, nil }
+func (c *testChain) GetGenesisBlock() *types.Block                         { return nil }
+func newSealTest(start time.Time, leaderSlot int64) (*testChain, *types.Block) {
+	var (
+		addrs     = []common.Address{{0x01}, {0x02}, {0x03}}
+		producers types.Producers
+	)
+	for i := 0; i < common.LEADER_LIMIT; i++ {
+		producers = append(producers, types.Producer{Addr: addrs[i%len(addrs)], Vote: new(big.Int)})
+	}
+	parent := &types.Header{Number: big.NewInt(1), Time: big.NewInt(start.Unix())}
+	header := &types.Header{
+		ParentHash: parent.Hash(),
+		Number:     big.NewInt(2),
+		Coinbase:   producers[leaderSlot%int64(len(producers))].Addr,
+	}
+	return &testChain{parent: parent}, types.NewBlock(header, nil, nil, nil, producers, nil)
+}
+func seal(t *testing.T, engine *Ethash, chain *testChain, block *types.Block) <-chan *types.Block {
+	results := make(chan *types.Block, 1)
+	go func() {
+		result, err := engine.Seal(chain, block, nil)
+		if err != nil {
+			t.Errorf("failed to seal block: %v", err)
+		}
+		results <- result
+	}()
+	return results
+}
+func TestSealWaitsForSlot(t *testing.T) {
+	var (
+		parentSlot   = common.GetCurrentSlot(time.Unix(common.GENESIS_TIME, 0)) + 1
+		leaderSlot   = parentSlot + 3
+		start        = common.GetSlotTime(parentSlot)
+		leaderStart  = common.GetSlotTime(leaderSlot)
+		clock        = mclock.NewSimulated(start)
+		chain, block = newSealTest(start, leaderSlot)
+	)
+	results := seal(t, New(Config{PowMode: ModeNormal, Clock: clock}), chain, block)
+	clock.WaitForTimers(1)
+	clock.Run(leaderStart.Sub(start) - time.Millisecond)
+	select {
+	case <-results:
+		t.Fatal("block sealed before the producer slot")
+	case <-time.After(50 * time.Millisecond):
+	}
+	clock.Run(time.Millisecond)
+	select {
+	case result := <-results:
+		if result == nil {
+			t.Fatal("no block sealed")
+		}
+		if have := result.Time().Int64(); have != leaderStart.Unix() {
+			t.Errorf("sealed block time mismatch: have %d, want %d", have, leaderStart.Unix())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("block not sealed after the clock reached the producer slot")
+	}
+}
+func TestSealInStartedSlot(t *testing.T) {
+	var (
+		parentSlot   = common.GetCurrentSlot(time.Unix(common.GENESIS_TIME, 0)) + 1
+		leaderSlot   = parentSlot + 1
+		leaderStart  = common.GetSlotTime(leaderSlot)
+		clock        = mclock.NewSimulated(leaderStart.Add(2 * time.Second))
+		chain, block = newSealTest(common.GetSlotTime(parentSlot), leaderSlot)
+	)
+	select {
+	case result := <-seal(t, New(Config{PowMode: ModeNormal, Clock: clock}), chain, block):
+		if have := result.Time().Int64(); have != leaderStart.Unix() {
+			t.Errorf("sealed block time mismatch: have %d, want %d", have, leaderStart.Unix())
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("block not sealed inside the producer slot")
+	}
+}
+func TestSealDevWithTransactions(t *testing.T) {
+	var (
+		start    = time.Unix(common.GENESIS_TIME, 0)
+		chain, _ = newSealTest(start, 0)
+		header   = &types.Header{Number: big.NewInt(2), Time: big.NewInt(start.Unix())}
+		tx       = types.NewTransaction(0, common.Address{0x01}, big.NewInt(1), 0, big.NewInt(1), nil)
+		block    = types.NewBlock(header, []*types.Transaction{tx}, nil, nil, nil, nil)
+		engine   = NewDeveloper(time.Hour, mclock.NewSimulated(start))
+	)
+	select {
+	case result := <-seal(t, engine, chain, block):
+		if result == nil || result.Hash() != block.Hash() {
+			t.Fatal("sealed block mismatch")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("block with transactions not sealed immediately")
+	}
+}
+func TestSealDevWaitsForBlockTime(t *testing.T) {
+	var (
+		start    = time.Unix(common.GENESIS_TIME, 0)
+		clock    = mclock.NewSimulated(start)
+		chain, _ = newSealTest(start, 0)
+		header   = &types.Header{Number: big.NewInt(2), Time: big.NewInt(start.Unix() + common.SLOT_BASE)}
+		tx       = types.NewTransaction(0, common.Address{0x01}, big.NewInt(1), 0, big.NewInt(1), nil)
+		block    = types.NewBlock(header, []*types.Transaction{tx}, nil, nil, nil, nil)
+	)
+	results := seal(t, NewDeveloper(time.Hour, clock), chain, block)
+	clock.WaitForTimers(1)
+	clock.Run(common.SLOT_BASE*time.Second - time.Millisecond)
+	select {
+	case <-results:
+		t.Fatal("block sealed ahead of the clock")
+	case <-time.After(50 * time.Millisecond):
+	}
+	clock.Run(time.Millisecond)
+	select {
+	case result := <-results:
+		if result == nil || result.Hash() != block.Hash() {
+			t.Fatal("sealed block mismatch")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("block not sealed once the clock reached its time")
+	}
+}
+func TestSealDevEmptyWaitsForPeriod(t *testing.T) {
+	var (
+		start    = time.Unix(common.GENESIS_TIME, 0)
+		clock    = mclock.NewSimulated(start)
+		chain, _ = newSealTest(start, 0)
+		block    = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(2), Time: big.NewInt(start.Unix())})
+	)
+	results := seal(t, NewDeveloper(10*time.Second, clock), chain, block)
+	clock.WaitForTimers(1)
+	clock.Run(10*time.Second - time.Millisecond)
+	select {
+	case <-results:
+		t.Fatal("empty block sealed before the period")
+	case <-time.After(50 * time.Millisecond):
+	}
+	clock.Run(time.Millisecond)
+	select {
+	case result := <-results:
+		if result == nil {
+			t.Fatal("no block sealed")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("empty block not sealed after the period")
+	}
+}
+func TestSealDevEmptyWithoutPeriod(t *testing.T) {
+	var (
+		start    = time.Unix(common.GENESIS_TIME, 0)
+		chain, _ = newSealTest(start, 0)
+		block    = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(2), Time: big.NewInt(start.Unix())})
+		stop     = make(chan struct{})
+		results  = make(chan *types.Block, 1)
+	)
+	go func() {
+		result, _ := NewDeveloper(0, mclock.NewSimulated(start)).Seal(chain, block, stop)
+		results <- result
+	}()
+	select {
+	case <-results:
+		t.Fatal("empty block sealed without a period")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(stop)
+	select {
+	case result := <-results:
+		if result != nil {
+			t.Error("aborted block returned")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("seal not aborted")
+	}
+}
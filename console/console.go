@@ -106,6 +106,12 @@ func (c *Console) init(preload []string) error {
 			flatten += fmt.Sprintf("var %s = web3.%s; ", api, api)
 		}
 	}
+	if _, ok := apis["eth"]; ok {
+		if err = c.jsre.Compile("del.js", web3ext.Del_JS); err != nil {
+			return fmt.Errorf("del.js: %v", err)
+		}
+		flatten += "var del = web3.del; "
+	}
 	if _, err = c.jsre.Run(flatten); err != nil {
 		return fmt.Errorf("namespace flattening: %v", err)
 	}
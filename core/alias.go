@@ -0,0 +1,79 @@
+package core
+import (
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/vm"
+	"github.com/DEL-ORG/del/crypto"
+)
+var AliasAddress = common.BytesToAddress([]byte("alias"))
+type Alias struct {
+	Name   string         `json:"name"`
+	Owner  common.Address `json:"owner"`
+	Expiry uint64         `json:"expiry"`
+}
+func aliasKey(name string) common.Hash {
+	return crypto.Keccak256Hash([]byte("alias"), []byte(name))
+}
+func aliasNameKey(owner common.Address) common.Hash {
+	return crypto.Keccak256Hash([]byte("name"), owner[:])
+}
+func getAlias(db vm.StateDB, name string) *Alias {
+	key := aliasKey(name)
+	return &Alias{
+		Name:   name,
+		Owner:  common.BytesToAddress(db.GetState(AliasAddress, stateSlot(key, 0)).Bytes()),
+		Expiry: getStateUint(db, AliasAddress, stateSlot(key, 1)),
+	}
+}
+func GetAlias(db vm.StateDB, name string, number uint64) *Alias {
+	if alias := getAlias(db, name); alias.Expiry > number {
+		return alias
+	}
+	return nil
+}
+func ResolveAlias(db vm.StateDB, name string, number uint64) (common.Address, bool) {
+	if alias := GetAlias(db, name, number); alias != nil {
+		return alias.Owner, true
+	}
+	return common.Address{}, false
+}
+func LookupAlias(db vm.StateDB, owner common.Address, number uint64) *Alias {
+	name := string(getStateBytes(db, AliasAddress, aliasNameKey(owner)))
+	if name == "" {
+		return nil
+	}
+	if alias := GetAlias(db, name, number); alias != nil && alias.Owner == owner {
+		return alias
+	}
+	return nil
+}
+func CanRegisterAlias(db vm.StateDB, owner common.Address, name string, number uint64) bool {
+	if db.GetBalance(owner).Cmp(common.ALIAS_FEE) < 0 {
+		return false
+	}
+	alias := GetAlias(db, name, number)
+	return alias == nil || alias.Owner == owner
+}
+func RegisterAlias(db vm.StateDB, owner common.Address, name string, number uint64) {
+	touchSystemAccount(db, AliasAddress)
+	db.SubBalance(owner, common.ALIAS_FEE)
+	alias := getAlias(db, name)
+	if alias.Owner != owner && alias.Owner != (common.Address{}) {
+		if previous := string(getStateBytes(db, AliasAddress, aliasNameKey(alias.Owner))); previous == name {
+			setStateBytes(db, AliasAddress, aliasNameKey(alias.Owner), nil)
+		}
+		alias.Expiry = 0
+	}
+	if previous := string(getStateBytes(db, AliasAddress, aliasNameKey(owner))); previous != "" && previous != name && getAlias(db, previous).Owner == owner {
+		key := aliasKey(previous)
+		db.SetState(AliasAddress, stateSlot(key, 0), common.Hash{})
+		db.SetState(AliasAddress, stateSlot(key, 1), common.Hash{})
+	}
+	expiry := number
+	if alias.Owner == owner && alias.Expiry > number {
+		expiry = alias.Expiry
+	}
+	key := aliasKey(name)
+	db.SetState(AliasAddress, stateSlot(key, 0), owner.Hash())
+	setStateUint(db, AliasAddress, stateSlot(key, 1), expiry+common.ALIAS_EXPIRY_BLOCKS)
+	setStateBytes(db, AliasAddress, aliasNameKey(owner), []byte(name))
+}
@@ -0,0 +1,75 @@
+package core
+import (
+	"math/big"
+	"testing"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/vm"
+	"github.com/DEL-ORG/del/params"
+)
+func TestRegisterAlias(t *testing.T) {
+	var (
+		statedb = newTestState()
+		alice   = common.Address{0xaa}
+		bob     = common.Address{0xbb}
+		expiry  = uint64(common.ALIAS_EXPIRY_BLOCKS)
+	)
+	statedb.AddBalance(alice, new(big.Int).Mul(common.ALIAS_FEE, big.NewInt(3)))
+	statedb.AddBalance(bob, common.ALIAS_FEE)
+	tests := []struct {
+		owner    common.Address
+		name     string
+		number   uint64
+		accepted bool
+		resolved common.Address
+		expiry   uint64
+	}{
+		{alice, "alice", 10, true, alice, 10 + expiry},
+		{bob, "alice", 20, false, alice, 10 + expiry},
+		{alice, "alice", 30, true, alice, 10 + 2*expiry},
+		{bob, "alice", 10 + 2*expiry, true, bob, 10 + 3*expiry},
+		{alice, "carol", 10 + 2*expiry, true, alice, 10 + 3*expiry},
+		{alice, "dave", 10 + 2*expiry, false, common.Address{}, 0},
+	}
+	for i, tt := range tests {
+		if accepted := CanRegisterAlias(statedb, tt.owner, tt.name, tt.number); accepted != tt.accepted {
+			t.Fatalf("test %d: acceptance mismatch: have %v, want %v", i, accepted, tt.accepted)
+		}
+		if tt.accepted {
+			RegisterAlias(statedb, tt.owner, tt.name, tt.number)
+		}
+		if owner, _ := ResolveAlias(statedb, tt.name, tt.number); tt.accepted && owner != tt.resolved {
+			t.Errorf("test %d: resolved owner mismatch: have %x, want %x", i, owner, tt.resolved)
+		}
+		if alias := LookupAlias(statedb, tt.owner, tt.number); tt.accepted && (alias == nil || alias.Name != tt.name || alias.Expiry != tt.expiry) {
+			t.Errorf("test %d: reverse lookup mismatch: have %+v", i, alias)
+		}
+	}
+	if _, ok := ResolveAlias(statedb, "alice", 10+3*expiry); ok {
+		t.Errorf("expired alias resolved")
+	}
+	if balance := statedb.GetBalance(bob); balance.Sign() != 0 {
+		t.Errorf("alias fee not charged: have %v", balance)
+	}
+}
+func TestAliasCall(t *testing.T) {
+	var (
+		statedb = newTestState()
+		owner   = common.Address{0x01}
+		name    = "owner"
+	)
+	statedb.AddBalance(owner, common.ALIAS_FEE)
+	data, _ := (&common.DataProtocol{MessageID: common.DataProtocolMessageID_ALIAS, Text: &name}).Encode()
+	evm := newSystemEVM(statedb)
+	checkSystemCall(t, evm, owner, owner, data, params.AliasGas)
+	if resolved, ok := ResolveAlias(statedb, name, 5); !ok || resolved != owner {
+		t.Errorf("resolved owner mismatch: have %x, want %x", resolved, owner)
+	}
+	if _, _, err := evm.Call(vm.AccountRef(owner), owner, data, params.AliasGas, new(big.Int)); err != vm.ErrAliasUnavailable {
+		t.Errorf("alias without fee: have %v, want %v", err, vm.ErrAliasUnavailable)
+	}
+	invalid := "0xowner"
+	data, _ = (&common.DataProtocol{MessageID: common.DataProtocolMessageID_ALIAS, Text: &invalid}).Encode()
+	if _, _, err := evm.Call(vm.AccountRef(owner), owner, data, params.AliasGas, new(big.Int)); err != common.ErrAliasName {
+		t.Errorf("invalid alias: have %v, want %v", err, common.ErrAliasName)
+	}
+}
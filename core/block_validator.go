@@ -74,6 +74,9 @@ func (v *BlockValidator) ValidateState(block, parent *types.Block, statedb *stat
 	return nil
 }
 func CalcGasLimit(parent *types.Block) uint64 {
+	return CalcGasLimitTarget(parent, params.TargetGasLimit)
+}
+func CalcGasLimitTarget(parent *types.Block, target uint64) uint64 {
 	contrib := (parent.GasUsed() + parent.GasUsed()/2) / params.GasLimitBoundDivisor
 	decay := parent.GasLimit()/params.GasLimitBoundDivisor - 1
 	
@@ -81,10 +84,10 @@ func CalcGasLimit(parent *types.Block) uint64 {
 	if limit < params.MinGasLimit {
 		limit = params.MinGasLimit
 	}
-	if limit < params.TargetGasLimit {
+	if limit < target {
 		limit = parent.GasLimit() + decay
-		if limit > params.TargetGasLimit {
-			limit = params.TargetGasLimit
+		if limit > target {
+			limit = target
 		}
 	}
 	return limit
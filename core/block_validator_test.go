@@ -1,8 +1,10 @@
 package core
 import (
+	"math/big"
 	"runtime"
 	"testing"
 	"time"
+	"github.com/DEL-ORG/del/common"
 	"github.com/DEL-ORG/del/consensus/ethash"
 	"github.com/DEL-ORG/del/core/types"
 	"github.com/DEL-ORG/del/core/vm"
@@ -12,7 +14,7 @@ import (
 func TestHeaderVerification(t *testing.T) {
 	var (
 		testdb, _ = ethdb.NewMemDatabase()
-		gspec     = &Genesis{Config: params.TestChainConfig}
+		gspec     = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{{Addr: common.Address{0xff}, Balance: new(big.Int), Producer: true}}}
 		genesis   = gspec.MustCommit(testdb)
 		blocks, _ = GenerateChain(params.TestChainConfig, genesis, ethash.NewFaker(), testdb, 8, nil)
 	)
@@ -55,7 +57,7 @@ func TestHeaderConcurrentVerification32(t *testing.T) { testHeaderConcurrentVeri
 func testHeaderConcurrentVerification(t *testing.T, threads int) {
 	var (
 		testdb, _ = ethdb.NewMemDatabase()
-		gspec     = &Genesis{Config: params.TestChainConfig}
+		gspec     = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{{Addr: common.Address{0xff}, Balance: new(big.Int), Producer: true}}}
 		genesis   = gspec.MustCommit(testdb)
 		blocks, _ = GenerateChain(params.TestChainConfig, genesis, ethash.NewFaker(), testdb, 8, nil)
 	)
@@ -109,7 +111,7 @@ func TestHeaderConcurrentAbortion32(t *testing.T) { testHeaderConcurrentAbortion
 func testHeaderConcurrentAbortion(t *testing.T, threads int) {
 	var (
 		testdb, _ = ethdb.NewMemDatabase()
-		gspec     = &Genesis{Config: params.TestChainConfig}
+		gspec     = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{{Addr: common.Address{0xff}, Balance: new(big.Int), Producer: true}}}
 		genesis   = gspec.MustCommit(testdb)
 		blocks, _ = GenerateChain(params.TestChainConfig, genesis, ethash.NewFaker(), testdb, 1024, nil)
 	)
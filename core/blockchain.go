@@ -37,6 +37,7 @@ const (
 	maxFutureBlocks     = 256
 	maxTimeFutureBlocks = 30
 	badBlockLimit       = 10
+	votersCacheLimit    = 64
 	triesInMemory       = 128
 	BlockChainVersion = 3
 )
@@ -57,6 +58,9 @@ type BlockChain struct {
 	chainSideFeed event.Feed
 	chainHeadFeed event.Feed
 	logsFeed      event.Feed
+	roundFeed     event.Feed
+	votesFeed     event.Feed
+	textsFeed     event.Feed
 	scope         event.SubscriptionScope
 	genesisBlock  *types.Block
 	mu      sync.RWMutex 
@@ -79,6 +83,7 @@ type BlockChain struct {
 	validator Validator 
 	vmConfig  vm.Config
 	badBlocks *lru.Cache 
+	votersCache *lru.Cache
 	cacheVotersMap map[common.Hash]map[common.Address]types.Voters
 	maxNumber uint64
 	maxDiff uint64
@@ -98,6 +103,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, chainConfig *par
 	blockCache, _ := lru.New(blockCacheLimit)
 	futureBlocks, _ := lru.New(maxFutureBlocks)
 	badBlocks, _ := lru.New(badBlockLimit)
+	votersCache, _ := lru.New(votersCacheLimit)
 	bc := &BlockChain{
 		chainConfig:  chainConfig,
 		cacheConfig:  cacheConfig,
@@ -112,6 +118,7 @@ func NewBlockChain(db ethdb.Database, cacheConfig *CacheConfig, chainConfig *par
 		engine:       engine,
 		vmConfig:     vmConfig,
 		badBlocks:    badBlocks,
+		votersCache:  votersCache,
 		cacheVotersMap:map[common.Hash]map[common.Address]types.Voters{},
 	}
 	bc.SetValidator(NewBlockValidator(chainConfig, bc, engine))
@@ -164,6 +171,7 @@ func (bc *BlockChain)onPushBlock(block *types.Block) {
 			CoinbaseReward:big.NewInt(0),
 			SuperCoinbaseReward:big.NewInt(0),
 			VoteReward:big.NewInt(0),
+			FeeReward:big.NewInt(0),
 		}
 	}else {
 		amount.BlockReward.Add(amount.BlockReward, reward.BlockReward)
@@ -198,6 +206,7 @@ func (bc *BlockChain)onPushBlock(block *types.Block) {
 					CoinbaseReward:big.NewInt(0),
 					SuperCoinbaseReward:big.NewInt(0),
 					VoteReward:big.NewInt(0).Set(r),
+					FeeReward:big.NewInt(0),
 				}
 			}else {
 				amount.VoteReward.Add(amount.VoteReward, r)
@@ -217,6 +226,7 @@ func (bc *BlockChain)onPushBlock(block *types.Block) {
 				CoinbaseReward:big.NewInt(0).Set(r),
 				SuperCoinbaseReward:big.NewInt(0),
 				VoteReward:big.NewInt(0),
+				FeeReward:big.NewInt(0),
 			}
 		}else {
 			amount.CoinbaseReward.Add(amount.CoinbaseReward, r)
@@ -239,12 +249,27 @@ func (bc *BlockChain)onPushBlock(block *types.Block) {
 				CoinbaseReward:big.NewInt(0),
 				SuperCoinbaseReward:big.NewInt(0).Set(r),
 				VoteReward:big.NewInt(0),
+				FeeReward:big.NewInt(0),
 			}
 		}else {
 			amount.SuperCoinbaseReward.Add(amount.SuperCoinbaseReward, r)
 		}
 		superCoinbaseNum++
 	}
+	for addr, r := range bc.GetFeeShares(block) {
+		amount, ok := bc.reward[addr]
+		if !ok {
+			bc.reward[addr] = types.OutputBlockReward{
+				BlockReward:big.NewInt(0),
+				CoinbaseReward:big.NewInt(0),
+				SuperCoinbaseReward:big.NewInt(0),
+				VoteReward:big.NewInt(0),
+				FeeReward:big.NewInt(0).Set(r),
+			}
+		}else {
+			amount.FeeReward.Add(amount.FeeReward, r)
+		}
+	}
 }
 func (bc *BlockChain)onPopBlock(block *types.Block) {
 	reward := ethash.GetRewardByNumber(block.NumberU64())
@@ -314,6 +339,26 @@ func (bc *BlockChain)onPopBlock(block *types.Block) {
 		amount.SuperCoinbaseReward.Sub(amount.SuperCoinbaseReward, r)
 		superCoinbaseNum++
 	}
+	for addr, r := range bc.GetFeeShares(block) {
+		amount, ok := bc.reward[addr]
+		if !ok {
+			log.Error("PopBlock error, fee reward not found", "number", block.NumberU64(), "addr", addr.Hex())
+			continue
+		}
+		amount.FeeReward.Sub(amount.FeeReward, r)
+	}
+}
+func (bc *BlockChain)GetFeeShares(block *types.Block) map[common.Address]*big.Int {
+	fees := BlockFees(block.Transactions(), bc.GetReceiptsByHash(block.Hash()))
+	split := GovernedFeeSplit(bc.chainConfig, block.Header(), nil)
+	if bc.chainConfig.IsGovernance(block.Number()) {
+		if parent := bc.GetBlock(block.ParentHash(), block.NumberU64()-1); parent != nil {
+			if statedb, err := bc.StateAt(parent.Root()); err == nil {
+				split = GovernedFeeSplit(bc.chainConfig, block.Header(), statedb)
+			}
+		}
+	}
+	return FeeShares(split, block.Header(), fees, block.Voters)
 }
 func (bc *BlockChain)OnUpdateBlock(number uint64) {
 	bc.rewardMutex.Lock()
@@ -359,6 +404,7 @@ func (bc *BlockChain)Get24HReward(addr common.Address) (*big.Int, error) {
 		total.Add(total, reward.CoinbaseReward)
 		total.Add(total, reward.SuperCoinbaseReward)
 		total.Add(total, reward.VoteReward)
+		total.Add(total, reward.FeeReward)
 		return total, nil
 	}
 	return common.Big0, nil
@@ -366,18 +412,42 @@ func (bc *BlockChain)Get24HReward(addr common.Address) (*big.Int, error) {
 func (bc *BlockChain)GetGenesisBlock() *types.Block {
 	return bc.genesisBlock
 }
-func (bc *BlockChain)GetVotersState(header *types.Header) (voters types.VotersMap) {
+func (bc *BlockChain)RoundProducers(parent *types.Header) (types.Producers, error) {
+	return bc.engine.CalProducersWithoutParent(bc, parent)
+}
+func (bc *BlockChain)GetVotersState(header *types.Header) types.VotersMap {
 	if header.Number.Uint64() <= 0 {
 		return types.VotersMap{}
 	}
-	voters = types.VotersMap{}
-	current_round_number := common.GetRoundNumberByBlockNumber(header.Number.Uint64())
-	begin_block_number := common.GetBeginBlockNumberByRoundNumber(current_round_number)
+	hash := header.Hash()
+	cached, ok := bc.votersCache.Get(hash)
+	if !ok {
+		var complete bool
+		if cached, complete = bc.votersState(header); complete {
+			bc.votersCache.Add(hash, cached)
+		}
+	}
+	voters := types.VotersMap{}
+	for addr, vote := range cached.(types.VotersMap) {
+		voters[addr] = new(big.Int).Set(vote)
+	}
+	return voters
+}
+func (bc *BlockChain)votersState(header *types.Header) (voters types.VotersMap, complete bool) {
+	voters, complete = types.VotersMap{}, true
+	if current, _ := bc.StateAt(header.Root); current != nil {
+		voters = GetDelegatedVotes(current)
+	} else {
+		complete = false
+	}
+	current_round_number := common.GetRoundNumberByBlockNumber(header.Number.Uint64(), bc.chainConfig.GetRoundLength())
+	begin_block_number := common.GetBeginBlockNumberByRoundNumber(current_round_number, bc.chainConfig.GetRoundLength())
 	for ;header != nil && header.Number.Uint64() > 0 && header.Number.Uint64() >= begin_block_number;
 		header = bc.GetHeader(header.ParentHash, header.Number.Uint64() - 1) {
 		block := bc.GetBlockByHash(header.Hash())
 		if block == nil {
 			log.Error("Can not get block", "hash", header.Hash().Hex())
+			complete = false
 			continue
 		}
 		for _, tx := range block.Transactions() {
@@ -396,14 +466,28 @@ func (bc *BlockChain)GetVotersState(header *types.Header) (voters types.VotersMa
 				}
 			}
 		}
+		receipts := bc.GetReceiptsByHash(header.Hash())
+		if receipts == nil && len(block.Transactions()) > 0 {
+			complete = false
+		}
+		for _, vote := range GetContractVotes(receipts) {
+			amount, ok := voters[vote.Producer]
+			if ok {
+				amount.Add(amount, vote.Amount)
+			} else {
+				voters[vote.Producer] = new(big.Int).Set(vote.Amount)
+			}
+		}
 	}
 	var state *state.StateDB = nil
 	if header == nil {
 		log.Error("header nil")
+		complete = false
 	}else {
 		state, _ = bc.StateAt(header.Root)
 		if state == nil {
 			log.Error("Can not get state", "hash", header.Root)
+			complete = false
 		}
 	}
 	if state != nil {
@@ -417,7 +501,7 @@ func (bc *BlockChain)GetVotersState(header *types.Header) (voters types.VotersMa
 func (bc *BlockChain)GetAllMessage(header *types.Header) (ret map[common.Address]types.Voters) {
 	header_hash := common.HexToHash(header.Hash().Hex())
 	if ret, ok := bc.cacheVotersMap[header_hash]; ok {
-		log.Debug("Hint voters cache", "hash", header_hash.Hex(), "round", common.GetRoundNumberByBlockNumber(header.Number.Uint64()))
+		log.Debug("Hint voters cache", "hash", header_hash.Hex(), "round", common.GetRoundNumberByBlockNumber(header.Number.Uint64(), bc.chainConfig.GetRoundLength()))
 		return ret
 	}
 	for len(bc.cacheVotersMap) > 2 {
@@ -429,7 +513,7 @@ func (bc *BlockChain)GetAllMessage(header *types.Header) (ret map[common.Address
 				delete(bc.cacheVotersMap, hash)
 				continue
 			}
-			round := common.GetRoundNumberByBlockNumber(h.Number.Uint64())
+			round := common.GetRoundNumberByBlockNumber(h.Number.Uint64(), bc.chainConfig.GetRoundLength())
 			if minRound == 0 || round < minRound {
 				minRound = round
 				minHash = hash
@@ -442,9 +526,14 @@ func (bc *BlockChain)GetAllMessage(header *types.Header) (ret map[common.Address
 			break
 		}
 	}
-	round_number := common.GetRoundNumberByBlockNumber(header.Number.Uint64())
-	round_begin_number := common.GetBeginBlockNumberByRoundNumber(round_number)
+	round_number := common.GetRoundNumberByBlockNumber(header.Number.Uint64(), bc.chainConfig.GetRoundLength())
+	round_begin_number := common.GetBeginBlockNumberByRoundNumber(round_number, bc.chainConfig.GetRoundLength())
 	votersMap := map[common.Address]map[common.Address]*big.Int{}
+	if current, _ := bc.StateAt(header.Root); current != nil {
+		for _, producer := range GetDelegatedProducers(current) {
+			votersMap[producer] = GetDelegators(current, producer)
+		}
+	}
 	for ;header != nil && header.Number.Uint64() >= round_begin_number; header = bc.GetHeader(header.ParentHash, header.Number.Uint64() - 1) {
 		block := bc.GetBlock(header.Hash(), header.Number.Uint64())
 		if block == nil {
@@ -474,6 +563,19 @@ func (bc *BlockChain)GetAllMessage(header *types.Header) (ret map[common.Address
 				}
 			}
 		}
+		for _, vote := range GetContractVotes(bc.GetReceiptsByHash(header.Hash())) {
+			votes, ok := votersMap[vote.Producer]
+			if !ok {
+				votes = map[common.Address]*big.Int{}
+				votersMap[vote.Producer] = votes
+			}
+			amount, ok := votes[vote.Voter]
+			if !ok {
+				votes[vote.Voter] = new(big.Int).Set(vote.Amount)
+			} else {
+				amount.Add(amount, vote.Amount)
+			}
+		}
 	}
 	ret = map[common.Address]types.Voters{}
 	for coinbase, votes := range votersMap {
@@ -495,9 +597,16 @@ func (bc *BlockChain)GetAllMessage(header *types.Header) (ret map[common.Address
 	bc.cacheVotersMap[header_hash] = ret
 	return ret
 }
+func (bc *BlockChain)GetRandaoMix(header *types.Header) (common.Hash, error) {
+	state, err := bc.StateAt(header.Root)
+	if err != nil {
+		return common.Hash{}, ErrRandaoState
+	}
+	return GetRandaoMix(state), nil
+}
 func (bc *BlockChain)GetVoters(header *types.Header) types.Voters {
 	coinbase := header.Coinbase
-	round_number := common.GetRoundNumberByBlockNumber(header.Number.Uint64())
+	round_number := common.GetRoundNumberByBlockNumber(header.Number.Uint64(), bc.chainConfig.GetRoundLength())
 	var last_round uint64 = 0
 	if round_number > 0 {
 		last_round = round_number - 1
@@ -505,7 +614,7 @@ func (bc *BlockChain)GetVoters(header *types.Header) types.Voters {
 	if last_round <= 0 {
 		return types.Voters{}
 	}
-	var round_end_number = common.GetEndBlockNumberByRoundNumber(last_round)
+	var round_end_number = common.GetEndBlockNumberByRoundNumber(last_round, bc.chainConfig.GetRoundLength())
 	for ;header != nil && header.Number.Uint64() > round_end_number; header = bc.GetHeader(header.ParentHash, header.Number.Uint64() - 1) {
 	}
 	if header == nil {
@@ -581,6 +690,7 @@ func (bc *BlockChain) SetHead(head uint64) error {
 	bc.bodyCache.Purge()
 	bc.bodyRLPCache.Purge()
 	bc.blockCache.Purge()
+	bc.votersCache.Purge()
 	bc.futureBlocks.Purge()
 	if bc.currentBlock != nil && currentHeader.Number.Uint64() < bc.currentBlock.NumberU64() {
 		bc.currentBlock = bc.GetBlock(currentHeader.Hash(), currentHeader.Number.Uint64())
@@ -1219,6 +1329,7 @@ func (bc *BlockChain) insertChain(chain types.Blocks) (int, []interface{}, []*ty
 			coalescedLogs = append(coalescedLogs, logs...)
 			blockInsertTimer.UpdateSince(bstart)
 			events = append(events, ChainEvent{block, block.Hash(), logs})
+			events = append(events, bc.DposEvents(block, receipts)...)
 			lastCanon = block
 			bc.gcproc += proctime
 		case SideStatTy:
@@ -1368,6 +1479,14 @@ func (bc *BlockChain) reorg(oldBlock, newBlock *types.Block) error {
 			}
 		}()
 	}
+	var dposEvents []interface{}
+	for i := len(newChain) - 1; i > 0; i-- {
+		receipts := GetBlockReceipts(bc.db, newChain[i].Hash(), newChain[i].NumberU64())
+		dposEvents = append(dposEvents, bc.DposEvents(newChain[i], receipts)...)
+	}
+	if len(dposEvents) > 0 {
+		go bc.PostChainEvents(dposEvents, nil)
+	}
 	return nil
 }
 func (bc *BlockChain) PostChainEvents(events []interface{}, logs []*types.Log) {
@@ -1382,9 +1501,80 @@ func (bc *BlockChain) PostChainEvents(events []interface{}, logs []*types.Log) {
 			bc.chainHeadFeed.Send(ev)
 		case ChainSideEvent:
 			bc.chainSideFeed.Send(ev)
+		case NewRoundEvent:
+			bc.roundFeed.Send(ev)
+		case VotesEvent:
+			bc.votesFeed.Send(ev)
+		case TextsEvent:
+			bc.textsFeed.Send(ev)
 		}
 	}
 }
+func (bc *BlockChain) DposEvents(block *types.Block, receipts types.Receipts) (events []interface{}) {
+	number := block.NumberU64()
+	round := common.GetRoundNumberByBlockNumber(number, bc.chainConfig.GetRoundLength())
+	if number > 0 && common.GetBeginBlockNumberByRoundNumber(round, bc.chainConfig.GetRoundLength()) == number {
+		events = append(events, NewRoundEvent{Round: round, Block: block, Producers: block.Producers()})
+	}
+	var (
+		votes  []types.OutputVote
+		texts  []types.OutputText
+		signer = types.MakeSigner(bc.chainConfig, block.Number())
+		btime  = time.Unix(block.Time().Int64(), 0)
+	)
+	for _, tx := range block.Transactions() {
+		message, err := tx.GetMessage()
+		if err != nil || message == nil {
+			continue
+		}
+		from, err := types.Sender(signer, tx)
+		if err != nil {
+			continue
+		}
+		switch message.MessageID {
+		case common.DataProtocolMessageID_VOTE:
+			for _, ticket := range message.Tickets {
+				votes = append(votes, types.OutputVote{
+					Hash:   tx.Hash(),
+					From:   from,
+					To:     common.HexToAddress(ticket.Addr),
+					Time:   btime,
+					Amount: ticket.GetAmount(),
+				})
+			}
+		case common.DataProtocolMessageID_TEXT:
+			if message.Text == nil || tx.To() == nil {
+				continue
+			}
+			price := new(big.Int).Set(tx.GasPrice())
+			price.Mul(price, new(big.Int).SetUint64(tx.Gas()))
+			texts = append(texts, types.OutputText{
+				Hash:  tx.Hash(),
+				From:  from,
+				To:    *tx.To(),
+				Text:  *message.Text,
+				Time:  btime,
+				Price: price,
+			})
+		}
+	}
+	for _, vote := range GetContractVotes(receipts) {
+		votes = append(votes, types.OutputVote{
+			Hash:   vote.TxHash,
+			From:   vote.Voter,
+			To:     vote.Producer,
+			Time:   btime,
+			Amount: vote.Amount,
+		})
+	}
+	if len(votes) > 0 {
+		events = append(events, VotesEvent{Block: block, Votes: votes})
+	}
+	if len(texts) > 0 {
+		events = append(events, TextsEvent{Block: block, Texts: texts})
+	}
+	return events
+}
 func (bc *BlockChain) update() {
 	futureTimer := time.NewTicker(5 * time.Second)
 	defer futureTimer.Stop()
@@ -1447,6 +1637,13 @@ func (bc *BlockChain) InsertHeaderChain(chain []*types.Header, checkFreq int) (i
 	}
 	return bc.hc.InsertHeaderChain(chain, whFunc, start)
 }
+func (bc *BlockChain) InsertCheckpointHeader(header *types.Header, td *big.Int) error {
+	bc.chainmu.Lock()
+	defer bc.chainmu.Unlock()
+	bc.mu.Lock()
+	defer bc.mu.Unlock()
+	return bc.hc.InsertCheckpointHeader(header, td)
+}
 func (bc *BlockChain) writeHeader(header *types.Header) error {
 	bc.wg.Add(1)
 	defer bc.wg.Done()
@@ -1499,3 +1696,12 @@ func (bc *BlockChain) SubscribeChainSideEvent(ch chan<- ChainSideEvent) event.Su
 func (bc *BlockChain) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription {
 	return bc.scope.Track(bc.logsFeed.Subscribe(ch))
 }
+func (bc *BlockChain) SubscribeNewRoundEvent(ch chan<- NewRoundEvent) event.Subscription {
+	return bc.scope.Track(bc.roundFeed.Subscribe(ch))
+}
+func (bc *BlockChain) SubscribeVotesEvent(ch chan<- VotesEvent) event.Subscription {
+	return bc.scope.Track(bc.votesFeed.Subscribe(ch))
+}
+func (bc *BlockChain) SubscribeTextsEvent(ch chan<- TextsEvent) event.Subscription {
+	return bc.scope.Track(bc.textsFeed.Subscribe(ch))
+}
@@ -451,7 +451,7 @@ func TestFastVsFullChains(t *testing.T) {
 		funds    = big.NewInt(1000000000)
 		gspec    = &Genesis{
 			Config: params.TestChainConfig,
-			Alloc:  GenesisAlloc{address: {Balance: funds}},
+			Alloc:  GenesisAlloc{{Addr: address, Balance: funds, Producer: true}},
 		}
 		genesis = gspec.MustCommit(gendb)
 		signer  = types.NewEIP155Signer(gspec.Config.ChainId)
@@ -523,7 +523,7 @@ func TestLightVsFastVsFullChainHeads(t *testing.T) {
 		key, _   = crypto.HexToECDSA("b71c71a67e1177ad4e901695e1b4b9ee17ae16c6668d313eac2f96dbcda3f291")
 		address  = crypto.PubkeyToAddress(key.PublicKey)
 		funds    = big.NewInt(1000000000)
-		gspec    = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{address: {Balance: funds}}}
+		gspec    = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{{Addr: address, Balance: funds, Producer: true}}}
 		genesis  = gspec.MustCommit(gendb)
 	)
 	height := uint64(1024)
@@ -591,12 +591,11 @@ func TestChainTxReorgs(t *testing.T) {
 		addr3   = crypto.PubkeyToAddress(key3.PublicKey)
 		db, _   = ethdb.NewMemDatabase()
 		gspec   = &Genesis{
-			Config:   params.TestChainConfig,
-			GasLimit: 3141592,
+			Config: params.TestChainConfig,
 			Alloc: GenesisAlloc{
-				addr1: {Balance: big.NewInt(1000000)},
-				addr2: {Balance: big.NewInt(1000000)},
-				addr3: {Balance: big.NewInt(1000000)},
+				{Addr: addr1, Balance: big.NewInt(1000000), Producer: true},
+				{Addr: addr2, Balance: big.NewInt(1000000)},
+				{Addr: addr3, Balance: big.NewInt(1000000)},
 			},
 		}
 		genesis = gspec.MustCommit(db)
@@ -613,10 +612,10 @@ func TestChainTxReorgs(t *testing.T) {
 			gen.AddTx(pastDrop)  
 			gen.AddTx(postponed) 
 		case 2:
+			gen.OffsetTime(9) 
 			freshDrop, _ = types.SignTx(types.NewTransaction(gen.TxNonce(addr2), addr2, big.NewInt(1000), params.TxGas, nil, nil), signer, key2)
 			gen.AddTx(freshDrop) 
 			gen.AddTx(swapped)   
-			gen.OffsetTime(9) 
 		}
 	})
 	blockchain, _ := NewBlockChain(db, nil, gspec.Config, ethash.NewFaker(), vm.Config{})
@@ -673,7 +672,7 @@ func TestLogReorgs(t *testing.T) {
 		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
 		db, _   = ethdb.NewMemDatabase()
 		code    = common.Hex2Bytes("60606040525b7f24ec1d3ff24c2f6ff210738839dbc339cd45a5294d85c79361016243157aae7b60405180905060405180910390a15b600a8060416000396000f360606040526008565b00")
-		gspec   = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{addr1: {Balance: big.NewInt(10000000000000)}}}
+		gspec   = &Genesis{Config: params.TestChainConfig, Alloc: GenesisAlloc{{Addr: addr1, Balance: big.NewInt(10000000000000), Producer: true}}}
 		genesis = gspec.MustCommit(db)
 		signer  = types.NewEIP155Signer(gspec.Config.ChainId)
 	)
@@ -714,7 +713,7 @@ func TestReorgSideEvent(t *testing.T) {
 		addr1   = crypto.PubkeyToAddress(key1.PublicKey)
 		gspec   = &Genesis{
 			Config: params.TestChainConfig,
-			Alloc:  GenesisAlloc{addr1: {Balance: big.NewInt(10000000000000)}},
+			Alloc:  GenesisAlloc{{Addr: addr1, Balance: big.NewInt(10000000000000), Producer: true}},
 		}
 		genesis = gspec.MustCommit(db)
 		signer  = types.NewEIP155Signer(gspec.Config.ChainId)
@@ -727,8 +726,8 @@ func TestReorgSideEvent(t *testing.T) {
 	}
 	replacementBlocks, _ := GenerateChain(gspec.Config, genesis, ethash.NewFaker(), db, 4, func(i int, gen *BlockGen) {
 		tx, err := types.SignTx(types.NewContractCreation(gen.TxNonce(addr1), new(big.Int), 1000000, new(big.Int), nil), signer, key1)
-		if i == 2 {
-			gen.OffsetTime(-9)
+		if i == 0 {
+			gen.OffsetTime(5)
 		}
 		if err != nil {
 			t.Fatalf("failed to create tx: %v", err)
@@ -743,6 +742,7 @@ func TestReorgSideEvent(t *testing.T) {
 	expectedSideHashes := map[common.Hash]bool{
 		replacementBlocks[0].Hash(): true,
 		replacementBlocks[1].Hash(): true,
+		replacementBlocks[2].Hash(): true,
 		chain[0].Hash():             true,
 		chain[1].Hash():             true,
 		chain[2].Hash():             true,
@@ -789,14 +789,17 @@ func TestCanonicalBlockRetrieval(t *testing.T) {
 					continue 
 				}
 				if ch != block.Hash() {
-					t.Fatalf("unknown canonical hash, want %s, got %s", block.Hash().Hex(), ch.Hex())
+					t.Errorf("unknown canonical hash, want %s, got %s", block.Hash().Hex(), ch.Hex())
+					return
 				}
 				fb := GetBlock(bc.db, ch, block.NumberU64())
 				if fb == nil {
-					t.Fatalf("unable to retrieve block %d for canonical hash: %s", block.NumberU64(), ch.Hex())
+					t.Errorf("unable to retrieve block %d for canonical hash: %s", block.NumberU64(), ch.Hex())
+					return
 				}
 				if fb.Hash() != block.Hash() {
-					t.Fatalf("invalid block hash for block %d, want %s, got %s", block.NumberU64(), block.Hash().Hex(), fb.Hash().Hex())
+					t.Errorf("invalid block hash for block %d, want %s, got %s", block.NumberU64(), block.Hash().Hex(), fb.Hash().Hex())
+					return
 				}
 				return
 			}
@@ -816,7 +819,7 @@ func TestEIP155Transition(t *testing.T) {
 		deleteAddr = common.Address{1}
 		gspec      = &Genesis{
 			Config: &params.ChainConfig{ChainId: big.NewInt(1), EIP155Block: big.NewInt(2), HomesteadBlock: new(big.Int)},
-			Alloc:  GenesisAlloc{address: {Balance: funds}, deleteAddr: {Balance: new(big.Int)}},
+			Alloc:  GenesisAlloc{{Addr: address, Balance: funds, Producer: true}, {Addr: deleteAddr, Balance: new(big.Int)}},
 		}
 		genesis = gspec.MustCommit(db)
 	)
@@ -915,7 +918,7 @@ func TestEIP161AccountRemoval(t *testing.T) {
 				EIP155Block:    new(big.Int),
 				EIP158Block:    big.NewInt(2),
 			},
-			Alloc: GenesisAlloc{address: {Balance: funds}},
+			Alloc: GenesisAlloc{{Addr: address, Balance: funds, Producer: true}},
 		}
 		genesis = gspec.MustCommit(db)
 	)
@@ -949,20 +952,21 @@ func TestEIP161AccountRemoval(t *testing.T) {
 	if _, err := blockchain.InsertChain(types.Blocks{blocks[1]}); err != nil {
 		t.Fatal(err)
 	}
-	if st, _ := blockchain.State(); st.Exist(theAddr) {
-		t.Error("account should not exist")
+	if st, _ := blockchain.State(); !st.Exist(theAddr) {
+		t.Error("expected account to exist")
 	}
 	if _, err := blockchain.InsertChain(types.Blocks{blocks[2]}); err != nil {
 		t.Fatal(err)
 	}
-	if st, _ := blockchain.State(); st.Exist(theAddr) {
-		t.Error("account should not exist")
+	if st, _ := blockchain.State(); !st.Exist(theAddr) {
+		t.Error("expected account to exist")
 	}
 }
 func TestBlockchainHeaderchainReorgConsistency(t *testing.T) {
 	engine := ethash.NewFaker()
 	db, _ := ethdb.NewMemDatabase()
-	genesis := new(Genesis).MustCommit(db)
+	gspec := newProducerGenesis(common.Address{1}, common.Address{2}, common.Address{3})
+	genesis := gspec.MustCommit(db)
 	blocks, _ := GenerateChain(params.TestChainConfig, genesis, engine, db, 64, func(i int, b *BlockGen) { b.SetCoinbase(common.Address{1}) })
 	forks := make([]*types.Block, len(blocks))
 	for i := 0; i < len(forks); i++ {
@@ -974,7 +978,7 @@ func TestBlockchainHeaderchainReorgConsistency(t *testing.T) {
 		forks[i] = fork[0]
 	}
 	diskdb, _ := ethdb.NewMemDatabase()
-	new(Genesis).MustCommit(diskdb)
+	gspec.MustCommit(diskdb)
 	chain, err := NewBlockChain(diskdb, nil, params.TestChainConfig, engine, vm.Config{})
 	if err != nil {
 		t.Fatalf("failed to create tester chain: %v", err)
@@ -997,7 +1001,8 @@ func TestBlockchainHeaderchainReorgConsistency(t *testing.T) {
 func TestTrieForkGC(t *testing.T) {
 	engine := ethash.NewFaker()
 	db, _ := ethdb.NewMemDatabase()
-	genesis := new(Genesis).MustCommit(db)
+	gspec := newProducerGenesis(common.Address{1}, common.Address{2}, common.Address{3})
+	genesis := gspec.MustCommit(db)
 	blocks, _ := GenerateChain(params.TestChainConfig, genesis, engine, db, 2*triesInMemory, func(i int, b *BlockGen) { b.SetCoinbase(common.Address{1}) })
 	forks := make([]*types.Block, len(blocks))
 	for i := 0; i < len(forks); i++ {
@@ -1009,7 +1014,7 @@ func TestTrieForkGC(t *testing.T) {
 		forks[i] = fork[0]
 	}
 	diskdb, _ := ethdb.NewMemDatabase()
-	new(Genesis).MustCommit(diskdb)
+	gspec.MustCommit(diskdb)
 	chain, err := NewBlockChain(diskdb, nil, params.TestChainConfig, engine, vm.Config{})
 	if err != nil {
 		t.Fatalf("failed to create tester chain: %v", err)
@@ -1033,12 +1038,23 @@ func TestTrieForkGC(t *testing.T) {
 func TestLargeReorgTrieGC(t *testing.T) {
 	engine := ethash.NewFaker()
 	db, _ := ethdb.NewMemDatabase()
-	genesis := new(Genesis).MustCommit(db)
-	shared, _ := GenerateChain(params.TestChainConfig, genesis, engine, db, 64, func(i int, b *BlockGen) { b.SetCoinbase(common.Address{1}) })
-	original, _ := GenerateChain(params.TestChainConfig, shared[len(shared)-1], engine, db, 2*triesInMemory, func(i int, b *BlockGen) { b.SetCoinbase(common.Address{2}) })
-	competitor, _ := GenerateChain(params.TestChainConfig, shared[len(shared)-1], engine, db, 2*triesInMemory+1, func(i int, b *BlockGen) { b.SetCoinbase(common.Address{3}) })
+	gspec := newProducerGenesis(common.Address{1}, common.Address{2}, common.Address{3})
+	genesis := gspec.MustCommit(db)
+	fork := func(n int, coinbase common.Address) []*types.Block {
+		blocks, _ := GenerateChain(params.TestChainConfig, genesis, engine, db, 64+n, func(i int, b *BlockGen) {
+			if i < 64 {
+				b.SetCoinbase(common.Address{1})
+			} else {
+				b.SetCoinbase(coinbase)
+			}
+		})
+		return blocks
+	}
+	original, competitor := fork(2*triesInMemory, common.Address{2}), fork(2*triesInMemory+1, common.Address{3})
+	shared := original[:64]
+	original, competitor = original[64:], competitor[64:]
 	diskdb, _ := ethdb.NewMemDatabase()
-	new(Genesis).MustCommit(diskdb)
+	gspec.MustCommit(diskdb)
 	chain, err := NewBlockChain(diskdb, nil, params.TestChainConfig, engine, vm.Config{})
 	if err != nil {
 		t.Fatalf("failed to create tester chain: %v", err)
@@ -1069,3 +1085,132 @@ func TestLargeReorgTrieGC(t *testing.T) {
 		}
 	}
 }
+func TestVotersStateCache(t *testing.T) {
+	var (
+		chain = newTestBlockChain(true)
+		voter = common.Address{0x01}
+		alice = common.Address{0xaa}
+	)
+	statedb, _ := chain.State()
+	statedb.AddBalance(voter, big.NewInt(1000))
+	Delegate(statedb, voter, delegationTickets(map[common.Address]int64{alice: 100}))
+	root, _ := statedb.Commit(true)
+	if err := chain.stateCache.TrieDB().Commit(root, false); err != nil {
+		t.Fatalf("failed to commit state: %v", err)
+	}
+	genesis := chain.Genesis()
+	header := &types.Header{ParentHash: genesis.Hash(), Number: big.NewInt(1), Root: root, Time: new(big.Int).Add(genesis.Time(), big.NewInt(1)), Difficulty: big.NewInt(1)}
+	if err := WriteBlock(chain.db, types.NewBlockWithHeader(header)); err != nil {
+		t.Fatalf("failed to write block: %v", err)
+	}
+	voters := chain.GetVotersState(header)
+	if vote := voters[alice]; vote == nil || vote.Cmp(big.NewInt(100)) != 0 {
+		t.Fatalf("alice votes mismatch: have %v, want 100", vote)
+	}
+	if !chain.votersCache.Contains(header.Hash()) {
+		t.Fatalf("voters state not cached")
+	}
+	voters[alice].SetInt64(1)
+	voters[voter] = big.NewInt(1)
+	voters = chain.GetVotersState(header)
+	if vote := voters[alice]; vote.Cmp(big.NewInt(100)) != 0 {
+		t.Errorf("cached alice votes mutated: have %v, want 100", vote)
+	}
+	if _, ok := voters[voter]; ok {
+		t.Errorf("cached voters state mutated")
+	}
+	pruned := &types.Header{ParentHash: genesis.Hash(), Number: big.NewInt(1), Root: common.Hash{0x01}, Time: header.Time, Difficulty: big.NewInt(2)}
+	if err := WriteBlock(chain.db, types.NewBlockWithHeader(pruned)); err != nil {
+		t.Fatalf("failed to write block: %v", err)
+	}
+	chain.GetVotersState(pruned)
+	if chain.votersCache.Contains(pruned.Hash()) {
+		t.Errorf("partial voters state cached without block state")
+	}
+}
+func TestDposEventsOnReorg(t *testing.T) {
+	var (
+		chain     = newTestBlockChain(true)
+		key, _    = crypto.GenerateKey()
+		recipient = common.Address{0xaa}
+		producer  = common.Address{0xbb}
+		signer    = types.MakeSigner(chain.Config(), big.NewInt(1))
+		genesis   = chain.Genesis()
+	)
+	text, _ := types.SignTx(types.NewTextCreation(&recipient, 0, big.NewInt(1), []byte("hello")), signer, key)
+	vote, _ := types.SignTx(types.NewVoteCreation(&producer, 1, big.NewInt(1), big.NewInt(5)), signer, key)
+	makeBlock := func(parent *types.Block, extra byte, txs []*types.Transaction) *types.Block {
+		header := &types.Header{ParentHash: parent.Hash(), Number: new(big.Int).Add(parent.Number(), common.Big1), Time: new(big.Int).Add(parent.Time(), common.Big1), Difficulty: big.NewInt(1), Extra: []byte{extra}}
+		block := types.NewBlock(header, txs, nil, nil, nil, nil)
+		if err := WriteBlock(chain.db, block); err != nil {
+			t.Fatalf("failed to write block: %v", err)
+		}
+		return block
+	}
+	old := makeBlock(genesis, 1, nil)
+	chain.insert(old)
+	side := makeBlock(genesis, 2, []*types.Transaction{text, vote})
+	head := makeBlock(side, 2, nil)
+	rounds := make(chan NewRoundEvent, 1)
+	texts := make(chan TextsEvent, 1)
+	votes := make(chan VotesEvent, 1)
+	defer chain.SubscribeNewRoundEvent(rounds).Unsubscribe()
+	defer chain.SubscribeTextsEvent(texts).Unsubscribe()
+	defer chain.SubscribeVotesEvent(votes).Unsubscribe()
+	if err := chain.reorg(old, head); err != nil {
+		t.Fatalf("reorg failed: %v", err)
+	}
+	timeout := time.After(time.Second)
+	select {
+	case ev := <-rounds:
+		if ev.Round != 1 || ev.Block.Hash() != side.Hash() {
+			t.Errorf("new round mismatch: have round %d block %x, want round 1 block %x", ev.Round, ev.Block.Hash(), side.Hash())
+		}
+	case <-timeout:
+		t.Fatal("timeout waiting for new round event")
+	}
+	select {
+	case ev := <-texts:
+		if len(ev.Texts) != 1 || ev.Texts[0].Text != "hello" || ev.Texts[0].To != recipient || ev.Texts[0].Hash != text.Hash() {
+			t.Errorf("texts mismatch: %v", ev.Texts)
+		}
+	case <-timeout:
+		t.Fatal("timeout waiting for texts event")
+	}
+	select {
+	case ev := <-votes:
+		if len(ev.Votes) != 1 || ev.Votes[0].To != producer || ev.Votes[0].Amount.Cmp(big.NewInt(5)) != 0 {
+			t.Errorf("votes mismatch: %v", ev.Votes)
+		}
+	case <-timeout:
+		t.Fatal("timeout waiting for votes event")
+	}
+}
+func TestInsertCheckpointHeader(t *testing.T) {
+	chain := newTestBlockChain(true)
+	defer chain.Stop()
+	headers := makeHeaderChainWithDiff(chain.Genesis(), []int{1, 2, 3, 4, 5, 6}, 10)
+	checkpoint, td := headers[2], big.NewInt(100)
+	if err := chain.InsertCheckpointHeader(checkpoint, td); err != nil {
+		t.Fatalf("failed to insert checkpoint header: %v", err)
+	}
+	if head := chain.CurrentHeader(); head.Hash() != checkpoint.Hash() {
+		t.Errorf("head mismatch after checkpoint: have %x, want %x", head.Hash(), checkpoint.Hash())
+	}
+	if _, err := chain.InsertHeaderChain(headers[3:], 1); err != nil {
+		t.Fatalf("failed to insert headers on top of the checkpoint: %v", err)
+	}
+	head := chain.CurrentHeader()
+	if head.Hash() != headers[5].Hash() {
+		t.Errorf("head mismatch: have %x, want %x", head.Hash(), headers[5].Hash())
+	}
+	if have, want := chain.GetTd(head.Hash(), head.Number.Uint64()), big.NewInt(100+4+5+6); have.Cmp(want) != 0 {
+		t.Errorf("td mismatch: have %v, want %v", have, want)
+	}
+	if hash := chain.GetHeaderByNumber(3).Hash(); hash != checkpoint.Hash() {
+		t.Errorf("canonical checkpoint mismatch: have %x, want %x", hash, checkpoint.Hash())
+	}
+	if err := chain.InsertCheckpointHeader(headers[4], td); err != ErrCheckpointBehind {
+		t.Errorf("checkpoint behind head: have %v, want %v", err, ErrCheckpointBehind)
+	}
+}
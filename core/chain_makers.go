@@ -4,7 +4,6 @@ import (
 	"math/big"
 	"github.com/DEL-ORG/del/common"
 	"github.com/DEL-ORG/del/consensus"
-	"github.com/DEL-ORG/del/consensus/misc"
 	"github.com/DEL-ORG/del/core/state"
 	"github.com/DEL-ORG/del/core/types"
 	"github.com/DEL-ORG/del/core/vm"
@@ -26,6 +25,7 @@ type BlockGen struct {
 	txs      []*types.Transaction
 	receipts []*types.Receipt
 	uncles   []*types.Header
+	fees     *big.Int
 	producers   types.Producers
 	voters   types.Voters
 	config *params.ChainConfig
@@ -38,7 +38,15 @@ func (b *BlockGen) SetCoinbase(addr common.Address) {
 		}
 		panic("coinbase can only be set once")
 	}
-	b.header.Coinbase = addr
+	if slot := common.GetCurrentSlotByBigInt(b.header.Time); b.scheduled(slot) {
+		slot, ok := b.nextSlot(slot, addr)
+		if !ok {
+			panic("coinbase is not a scheduled producer")
+		}
+		b.setSlot(slot)
+	} else {
+		b.header.Coinbase = addr
+	}
 	b.gasPool = new(GasPool).AddGas(b.header.GasLimit)
 }
 func (b *BlockGen) SetExtra(data []byte) {
@@ -54,13 +62,14 @@ func (b *BlockGen) SetProducers(producers types.Producers) types.Producers {
 }
 func (b *BlockGen) AddTx(tx *types.Transaction) {
 	if b.gasPool == nil {
-		b.SetCoinbase(common.Address{})
+		b.SetCoinbase(b.header.Coinbase)
 	}
 	b.statedb.Prepare(tx.Hash(), common.Hash{}, len(b.txs))
-	receipt, _, _, err := ApplyTransaction(b.config, nil, &b.header.Coinbase, b.gasPool, b.statedb, b.header, tx, &b.header.GasUsed, vm.Config{})
+	receipt, _, fee, err := ApplyTransaction(b.config, nil, &b.header.Coinbase, b.gasPool, b.statedb, b.header, tx, &b.header.GasUsed, vm.Config{})
 	if err != nil {
 		panic(err)
 	}
+	b.fees.Add(b.fees, fee)
 	b.txs = append(b.txs, tx)
 	b.receipts = append(b.receipts, receipt)
 }
@@ -89,63 +98,90 @@ func (b *BlockGen) PrevBlock(index int) *types.Block {
 	return b.chain[index]
 }
 func (b *BlockGen) OffsetTime(seconds int64) {
+	if b.gasPool != nil {
+		panic("block time must be set before the coinbase")
+	}
 	b.header.Time.Add(b.header.Time, new(big.Int).SetInt64(seconds))
 	if b.header.Time.Cmp(b.parent.Header().Time) <= 0 {
 		panic("block time out of range")
 	}
-	b.header.Difficulty = b.engine.CalcDifficulty(b.chainReader, b.header, nil)
+	if len(b.producers) > 0 {
+		producer := b.producers[common.GetCurrentSlotByBigInt(b.header.Time)%int64(len(b.producers))]
+		if producer.Empty() {
+			panic("no producer scheduled for block time")
+		}
+		b.header.Coinbase = producer.Addr
+	}
 }
 func (b *BlockGen) GetTime() *big.Int {
 	return b.header.Time
 }
-func GenerateChain(config *params.ChainConfig, parent *types.Block, engine consensus.Engine, db ethdb.Database, n int, gen func(int, *BlockGen)) ([]*types.Block, []types.Receipts) {
-	if config == nil {
-		config = params.TestChainConfig
-	}
-	blocks, receipts := make(types.Blocks, n), make([]types.Receipts, n)
-	genblock := func(i int, parent *types.Block, statedb *state.StateDB) (*types.Block, types.Receipts) {
-		blockchain, _ := NewBlockChain(db, nil, config, engine, vm.Config{})
-		defer blockchain.Stop()
-		b := &BlockGen{i: i, parent: parent, chain: blocks, chainReader: blockchain, statedb: statedb, config: config, engine: engine}
-		b.header = makeHeader(b.chainReader, parent, statedb, b.engine)
-		if daoBlock := config.DAOForkBlock; daoBlock != nil {
-			limit := new(big.Int).Add(daoBlock, params.DAOForkExtraRange)
-			if b.header.Number.Cmp(daoBlock) >= 0 && b.header.Number.Cmp(limit) < 0 {
-				if config.DAOForkSupport {
-					b.header.Extra = common.CopyBytes(params.DAOForkBlockExtra)
-				}
-			}
-		}
-		if config.DAOForkSupport && config.DAOForkBlock != nil && config.DAOForkBlock.Cmp(b.header.Number) == 0 {
-			misc.ApplyDAOHardFork(statedb)
-		}
-		if gen != nil {
-			gen(i, b)
-		}
-		if b.engine != nil {
-			block, _ := b.engine.Finalize(b.chainReader, b.header, statedb, b.txs, b.uncles, b.receipts, b.producers, b.voters)
-			root, err := statedb.Commit(config.IsEIP158(b.header.Number))
-			if err != nil {
-				panic(fmt.Sprintf("state write error: %v", err))
-			}
-			if err := statedb.Database().TrieDB().Commit(root, false); err != nil {
-				panic(fmt.Sprintf("trie write error: %v", err))
-			}
-			return block, b.receipts
+func (b *BlockGen) nextSlot(slot int64, addr common.Address) (int64, bool) {
+	for i := int64(0); i < int64(len(b.producers)); i++ {
+		if producer := b.producers[(slot+i)%int64(len(b.producers))]; !producer.Empty() && (addr == (common.Address{}) || producer.Addr == addr) {
+			return slot + i, true
 		}
-		return nil, nil
 	}
-	for i := 0; i < n; i++ {
-		statedb, err := state.New(parent.Root(), state.NewDatabase(db))
-		if err != nil {
-			panic(err)
+	return slot, false
+}
+func (b *BlockGen) scheduled(slot int64) bool {
+	_, ok := b.nextSlot(slot, common.Address{})
+	return ok
+}
+func (b *BlockGen) setSlot(slot int64) {
+	b.header.Time = new(big.Int).SetInt64(slot * common.SLOT_BASE)
+	if len(b.producers) > 0 {
+		b.header.Coinbase = b.producers[slot%int64(len(b.producers))].Addr
+	}
+}
+type chainMaker struct {
+	*BlockChain
+	head     *types.Block
+	blocks   map[common.Hash]*types.Block
+	receipts map[common.Hash]types.Receipts
+}
+func (cm *chainMaker) add(block *types.Block, receipts types.Receipts) {
+	cm.head = block
+	cm.blocks[block.Hash()] = block
+	cm.receipts[block.Hash()] = receipts
+}
+func (cm *chainMaker) CurrentHeader() *types.Header {
+	return cm.head.Header()
+}
+func (cm *chainMaker) GetBlock(hash common.Hash, number uint64) *types.Block {
+	if block, ok := cm.blocks[hash]; ok && block.NumberU64() == number {
+		return block
+	}
+	return cm.BlockChain.GetBlock(hash, number)
+}
+func (cm *chainMaker) GetHeader(hash common.Hash, number uint64) *types.Header {
+	if block, ok := cm.blocks[hash]; ok && block.NumberU64() == number {
+		return block.Header()
+	}
+	return cm.BlockChain.GetHeader(hash, number)
+}
+func (cm *chainMaker) GetHeaderByHash(hash common.Hash) *types.Header {
+	if block, ok := cm.blocks[hash]; ok {
+		return block.Header()
+	}
+	return cm.BlockChain.GetHeaderByHash(hash)
+}
+func (cm *chainMaker) GetHeaderByNumber(number uint64) *types.Header {
+	for block := cm.head; block != nil; block = cm.blocks[block.ParentHash()] {
+		if block.NumberU64() == number {
+			return block.Header()
 		}
-		block, receipt := genblock(i, parent, statedb)
-		blocks[i] = block
-		receipts[i] = receipt
-		parent = block
 	}
-	return blocks, receipts
+	return cm.BlockChain.GetHeaderByNumber(number)
+}
+func (cm *chainMaker) GetReceiptsByHash(hash common.Hash) types.Receipts {
+	if receipts, ok := cm.receipts[hash]; ok {
+		return receipts
+	}
+	return cm.BlockChain.GetReceiptsByHash(hash)
+}
+func GenerateChain(config *params.ChainConfig, parent *types.Block, engine consensus.Engine, db ethdb.Database, n int, gen func(int, *BlockGen)) ([]*types.Block, []types.Receipts) {
+	return GenerateChainAndSave(config, parent, engine, db, n, gen, nil)
 }
 func GenerateChainAndSave(config *params.ChainConfig, parent *types.Block, engine consensus.Engine, db ethdb.Database, n int,
 	gen func(int, *BlockGen),
@@ -154,26 +190,34 @@ func GenerateChainAndSave(config *params.ChainConfig, parent *types.Block, engin
 		config = params.TestChainConfig
 	}
 	blocks, receipts := make(types.Blocks, n), make([]types.Receipts, n)
+	blockchain, _ := NewBlockChain(db, nil, config, engine, vm.Config{})
+	defer blockchain.Stop()
+	chain := &chainMaker{BlockChain: blockchain, head: parent, blocks: make(map[common.Hash]*types.Block), receipts: make(map[common.Hash]types.Receipts)}
+	if blockchain.GetBlock(parent.Hash(), parent.NumberU64()) == nil {
+		chain.add(parent, nil)
+	}
 	genblock := func(i int, parent *types.Block, statedb *state.StateDB) (*types.Block, types.Receipts) {
-		blockchain, _ := NewBlockChain(db, nil, config, engine, vm.Config{})
-		defer blockchain.Stop()
-		b := &BlockGen{i: i, parent: parent, chain: blocks, chainReader: blockchain, statedb: statedb, config: config, engine: engine}
+		b := &BlockGen{i: i, parent: parent, chain: blocks, chainReader: chain, statedb: statedb, fees: new(big.Int), config: config, engine: engine}
 		b.header = makeHeader(b.chainReader, parent, statedb, b.engine)
-		if daoBlock := config.DAOForkBlock; daoBlock != nil {
-			limit := new(big.Int).Add(daoBlock, params.DAOForkExtraRange)
-			if b.header.Number.Cmp(daoBlock) >= 0 && b.header.Number.Cmp(limit) < 0 {
-				if config.DAOForkSupport {
-					b.header.Extra = common.CopyBytes(params.DAOForkBlockExtra)
-				}
-			}
+		if b.engine != nil {
+			b.producers, _ = b.engine.CalProducers(b.chainReader, b.header)
 		}
-		if config.DAOForkSupport && config.DAOForkBlock != nil && config.DAOForkBlock.Cmp(b.header.Number) == 0 {
-			misc.ApplyDAOHardFork(statedb)
+		start := common.GetCurrentSlotByBigInt(parent.Time()) + 1
+		if genesis := int64(common.GENESIS_TIME / common.SLOT_BASE); start < genesis {
+			start = genesis
 		}
+		slot, _ := b.nextSlot(start, common.Address{})
+		b.setSlot(slot)
+		ApplyReleaseGenesisBalance(b.chainReader, b.header, statedb)
+		ApplyRandaoReveal(config, b.header, statedb)
 		if gen != nil {
 			gen(i, b)
 		}
 		if b.engine != nil {
+			ApplyFees(config, b.header, statedb, b.fees, b.voters)
+			ApplyReleaseVoterBalance(b.chainReader, b.header, statedb, b.txs, b.receipts)
+			ApplyGovernance(config, b.header, statedb, b.producers)
+			b.header.Difficulty = b.engine.CalcDifficulty(b.chainReader, b.header, b.txs)
 			block, _ := b.engine.Finalize(b.chainReader, b.header, statedb, b.txs, b.uncles, b.receipts, b.producers, b.voters)
 			root, err := statedb.Commit(config.IsEIP158(b.header.Number))
 			if err != nil {
@@ -182,6 +226,7 @@ func GenerateChainAndSave(config *params.ChainConfig, parent *types.Block, engin
 			if err := statedb.Database().TrieDB().Commit(root, false); err != nil {
 				panic(fmt.Sprintf("trie write error: %v", err))
 			}
+			chain.add(block, b.receipts)
 			return block, b.receipts
 		}
 		return nil, nil
@@ -192,10 +237,8 @@ func GenerateChainAndSave(config *params.ChainConfig, parent *types.Block, engin
 			panic(err)
 		}
 		block, receipt := genblock(i, parent, statedb)
-		if i <= 0 {
+		if onBlock != nil {
 			onBlock(parent, block)
-		}else {
-			onBlock(blocks[i - 1], block)
 		}
 		blocks[i] = block
 		receipts[i] = receipt
@@ -214,19 +257,14 @@ func makeHeader(chain consensus.ChainReader, parent *types.Block, state *state.S
 		Root:       state.IntermediateRoot(chain.Config().IsEIP158(parent.Number())),
 		ParentHash: parent.Hash(),
 		Coinbase:   parent.Coinbase(),
-		Difficulty: engine.CalcDifficulty(chain, &types.Header{
-			Number:     parent.Number(),
-			Time:       new(big.Int).Sub(time, big.NewInt(10)),
-			Difficulty: parent.Difficulty(),
-			UncleHash:  parent.UncleHash(),
-		}, nil),
+		Difficulty: new(big.Int),
 		GasLimit: CalcGasLimit(parent),
 		Number:   new(big.Int).Add(parent.Number(), common.Big1),
-		Time:     parent.Time(),
+		Time:     time,
 	}
 }
 func newCanonical(engine consensus.Engine, n int, full bool) (ethdb.Database, *BlockChain, error) {
-	gspec := new(Genesis)
+	gspec := &Genesis{Alloc: GenesisAlloc{{Addr: common.Address{0xff}, Balance: new(big.Int), Producer: true}}}
 	db, _ := ethdb.NewMemDatabase()
 	genesis := gspec.MustCommit(db)
 	blockchain, _ := NewBlockChain(db, nil, params.AllEthashProtocolChanges, engine, vm.Config{})
@@ -252,7 +290,7 @@ func makeHeaderChain(parent *types.Header, n int, engine consensus.Engine, db et
 }
 func makeBlockChain(parent *types.Block, n int, engine consensus.Engine, db ethdb.Database, seed int) []*types.Block {
 	blocks, _ := GenerateChain(params.TestChainConfig, parent, engine, db, n, func(i int, b *BlockGen) {
-		b.SetCoinbase(common.Address{0: byte(seed), 19: byte(i)})
+		b.SetExtra([]byte{byte(seed), byte(i)})
 	})
 	return blocks
 }
@@ -21,7 +21,7 @@ func ExampleGenerateChain() {
 	)
 	gspec := &Genesis{
 		Config: &params.ChainConfig{HomesteadBlock: new(big.Int)},
-		Alloc:  GenesisAlloc{addr1: {Balance: big.NewInt(1000000)}},
+		Alloc:  GenesisAlloc{{Addr: addr1, Balance: big.NewInt(1000000)}},
 	}
 	genesis := gspec.MustCommit(db)
 	signer := types.HomesteadSigner{}
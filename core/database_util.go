@@ -21,9 +21,10 @@ type DatabaseDeleter interface {
 	Delete(key []byte) error
 }
 var (
-	headHeaderKey = []byte("LastHeader")
-	headBlockKey  = []byte("LastBlock")
-	headFastKey   = []byte("LastFast")
+	headHeaderKey     = []byte("LastHeader")
+	headBlockKey      = []byte("LastBlock")
+	headFastKey       = []byte("LastFast")
+	lastCheckpointKey = []byte("LastCheckpoint")
 	headerPrefix        = []byte("h") 
 	tdSuffix            = []byte("t") 
 	numSuffix           = []byte("n") 
@@ -35,6 +36,7 @@ var (
 	preimagePrefix = "secure-key-"              
 	configPrefix   = []byte("ethereum-config-") 
 	BloomBitsIndexPrefix = []byte("iB") 
+	checkpointPrefix     = []byte("checkpoint-")
 	oldReceiptsPrefix = []byte("receipts-")
 	oldTxMetaSuffix   = []byte{0x01}
 	ErrChainConfigNotFound = errors.New("ChainConfig not found") 
@@ -426,6 +428,41 @@ func GetChainConfig(db DatabaseReader, hash common.Hash) (*params.ChainConfig, e
 	}
 	return &config, nil
 }
+func GetCheckpoint(db DatabaseReader, number uint64) *types.Checkpoint {
+	data, _ := db.Get(append(checkpointPrefix, encodeBlockNumber(number)...))
+	if len(data) == 0 {
+		return nil
+	}
+	checkpoint := new(types.Checkpoint)
+	if err := rlp.DecodeBytes(data, checkpoint); err != nil {
+		log.Error("Invalid checkpoint RLP", "number", number, "err", err)
+		return nil
+	}
+	return checkpoint
+}
+func WriteCheckpoint(db ethdb.Putter, checkpoint *types.Checkpoint) error {
+	data, err := rlp.EncodeToBytes(checkpoint)
+	if err != nil {
+		return err
+	}
+	if err := db.Put(append(checkpointPrefix, encodeBlockNumber(checkpoint.Number)...), data); err != nil {
+		log.Crit("Failed to store checkpoint", "err", err)
+	}
+	return nil
+}
+func GetLastCheckpoint(db DatabaseReader) *types.Checkpoint {
+	data, _ := db.Get(lastCheckpointKey)
+	if len(data) != 8 {
+		return nil
+	}
+	return GetCheckpoint(db, binary.BigEndian.Uint64(data))
+}
+func WriteLastCheckpoint(db ethdb.Putter, number uint64) error {
+	if err := db.Put(lastCheckpointKey, encodeBlockNumber(number)); err != nil {
+		log.Crit("Failed to store last checkpoint", "err", err)
+	}
+	return nil
+}
 func FindCommonAncestor(db DatabaseReader, a, b *types.Header) *types.Header {
 	for bn := b.Number.Uint64(); a.Number.Uint64() > bn; {
 		a = GetHeader(db, a.ParentHash, a.Number.Uint64()-1)
@@ -0,0 +1,118 @@
+package core
+import (
+	"math/big"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/core/vm"
+	"github.com/DEL-ORG/del/crypto"
+	"github.com/DEL-ORG/del/log"
+	"github.com/DEL-ORG/del/rlp"
+)
+var DelegationAddress = common.BytesToAddress([]byte("delegation"))
+var delegationProducersKey = crypto.Keccak256Hash([]byte("producers"))
+type Delegation struct {
+	Producer common.Address
+	Amount   *big.Int
+}
+type Delegations []Delegation
+func (self Delegations) Total() *big.Int {
+	total := new(big.Int)
+	for _, delegation := range self {
+		total.Add(total, delegation.Amount)
+	}
+	return total
+}
+func NewDelegations(tickets common.DataProtocolTickets) Delegations {
+	delegations := make(Delegations, 0, len(tickets))
+	for _, ticket := range tickets {
+		delegations = append(delegations, Delegation{Producer: common.HexToAddress(ticket.Addr), Amount: ticket.GetAmount()})
+	}
+	return delegations
+}
+func delegationKey(voter common.Address) common.Hash {
+	return crypto.Keccak256Hash([]byte("delegation"), voter[:])
+}
+func delegationTotalKey(producer common.Address) common.Hash {
+	return crypto.Keccak256Hash([]byte("total"), producer[:])
+}
+func delegationVotersKey(producer common.Address) common.Hash {
+	return crypto.Keccak256Hash([]byte("voters"), producer[:])
+}
+func GetDelegations(db vm.StateDB, voter common.Address) Delegations {
+	data := getStateBytes(db, DelegationAddress, delegationKey(voter))
+	if len(data) == 0 {
+		return nil
+	}
+	var delegations Delegations
+	if err := rlp.DecodeBytes(data, &delegations); err != nil {
+		log.Error("Invalid delegation entry", "voter", voter, "err", err)
+		return nil
+	}
+	return delegations
+}
+func GetDelegatedVote(db vm.StateDB, producer common.Address) *big.Int {
+	return getStateBig(db, DelegationAddress, delegationTotalKey(producer))
+}
+func GetDelegatedProducers(db vm.StateDB) []common.Address {
+	return getStateSet(db, DelegationAddress, delegationProducersKey)
+}
+func GetDelegators(db vm.StateDB, producer common.Address) map[common.Address]*big.Int {
+	delegators := make(map[common.Address]*big.Int)
+	for _, voter := range getStateSet(db, DelegationAddress, delegationVotersKey(producer)) {
+		for _, delegation := range GetDelegations(db, voter) {
+			if delegation.Producer == producer {
+				delegators[voter] = new(big.Int).Set(delegation.Amount)
+			}
+		}
+	}
+	return delegators
+}
+func GetDelegatedVotes(db vm.StateDB) types.VotersMap {
+	votes := types.VotersMap{}
+	for _, producer := range GetDelegatedProducers(db) {
+		votes[producer] = GetDelegatedVote(db, producer)
+	}
+	return votes
+}
+func CanDelegate(db vm.StateDB, voter common.Address, tickets common.DataProtocolTickets) bool {
+	available := new(big.Int).Add(db.GetBalance(voter), GetDelegations(db, voter).Total())
+	return available.Cmp(tickets.TotalAmount()) >= 0
+}
+func Delegate(db vm.StateDB, voter common.Address, tickets common.DataProtocolTickets) {
+	touchSystemAccount(db, DelegationAddress)
+	previous, delegations := GetDelegations(db, voter), NewDelegations(tickets)
+	for _, delegation := range previous {
+		key := delegationTotalKey(delegation.Producer)
+		total := new(big.Int).Sub(getStateBig(db, DelegationAddress, key), delegation.Amount)
+		setStateBig(db, DelegationAddress, key, total)
+		removeStateSet(db, DelegationAddress, delegationVotersKey(delegation.Producer), voter)
+		if total.Sign() == 0 {
+			removeStateSet(db, DelegationAddress, delegationProducersKey, delegation.Producer)
+		}
+	}
+	for _, delegation := range delegations {
+		key := delegationTotalKey(delegation.Producer)
+		setStateBig(db, DelegationAddress, key, new(big.Int).Add(getStateBig(db, DelegationAddress, key), delegation.Amount))
+		addStateSet(db, DelegationAddress, delegationVotersKey(delegation.Producer), voter)
+		addStateSet(db, DelegationAddress, delegationProducersKey, delegation.Producer)
+	}
+	switch diff := new(big.Int).Sub(delegations.Total(), previous.Total()); diff.Sign() {
+	case 1:
+		db.SubBalance(voter, diff)
+		db.AddFreeze(voter, diff)
+	case -1:
+		diff.Neg(diff)
+		db.SubFreeze(voter, diff)
+		db.AddBalance(voter, diff)
+	}
+	if len(delegations) == 0 {
+		setStateBytes(db, DelegationAddress, delegationKey(voter), nil)
+		return
+	}
+	data, err := rlp.EncodeToBytes(delegations)
+	if err != nil {
+		log.Error("Failed to encode delegation entry", "voter", voter, "err", err)
+		return
+	}
+	setStateBytes(db, DelegationAddress, delegationKey(voter), data)
+}
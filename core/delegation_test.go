@@ -0,0 +1,104 @@
+package core
+import (
+	"math/big"
+	"testing"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/vm"
+	"github.com/DEL-ORG/del/params"
+)
+func delegationTickets(amounts map[common.Address]int64) common.DataProtocolTickets {
+	tickets := common.DataProtocolTickets{}
+	for producer, amount := range amounts {
+		tickets = append(tickets, common.DataProtocolVote{Addr: producer.Hex(), Amount: big.NewInt(amount)})
+	}
+	return tickets
+}
+func TestDelegate(t *testing.T) {
+	var (
+		statedb = newTestState()
+		one     = common.Address{0x01}
+		two     = common.Address{0x02}
+		alice   = common.Address{0xaa}
+		bob     = common.Address{0xbb}
+	)
+	statedb.AddBalance(one, big.NewInt(1000))
+	statedb.AddBalance(two, big.NewInt(1000))
+	tests := []struct {
+		voter   common.Address
+		tickets map[common.Address]int64
+		balance int64
+		freeze  int64
+		alice   int64
+		bob     int64
+	}{
+		{one, map[common.Address]int64{alice: 100, bob: 200}, 700, 300, 100, 200},
+		{two, map[common.Address]int64{alice: 50}, 950, 50, 150, 200},
+		{one, map[common.Address]int64{alice: 400}, 600, 400, 450, 0},
+		{one, map[common.Address]int64{bob: 10}, 990, 10, 50, 10},
+		{one, nil, 1000, 0, 50, 0},
+	}
+	for i, tt := range tests {
+		tickets := delegationTickets(tt.tickets)
+		if !CanDelegate(statedb, tt.voter, tickets) {
+			t.Fatalf("test %d: delegation rejected", i)
+		}
+		Delegate(statedb, tt.voter, tickets)
+		if balance := statedb.GetBalance(tt.voter); balance.Int64() != tt.balance {
+			t.Errorf("test %d: balance mismatch: have %v, want %d", i, balance, tt.balance)
+		}
+		if freeze := statedb.GetFreeze(tt.voter); freeze.Int64() != tt.freeze {
+			t.Errorf("test %d: freeze mismatch: have %v, want %d", i, freeze, tt.freeze)
+		}
+		if total := GetDelegations(statedb, tt.voter).Total(); total.Int64() != tt.freeze {
+			t.Errorf("test %d: delegation total mismatch: have %v, want %d", i, total, tt.freeze)
+		}
+		if vote := GetDelegatedVote(statedb, alice); vote.Int64() != tt.alice {
+			t.Errorf("test %d: first producer vote mismatch: have %v, want %d", i, vote, tt.alice)
+		}
+		if vote := GetDelegatedVote(statedb, bob); vote.Int64() != tt.bob {
+			t.Errorf("test %d: second producer vote mismatch: have %v, want %d", i, vote, tt.bob)
+		}
+		votes := GetDelegatedVotes(statedb)
+		for producer, want := range map[common.Address]int64{alice: tt.alice, bob: tt.bob} {
+			if vote, ok := votes[producer]; ok != (want != 0) || (ok && vote.Int64() != want) {
+				t.Errorf("test %d: delegated votes mismatch for %x: have %v, want %d", i, producer, vote, want)
+			}
+		}
+	}
+	delegators := GetDelegators(statedb, alice)
+	if len(delegators) != 1 || delegators[two].Int64() != 50 {
+		t.Errorf("delegators mismatch: have %v", delegators)
+	}
+	if CanDelegate(statedb, two, delegationTickets(map[common.Address]int64{alice: 1001})) {
+		t.Errorf("overdrawn delegation accepted")
+	}
+	if !CanDelegate(statedb, two, delegationTickets(map[common.Address]int64{alice: 1000})) {
+		t.Errorf("delegation of balance and previous delegation rejected")
+	}
+}
+func TestDelegateCall(t *testing.T) {
+	var (
+		statedb  = newTestState()
+		voter    = common.Address{0x01}
+		producer = common.Address{0xaa}
+	)
+	statedb.AddBalance(voter, big.NewInt(1000))
+	tickets := delegationTickets(map[common.Address]int64{producer: 600})
+	data, _ := (&common.DataProtocol{MessageID: common.DataProtocolMessageID_DELEGATE, Tickets: tickets}).Encode()
+	evm := newSystemEVM(statedb)
+	gas := params.DelegationGas(tickets)
+	checkSystemCall(t, evm, voter, voter, data, gas)
+	if freeze := statedb.GetFreeze(voter); freeze.Int64() != 600 {
+		t.Errorf("freeze mismatch: have %v, want 600", freeze)
+	}
+	tickets = delegationTickets(map[common.Address]int64{producer: 1601})
+	data, _ = (&common.DataProtocol{MessageID: common.DataProtocolMessageID_DELEGATE, Tickets: tickets}).Encode()
+	if _, _, err := evm.Call(vm.AccountRef(voter), voter, data, gas, new(big.Int)); err != vm.ErrInsufficientDelegation {
+		t.Errorf("overdrawn delegation: have %v, want %v", err, vm.ErrInsufficientDelegation)
+	}
+	tickets = append(tickets, tickets[0])
+	data, _ = (&common.DataProtocol{MessageID: common.DataProtocolMessageID_DELEGATE, Tickets: tickets}).Encode()
+	if _, _, err := evm.Call(vm.AccountRef(voter), voter, data, params.DelegationGas(tickets), new(big.Int)); err != common.ErrDelegationDuplicate {
+		t.Errorf("duplicate delegation: have %v, want %v", err, common.ErrDelegationDuplicate)
+	}
+}
@@ -5,4 +5,6 @@ var (
 	ErrGasLimitReached = errors.New("gas limit reached")
 	ErrBlacklistedHash = errors.New("blacklisted hash")
 	ErrNonceTooHigh = errors.New("nonce too high")
+	ErrRandaoState = errors.New("randao mix requires state")
+	ErrCheckpointBehind = errors.New("checkpoint behind local chain")
 )
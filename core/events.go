@@ -20,3 +20,16 @@ type ChainSideEvent struct {
 	Block *types.Block
 }
 type ChainHeadEvent struct{ Block *types.Block }
+type NewRoundEvent struct {
+	Round     uint64
+	Block     *types.Block
+	Producers types.Producers
+}
+type VotesEvent struct {
+	Block *types.Block
+	Votes []types.OutputVote
+}
+type TextsEvent struct {
+	Block *types.Block
+	Texts []types.OutputText
+}
@@ -22,7 +22,18 @@ func NewEVMContext(msg Message, header *types.Header, chain ChainContext, author
 		Transfer:    Transfer,
 		CanVote:CanVote,
 		Vote:Vote,
+		CanLock: CanLock,
+		Lock: Lock,
+		CanDelegate: CanDelegate,
+		Delegate: Delegate,
+		Propose: Propose,
+		CanApprove: CanApprove,
+		Approve: Approve,
+		CanRegisterAlias: CanRegisterAlias,
+		RegisterAlias: RegisterAlias,
 		GetHash:     GetHashFn(header, chain),
+		GetProducers: GetProducersFn(header, chain),
+		GetCandidateVote: GetCandidateVoteFn(header, chain),
 		Origin:      msg.From(),
 		Coinbase:    beneficiary,
 		BlockNumber: new(big.Int).Set(header.Number),
@@ -42,6 +53,35 @@ func GetHashFn(ref *types.Header, chain ChainContext) func(n uint64) common.Hash
 		return common.Hash{}
 	}
 }
+func GetProducersFn(ref *types.Header, chain ChainContext) func() types.Producers {
+	return func() types.Producers {
+		reader, ok := chain.(consensus.ChainReader)
+		if !ok {
+			return nil
+		}
+		producers, err := chain.Engine().CalProducers(reader, ref)
+		if err != nil {
+			return nil
+		}
+		return producers
+	}
+}
+func GetCandidateVoteFn(ref *types.Header, chain ChainContext) func(common.Address) *big.Int {
+	return func(addr common.Address) *big.Int {
+		reader, ok := chain.(consensus.ChainReader)
+		if !ok {
+			return common.Big0
+		}
+		parent := chain.GetHeader(ref.ParentHash, ref.Number.Uint64()-1)
+		if parent == nil {
+			return common.Big0
+		}
+		if vote, ok := reader.GetVotersState(parent)[addr]; ok {
+			return new(big.Int).Set(vote)
+		}
+		return common.Big0
+	}
+}
 func CanTransfer(db vm.StateDB, addr common.Address, amount *big.Int) bool {
 	return db.GetBalance(addr).Cmp(amount) >= 0
 }
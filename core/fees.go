@@ -1,5 +1,68 @@
 package core
 import (
 	"math/big"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/core/vm"
+	"github.com/DEL-ORG/del/params"
 )
 var BlockReward = big.NewInt(5e+18)
+func BlockFees(txs types.Transactions, receipts types.Receipts) *big.Int {
+	fees := big.NewInt(0)
+	for i, receipt := range receipts {
+		if i >= len(txs) {
+			break
+		}
+		fees.Add(fees, new(big.Int).Mul(new(big.Int).SetUint64(receipt.GasUsed), txs[i].GasPrice()))
+	}
+	return fees
+}
+func FeeShares(split *params.FeeSplitConfig, header *types.Header, fees *big.Int, voters types.Voters) map[common.Address]*big.Int {
+	shares := make(map[common.Address]*big.Int)
+	credit := func(addr common.Address, amount *big.Int) {
+		if amount.Sign() <= 0 {
+			return
+		}
+		if share, ok := shares[addr]; ok {
+			share.Add(share, amount)
+		} else {
+			shares[addr] = new(big.Int).Set(amount)
+		}
+	}
+	if split == nil {
+		credit(header.Coinbase, fees)
+		return shares
+	}
+	coinbase, _, voterShare, treasury := split.Split(fees)
+	credit(split.TreasuryAddress, treasury)
+	var totalRank uint64
+	for _, voter := range voters {
+		totalRank += voter.Rank
+	}
+	if totalRank == 0 {
+		coinbase.Add(coinbase, voterShare)
+	} else {
+		paid := new(big.Int)
+		for _, voter := range voters {
+			r := new(big.Int).Mul(voterShare, new(big.Int).SetUint64(voter.Rank))
+			r.Div(r, new(big.Int).SetUint64(totalRank))
+			credit(voter.Addr, r)
+			paid.Add(paid, r)
+		}
+		coinbase.Add(coinbase, paid.Sub(voterShare, paid))
+	}
+	credit(header.Coinbase, coinbase)
+	return shares
+}
+func ApplyFees(config *params.ChainConfig, header *types.Header, db vm.StateDB, fees *big.Int, voters types.Voters) {
+	shares := FeeShares(GovernedFeeSplit(config, header, db), header, fees, voters)
+	coinbase, ok := shares[header.Coinbase]
+	if !ok {
+		coinbase = new(big.Int)
+	}
+	db.AddBalance(header.Coinbase, coinbase)
+	delete(shares, header.Coinbase)
+	for addr, amount := range shares {
+		db.AddBalance(addr, amount)
+	}
+}
@@ -0,0 +1,60 @@
+package core
+import (
+	"math/big"
+	"testing"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/params"
+)
+func TestApplyFees(t *testing.T) {
+	var (
+		coinbase = common.Address{0xcb}
+		treasury = common.Address{0xee}
+		alice    = common.Address{0xaa}
+		bob      = common.Address{0xbb}
+		voters   = types.Voters{{Addr: alice, Rank: 1}, {Addr: bob, Rank: 2}}
+		split    = &params.FeeSplitConfig{Coinbase: 50, Burn: 20, Voters: 20, Treasury: 10, TreasuryAddress: treasury}
+	)
+	tests := []struct {
+		block    int64
+		voters   types.Voters
+		coinbase int64
+		treasury int64
+		alice    int64
+		bob      int64
+	}{
+		{9, voters, 1000, 0, 0, 0},
+		{10, voters, 501, 100, 66, 133},
+		{10, nil, 700, 100, 0, 0},
+	}
+	for i, tt := range tests {
+		var (
+			statedb = newTestState()
+			config  = &params.ChainConfig{FeeSplitBlock: big.NewInt(10), FeeSplit: split}
+			header  = &types.Header{Number: big.NewInt(tt.block), Coinbase: coinbase}
+		)
+		ApplyFees(config, header, statedb, big.NewInt(1000), tt.voters)
+		for addr, want := range map[common.Address]int64{coinbase: tt.coinbase, treasury: tt.treasury, alice: tt.alice, bob: tt.bob} {
+			if balance := statedb.GetBalance(addr); balance.Int64() != want {
+				t.Errorf("test %d: balance mismatch for %x: have %v, want %d", i, addr, balance, want)
+			}
+		}
+		total := big.NewInt(0)
+		for _, share := range FeeShares(GovernedFeeSplit(config, header, statedb), header, big.NewInt(1000), tt.voters) {
+			total.Add(total, share)
+		}
+		if burnt := 1000 - total.Int64(); (tt.block < 10 && burnt != 0) || (tt.block >= 10 && burnt != 200) {
+			t.Errorf("test %d: burnt fee mismatch: have %d", i, burnt)
+		}
+	}
+}
+func TestBlockFees(t *testing.T) {
+	txs := types.Transactions{
+		types.NewTransaction(0, common.Address{}, big.NewInt(0), 21000, big.NewInt(2), nil),
+		types.NewTransaction(1, common.Address{}, big.NewInt(0), 50000, big.NewInt(3), nil),
+	}
+	receipts := types.Receipts{{GasUsed: 21000}, {GasUsed: 30000}}
+	if fees := BlockFees(txs, receipts); fees.Int64() != 21000*2+30000*3 {
+		t.Errorf("block fees mismatch: have %v, want %d", fees, 21000*2+30000*3)
+	}
+}
@@ -16,6 +16,7 @@ func (g GenesisAccount) MarshalJSON() ([]byte, error) {
 		Freeze    *math.HexOrDecimal256       `json:"freeze" gencodec:"required"`
 		Addr common.Address               `json:"addr" gencodec:"required"`
 		Producer bool              `json:"producer" gencodec:"required"`
+		Vesting    []common.VestingSchedule    `json:"vesting,omitempty"`
 		Nonce      math.HexOrDecimal64         `json:"nonce,omitempty"`
 		PrivateKey hexutil.Bytes               `json:"secretKey,omitempty"`
 	}
@@ -31,6 +32,7 @@ func (g GenesisAccount) MarshalJSON() ([]byte, error) {
 	enc.Freeze = (*math.HexOrDecimal256)(g.Freeze)
 	enc.Addr = g.Addr
 	enc.Producer = g.Producer
+	enc.Vesting = g.Vesting
 	enc.Nonce = math.HexOrDecimal64(g.Nonce)
 	enc.PrivateKey = g.PrivateKey
 	return json.Marshal(&enc)
@@ -43,6 +45,7 @@ func (g *GenesisAccount) UnmarshalJSON(input []byte) error {
 		Freeze    *math.HexOrDecimal256       `json:"freeze" gencodec:"required"`
 		Addr *hexutil.Bytes              `json:"addr" gencodec:"required"`
 		Producer bool             `json:"producer" gencodec:"required"`
+		Vesting    []common.VestingSchedule    `json:"vesting,omitempty"`
 		Nonce      *math.HexOrDecimal64        `json:"nonce,omitempty"`
 		PrivateKey *hexutil.Bytes              `json:"secretKey,omitempty"`
 	}
@@ -65,6 +68,7 @@ func (g *GenesisAccount) UnmarshalJSON(input []byte) error {
 	g.Balance = (*big.Int)(dec.Balance)
 	g.Freeze = (*big.Int)(dec.Freeze)
 	g.Producer = dec.Producer
+	g.Vesting = dec.Vesting
 	if dec.Addr != nil {
 		g.Addr = common.BytesToAddress(*dec.Addr)
 	}
@@ -58,6 +58,7 @@ type GenesisAccount struct {
 	Balance    *big.Int                    `json:"balance" gencodec:"required"`
 	Freeze     *big.Int                    `json:"freeze" gencodec:"required"`
 	Producer   bool                        `json:"producer" gencodec:"required"`
+	Vesting    []common.VestingSchedule    `json:"vesting,omitempty"`
 	Nonce      uint64                      `json:"nonce,omitempty"`
 	PrivateKey []byte                      `json:"secretKey,omitempty"` 
 }
@@ -104,6 +105,14 @@ func SetupGenesisBlock(db ethdb.Database, genesis *Genesis) (*params.ChainConfig
 	if genesis != nil && genesis.Config == nil {
 		return params.AllEthashProtocolChanges, common.Hash{}, errGenesisNoConfig
 	}
+	if genesis != nil {
+		if err := genesis.Config.Validate(); err != nil {
+			return genesis.Config, common.Hash{}, err
+		}
+		if err := genesis.validateVesting(); err != nil {
+			return genesis.Config, common.Hash{}, err
+		}
+	}
 	stored := GetCanonicalHash(db, 0)
 	if (stored == common.Hash{}) {
 		if genesis == nil {
@@ -122,6 +131,9 @@ func SetupGenesisBlock(db ethdb.Database, genesis *Genesis) (*params.ChainConfig
 		}
 	}
 	newcfg := genesis.configOrDefault(stored)
+	if err := newcfg.Validate(); err != nil {
+		return newcfg, stored, err
+	}
 	storedcfg, err := GetChainConfig(db, stored)
 	if err != nil {
 		if err == ErrChainConfigNotFound {
@@ -130,7 +142,9 @@ func SetupGenesisBlock(db ethdb.Database, genesis *Genesis) (*params.ChainConfig
 		}
 		return newcfg, stored, err
 	}
-	
+	if genesis == nil && stored != params.MainnetGenesisHash {
+		return storedcfg, stored, nil
+	}
 	height := GetBlockNumber(db, GetHeadHeaderHash(db))
 	if height == missingNumber {
 		return newcfg, stored, fmt.Errorf("missing block number for head header hash")
@@ -159,7 +173,9 @@ func (g *Genesis) ToBlock(db ethdb.Database) *types.Block {
 	for _, account := range g.Alloc { 
 		addr := account.Addr
 		statedb.AddBalance(addr, account.Balance)
-		statedb.AddFreeze(addr, account.Freeze)
+		if account.Freeze != nil {
+			statedb.AddFreeze(addr, account.Freeze)
+		}
 		statedb.SetCode(addr, account.Code)
 		statedb.SetNonce(addr, account.Nonce)
 		for key, value := range account.Storage {
@@ -168,6 +184,9 @@ func (g *Genesis) ToBlock(db ethdb.Database) *types.Block {
 		if account.Producer {
 			producers = append(producers, types.Producer{Addr: addr, Vote: new(big.Int).SetInt64(0)})
 		}
+		for _, schedule := range account.Vesting {
+			AddVesting(statedb, addr, schedule, g.Number)
+		}
 	}
 	if len(producers) > common.LEADER_LIMIT {
 		log.Error("Genesis producers error", "len", len(producers))
@@ -189,7 +208,7 @@ func (g *Genesis) ToBlock(db ethdb.Database) *types.Block {
 		Root:       root,
 	}
 	if g.GasLimit == 0 {
-		head.GasLimit = params.GenesisGasLimit
+		head.GasLimit = params.MinGasLimit
 	}
 	if g.Difficulty == nil {
 		head.Difficulty = params.GenesisDifficulty
@@ -198,7 +217,20 @@ func (g *Genesis) ToBlock(db ethdb.Database) *types.Block {
 	statedb.Database().TrieDB().Commit(root, true)
 	return types.NewBlock(head, nil, nil, nil, producers, nil)
 }
+func (g *Genesis) validateVesting() error {
+	for _, account := range g.Alloc {
+		for _, schedule := range account.Vesting {
+			if err := schedule.Validate(); err != nil {
+				return fmt.Errorf("invalid vesting schedule for %x: %v", account.Addr, err)
+			}
+		}
+	}
+	return nil
+}
 func (g *Genesis) Commit(db ethdb.Database) (*types.Block, error) {
+	if err := g.validateVesting(); err != nil {
+		return nil, err
+	}
 	block := g.ToBlock(db)
 	if block.Number().Sign() != 0 {
 		return nil, fmt.Errorf("can't commit genesis block with number > 0")
@@ -235,7 +267,7 @@ func (g *Genesis) MustCommit(db ethdb.Database) *types.Block {
 	return block
 }
 func GenesisBlockForTesting(db ethdb.Database, addr common.Address, balance *big.Int) *types.Block {
-	g := Genesis{Alloc: GenesisAlloc{{Addr: addr, Balance: balance}}}
+	g := Genesis{Alloc: GenesisAlloc{{Addr: addr, Balance: balance, Producer: true}}}
 	return g.MustCommit(db)
 }
 func DefaultGenesisBlock() *Genesis {
@@ -272,26 +304,23 @@ func DefaultRinkebyGenesisBlock() *Genesis {
 		Alloc:      decodePrealloc(rinkebyAllocData),
 	}
 }
-func DeveloperGenesisBlock(period uint64, faucet common.Address) *Genesis {
-	config := *params.AllCliqueProtocolChanges
-	config.Clique.Period = period
-	return &Genesis{
+func DeveloperGenesisBlock(faucet common.Address) *Genesis {
+	config := *params.AllEthashProtocolChanges
+	genesis := &Genesis{
 		Config:     &config,
-		ExtraData:  append(append(make([]byte, 32), faucet[:]...), make([]byte, 65)...),
-		GasLimit:   6283185,
-		Difficulty: big.NewInt(1),
-		Alloc: []GenesisAccount{
-			{Addr: common.BytesToAddress([]byte{1}), Balance: big.NewInt(1)}, 
-			{Addr: common.BytesToAddress([]byte{2}), Balance: big.NewInt(1)}, 
-			{Addr: common.BytesToAddress([]byte{3}), Balance: big.NewInt(1)}, 
-			{Addr: common.BytesToAddress([]byte{4}), Balance: big.NewInt(1)}, 
-			{Addr: common.BytesToAddress([]byte{5}), Balance: big.NewInt(1)}, 
-			{Addr: common.BytesToAddress([]byte{6}), Balance: big.NewInt(1)}, 
-			{Addr: common.BytesToAddress([]byte{7}), Balance: big.NewInt(1)}, 
-			{Addr: common.BytesToAddress([]byte{8}), Balance: big.NewInt(1)}, 
-			{Addr: faucet, Balance: new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(9))},
-		},
+		Timestamp:  uint64(common.GENESIS_TIME),
+		GasLimit:   params.MinGasLimit,
+		Difficulty: big.NewInt(0),
+		Coinbase:   faucet,
+	}
+	for i := byte(1); i <= 8; i++ {
+		genesis.Alloc = append(genesis.Alloc, GenesisAccount{Addr: common.BytesToAddress([]byte{i}), Balance: big.NewInt(1), Freeze: new(big.Int)})
+	}
+	genesis.Alloc = append(genesis.Alloc, GenesisAccount{Addr: faucet, Balance: new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(9)), Freeze: new(big.Int), Producer: true})
+	for i := 1; i < common.LEADER_LIMIT; i++ {
+		genesis.Alloc = append(genesis.Alloc, GenesisAccount{Addr: faucet, Balance: new(big.Int), Freeze: new(big.Int), Producer: true})
 	}
+	return genesis
 }
 func decodePrealloc(data string) GenesisAlloc {
 	var p []struct {
@@ -22,11 +22,11 @@ func TestDefaultGenesisBlock(t *testing.T) {
 }
 func TestSetupGenesis(t *testing.T) {
 	var (
-		customghash = common.HexToHash("0x89c99d90b79719238d2645c7642f2c9295246e80775b38cfd162b696817fbd50")
+		customghash = common.HexToHash("0x4c87f69d3eb8cb346b83ac89c0413f9ec2349311ae8e3ecc5782be4055eb30fa")
 		customg     = Genesis{
 			Config: &params.ChainConfig{HomesteadBlock: big.NewInt(3)},
 			Alloc: GenesisAlloc{
-				{1}: {Balance: big.NewInt(1), Storage: map[common.Hash]common.Hash{{1}: {1}}},
+				{Addr: common.Address{1}, Balance: big.NewInt(1), Storage: map[common.Hash]common.Hash{{1}: {1}}},
 			},
 		}
 		oldcustomg = customg
@@ -133,3 +133,40 @@ func TestSetupGenesis(t *testing.T) {
 		}
 	}
 }
+func TestDeveloperGenesisRestart(t *testing.T) {
+	var (
+		db, _   = ethdb.NewMemDatabase()
+		faucet  = common.Address{0xfa}
+		genesis = DeveloperGenesisBlock(faucet)
+	)
+	if genesis.Timestamp != uint64(common.GENESIS_TIME) {
+		t.Fatalf("developer genesis timestamp mismatch: have %d, want %d", genesis.Timestamp, common.GENESIS_TIME)
+	}
+	_, hash, err := SetupGenesisBlock(db, genesis)
+	if err != nil {
+		t.Fatalf("failed to set up developer genesis: %v", err)
+	}
+	_, restarted, err := SetupGenesisBlock(db, DeveloperGenesisBlock(faucet))
+	if err != nil {
+		t.Fatalf("developer genesis rejected on restart: %v", err)
+	}
+	if restarted != hash {
+		t.Errorf("developer genesis hash changed on restart: have %x, want %x", restarted, hash)
+	}
+}
+func TestSetupGenesisFeeSplit(t *testing.T) {
+	db, _ := ethdb.NewMemDatabase()
+	genesis := DeveloperGenesisBlock(common.Address{0xfa})
+	if _, _, err := SetupGenesisBlock(db, genesis); err != nil {
+		t.Fatalf("failed to set up genesis: %v", err)
+	}
+	config := *genesis.Config
+	config.FeeSplit = &params.FeeSplitConfig{Coinbase: params.MaxFeeSplitWeight + 1}
+	genesis.Config = &config
+	if _, _, err := SetupGenesisBlock(db, genesis); err == nil {
+		t.Errorf("genesis with an out of range fee split accepted")
+	}
+	if _, _, err := SetupGenesisBlock(db, nil); err != nil {
+		t.Errorf("stored genesis rejected: %v", err)
+	}
+}
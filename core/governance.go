@@ -0,0 +1,235 @@
+package core
+import (
+	"math/big"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/core/vm"
+	"github.com/DEL-ORG/del/crypto"
+	"github.com/DEL-ORG/del/log"
+	"github.com/DEL-ORG/del/params"
+	"github.com/DEL-ORG/del/rlp"
+)
+var GovernanceAddress = common.BytesToAddress([]byte("governance"))
+var (
+	governanceCountKey = crypto.Keccak256Hash([]byte("count"))
+	governanceOpenKey  = crypto.Keccak256Hash([]byte("open"))
+)
+const (
+	ProposalPending uint64 = iota + 1
+	ProposalApproved
+	ProposalActive
+	ProposalExpired
+)
+type Proposal struct {
+	Id         uint64                     `json:"id"`
+	Proposer   common.Address             `json:"proposer"`
+	Round      uint64                     `json:"round"`
+	Status     uint64                     `json:"status"`
+	Activation uint64                     `json:"activation"`
+	Changes    common.DataProtocolTickets `json:"changes"`
+	Approvals  []common.Address           `json:"approvals"`
+}
+func proposalKey(id uint64) common.Hash {
+	return crypto.Keccak256Hash([]byte("proposal"), new(big.Int).SetUint64(id).Bytes())
+}
+func proposalChangesKey(id uint64) common.Hash {
+	return crypto.Keccak256Hash([]byte("changes"), new(big.Int).SetUint64(id).Bytes())
+}
+func proposalApprovalsKey(id uint64) common.Hash {
+	return crypto.Keccak256Hash([]byte("approvals"), new(big.Int).SetUint64(id).Bytes())
+}
+func governanceParamKey(name string) common.Hash {
+	return crypto.Keccak256Hash([]byte("param"), []byte(name))
+}
+func governanceParamSetKey(name string) common.Hash {
+	return crypto.Keccak256Hash([]byte("set"), []byte(name))
+}
+func GetProposalCount(db vm.StateDB) uint64 {
+	return getStateUint(db, GovernanceAddress, governanceCountKey)
+}
+func getProposalStatus(db vm.StateDB, id uint64) uint64 {
+	return getStateUint(db, GovernanceAddress, stateSlot(proposalKey(id), 2))
+}
+func getProposalChanges(db vm.StateDB, id uint64) common.DataProtocolTickets {
+	data := getStateBytes(db, GovernanceAddress, proposalChangesKey(id))
+	if len(data) == 0 {
+		return nil
+	}
+	var changes common.DataProtocolTickets
+	if err := rlp.DecodeBytes(data, &changes); err != nil {
+		log.Error("Invalid governance proposal", "id", id, "err", err)
+		return nil
+	}
+	return changes
+}
+func GetProposal(db vm.StateDB, id uint64) *Proposal {
+	if id == 0 || id > GetProposalCount(db) {
+		return nil
+	}
+	key := proposalKey(id)
+	return &Proposal{
+		Id:         id,
+		Proposer:   common.BytesToAddress(db.GetState(GovernanceAddress, stateSlot(key, 0)).Bytes()),
+		Round:      getStateUint(db, GovernanceAddress, stateSlot(key, 1)),
+		Status:     getProposalStatus(db, id),
+		Activation: getStateUint(db, GovernanceAddress, stateSlot(key, 3)),
+		Changes:    getProposalChanges(db, id),
+		Approvals:  getStateSet(db, GovernanceAddress, proposalApprovalsKey(id)),
+	}
+}
+func GetOpenProposals(db vm.StateDB) []*Proposal {
+	var proposals []*Proposal
+	open, count := getStateUint(db, GovernanceAddress, governanceOpenKey), GetProposalCount(db)
+	for id := open; open > 0 && id <= count; id++ {
+		if proposal := GetProposal(db, id); proposal.Status == ProposalPending || proposal.Status == ProposalApproved {
+			proposals = append(proposals, proposal)
+		}
+	}
+	return proposals
+}
+func Propose(db vm.StateDB, proposer common.Address, tickets common.DataProtocolTickets, round uint64) uint64 {
+	touchSystemAccount(db, GovernanceAddress)
+	id := GetProposalCount(db) + 1
+	data, err := rlp.EncodeToBytes(tickets)
+	if err != nil {
+		log.Error("Failed to encode governance proposal", "proposer", proposer, "err", err)
+		return 0
+	}
+	setStateUint(db, GovernanceAddress, governanceCountKey, id)
+	if getStateUint(db, GovernanceAddress, governanceOpenKey) == 0 {
+		setStateUint(db, GovernanceAddress, governanceOpenKey, id)
+	}
+	key := proposalKey(id)
+	db.SetState(GovernanceAddress, stateSlot(key, 0), proposer.Hash())
+	setStateUint(db, GovernanceAddress, stateSlot(key, 1), round)
+	setStateUint(db, GovernanceAddress, stateSlot(key, 2), ProposalPending)
+	setStateBytes(db, GovernanceAddress, proposalChangesKey(id), data)
+	return id
+}
+func CanApprove(db vm.StateDB, tickets common.DataProtocolTickets) bool {
+	for _, ticket := range tickets {
+		if id := ticket.GetAmount(); !id.IsUint64() || getProposalStatus(db, id.Uint64()) != ProposalPending {
+			return false
+		}
+	}
+	return true
+}
+func Approve(db vm.StateDB, producer common.Address, tickets common.DataProtocolTickets) {
+	touchSystemAccount(db, GovernanceAddress)
+	for _, ticket := range tickets {
+		addStateSet(db, GovernanceAddress, proposalApprovalsKey(ticket.GetAmount().Uint64()), producer)
+	}
+}
+func GovernanceQuorum(producers int) int {
+	return producers*2/3 + 1
+}
+func ApplyGovernance(config *params.ChainConfig, header *types.Header, db vm.StateDB, producers types.Producers) {
+	number := header.Number.Uint64()
+	round := common.GetRoundNumberByBlockNumber(number, config.GetRoundLength())
+	if !config.IsGovernance(header.Number) || number == 0 || number != common.GetEndBlockNumberByRoundNumber(round, config.GetRoundLength()) {
+		return
+	}
+	open, count := getStateUint(db, GovernanceAddress, governanceOpenKey), GetProposalCount(db)
+	if open == 0 || open > count {
+		return
+	}
+	schedule := make(map[common.Address]struct{})
+	for _, producer := range producers.GetWithOutEmpty() {
+		schedule[producer.Addr] = struct{}{}
+	}
+	quorum := GovernanceQuorum(len(schedule))
+	next, closed := open, true
+	for id := open; id <= count; id++ {
+		key := proposalKey(id)
+		status := getProposalStatus(db, id)
+		if status == ProposalPending {
+			approvals := 0
+			for _, producer := range getStateSet(db, GovernanceAddress, proposalApprovalsKey(id)) {
+				if _, ok := schedule[producer]; ok {
+					approvals++
+				}
+			}
+			switch {
+			case len(schedule) > 0 && approvals >= quorum:
+				status = ProposalApproved
+				setStateUint(db, GovernanceAddress, stateSlot(key, 3), round+1+common.GOVERNANCE_DELAY_ROUNDS)
+				log.Info("Governance proposal approved", "id", id, "approvals", approvals, "activation", round+1+common.GOVERNANCE_DELAY_ROUNDS)
+			case round+1 >= getStateUint(db, GovernanceAddress, stateSlot(key, 1))+common.GOVERNANCE_VOTING_ROUNDS:
+				status = ProposalExpired
+			}
+		}
+		if status == ProposalApproved && getStateUint(db, GovernanceAddress, stateSlot(key, 3)) <= round+1 {
+			changes := getProposalChanges(db, id)
+			weights := GetGovernanceParams(config, db)
+			for _, change := range changes {
+				weights[change.Addr] = change.GetAmount()
+			}
+			if err := params.ValidateFeeSplitParams(weights); config.FeeSplit != nil && err != nil {
+				status = ProposalExpired
+				log.Warn("Governance proposal rejected", "id", id, "err", err)
+			} else {
+				for _, change := range changes {
+					setStateBig(db, GovernanceAddress, governanceParamKey(change.Addr), change.GetAmount())
+					setStateUint(db, GovernanceAddress, governanceParamSetKey(change.Addr), 1)
+				}
+				status = ProposalActive
+				log.Info("Governance proposal activated", "id", id, "round", round+1)
+			}
+		}
+		setStateUint(db, GovernanceAddress, stateSlot(key, 2), status)
+		if closed && (status == ProposalActive || status == ProposalExpired) {
+			next = id + 1
+		} else {
+			closed = false
+		}
+	}
+	setStateUint(db, GovernanceAddress, governanceOpenKey, next)
+}
+func GetGovernanceParam(db vm.StateDB, name string) (*big.Int, bool) {
+	if getStateUint(db, GovernanceAddress, governanceParamSetKey(name)) == 0 {
+		return nil, false
+	}
+	return getStateBig(db, GovernanceAddress, governanceParamKey(name)), true
+}
+func GetGovernanceParams(config *params.ChainConfig, db vm.StateDB) map[string]*big.Int {
+	split := config.FeeSplit
+	if split == nil {
+		split = new(params.FeeSplitConfig)
+	}
+	active := map[string]*big.Int{
+		params.GovGasLimitTarget: new(big.Int).SetUint64(params.TargetGasLimit),
+		params.GovVoteMoneyLimit: new(big.Int).Set(common.VOTE_MONEY_LIMIT),
+		params.GovFeeCoinbase:    new(big.Int).SetUint64(split.Coinbase),
+		params.GovFeeBurn:        new(big.Int).SetUint64(split.Burn),
+		params.GovFeeVoters:      new(big.Int).SetUint64(split.Voters),
+		params.GovFeeTreasury:    new(big.Int).SetUint64(split.Treasury),
+	}
+	for _, name := range params.GovernanceParams {
+		if value, ok := GetGovernanceParam(db, name); ok {
+			active[name] = value
+		}
+	}
+	return active
+}
+func GasLimitTarget(db vm.StateDB) uint64 {
+	if value, ok := GetGovernanceParam(db, params.GovGasLimitTarget); ok {
+		return value.Uint64()
+	}
+	return params.TargetGasLimit
+}
+func VoteMoneyLimit(db vm.StateDB) *big.Int {
+	if value, ok := GetGovernanceParam(db, params.GovVoteMoneyLimit); ok {
+		return value
+	}
+	return common.VOTE_MONEY_LIMIT
+}
+func GovernedFeeSplit(config *params.ChainConfig, header *types.Header, db vm.StateDB) *params.FeeSplitConfig {
+	if !config.IsFeeSplit(header.Number) {
+		return nil
+	}
+	if db == nil || !config.IsGovernance(header.Number) {
+		return config.FeeSplit
+	}
+	weights := GetGovernanceParams(config, db)
+	return config.FeeSplit.WithWeights(weights[params.GovFeeCoinbase].Uint64(), weights[params.GovFeeBurn].Uint64(), weights[params.GovFeeVoters].Uint64(), weights[params.GovFeeTreasury].Uint64())
+}
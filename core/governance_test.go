@@ -0,0 +1,136 @@
+package core
+import (
+	"math/big"
+	"testing"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/core/vm"
+	"github.com/DEL-ORG/del/params"
+)
+func approvalTickets(ids ...uint64) common.DataProtocolTickets {
+	tickets := common.DataProtocolTickets{}
+	for _, id := range ids {
+		tickets = append(tickets, common.DataProtocolVote{Amount: new(big.Int).SetUint64(id)})
+	}
+	return tickets
+}
+func TestApplyGovernance(t *testing.T) {
+	var (
+		statedb   = newTestState()
+		config    = &params.ChainConfig{FeeSplitBlock: big.NewInt(0), GovernanceBlock: big.NewInt(0), FeeSplit: &params.FeeSplitConfig{Coinbase: 100}}
+		producers = types.Producers{{Addr: common.Address{0x01}, Vote: big.NewInt(1)}, {Addr: common.Address{0x02}, Vote: big.NewInt(1)}, {Addr: common.Address{0x03}, Vote: big.NewInt(1)}, {Addr: common.Address{0x04}, Vote: big.NewInt(1)}, {}}
+		outsider  = common.Address{0xee}
+	)
+	burn := common.DataProtocolTickets{{Addr: params.GovFeeBurn, Amount: big.NewInt(50)}, {Addr: params.GovGasLimitTarget, Amount: big.NewInt(8000000)}}
+	if id := Propose(statedb, producers[0].Addr, burn, 1); id != 1 {
+		t.Fatalf("first proposal id mismatch: have %d, want 1", id)
+	}
+	limit := common.DataProtocolTickets{{Addr: params.GovVoteMoneyLimit, Amount: big.NewInt(1)}}
+	if id := Propose(statedb, producers[1].Addr, limit, 1); id != 2 {
+		t.Fatalf("second proposal id mismatch: have %d, want 2", id)
+	}
+	for _, producer := range producers[:3] {
+		Approve(statedb, producer.Addr, approvalTickets(1))
+	}
+	Approve(statedb, producers[0].Addr, approvalTickets(2))
+	Approve(statedb, producers[1].Addr, approvalTickets(2))
+	Approve(statedb, outsider, approvalTickets(2))
+	tests := []struct {
+		number   uint64
+		first    uint64
+		second   uint64
+		burn     uint64
+		target   uint64
+		approved bool
+	}{
+		{common.LEADER_NUMBER - 1, ProposalPending, ProposalPending, 0, params.TargetGasLimit, true},
+		{common.LEADER_NUMBER, ProposalApproved, ProposalPending, 0, params.TargetGasLimit, true},
+		{common.LEADER_NUMBER * 2, ProposalActive, ProposalPending, 50, 8000000, true},
+		{common.LEADER_NUMBER * 3, ProposalActive, ProposalExpired, 50, 8000000, false},
+	}
+	for i, tt := range tests {
+		ApplyGovernance(config, &types.Header{Number: new(big.Int).SetUint64(tt.number)}, statedb, producers)
+		if status := GetProposal(statedb, 1).Status; status != tt.first {
+			t.Errorf("test %d: first proposal status mismatch: have %d, want %d", i, status, tt.first)
+		}
+		if status := GetProposal(statedb, 2).Status; status != tt.second {
+			t.Errorf("test %d: second proposal status mismatch: have %d, want %d", i, status, tt.second)
+		}
+		if split := GovernedFeeSplit(config, &types.Header{Number: big.NewInt(1)}, statedb); split.Burn != tt.burn || split.Coinbase != 100 {
+			t.Errorf("test %d: fee split mismatch: have %v", i, split)
+		}
+		if target := GasLimitTarget(statedb); target != tt.target {
+			t.Errorf("test %d: gas limit target mismatch: have %d, want %d", i, target, tt.target)
+		}
+		if approved := CanApprove(statedb, approvalTickets(2)); approved != tt.approved {
+			t.Errorf("test %d: approval acceptance mismatch: have %v, want %v", i, approved, tt.approved)
+		}
+	}
+	if proposal := GetProposal(statedb, 1); proposal.Activation != 3 || proposal.Round != 1 || len(proposal.Changes) != 2 || len(proposal.Approvals) != 3 {
+		t.Errorf("first proposal mismatch: have %+v", proposal)
+	}
+	if limit := VoteMoneyLimit(statedb); limit.Cmp(common.VOTE_MONEY_LIMIT) != 0 {
+		t.Errorf("vote money limit mismatch: have %v, want %v", limit, common.VOTE_MONEY_LIMIT)
+	}
+	if open := GetOpenProposals(statedb); len(open) != 0 {
+		t.Errorf("open proposals mismatch: have %d, want 0", len(open))
+	}
+}
+func TestGovernanceCall(t *testing.T) {
+	var (
+		statedb  = newTestState()
+		producer = common.Address{0x01}
+		outsider = common.Address{0xee}
+	)
+	changes := common.DataProtocolTickets{{Addr: params.GovFeeBurn, Amount: big.NewInt(10)}}
+	propose, _ := (&common.DataProtocol{MessageID: common.DataProtocolMessageID_PROPOSE, Tickets: changes}).Encode()
+	evm := newSystemEVM(statedb, producer)
+	gas := params.GovernanceGas(changes)
+	if _, _, err := evm.Call(vm.AccountRef(outsider), outsider, propose, gas, new(big.Int)); err != vm.ErrNotProducer {
+		t.Errorf("proposal from outsider: have %v, want %v", err, vm.ErrNotProducer)
+	}
+	checkSystemCall(t, evm, producer, producer, propose, gas)
+	unknown, _ := (&common.DataProtocol{MessageID: common.DataProtocolMessageID_PROPOSE, Tickets: common.DataProtocolTickets{{Addr: "unknown", Amount: big.NewInt(1)}}}).Encode()
+	if _, _, err := evm.Call(vm.AccountRef(producer), producer, unknown, gas, new(big.Int)); err != params.ErrUnknownGovernanceParam {
+		t.Errorf("unknown parameter: have %v, want %v", err, params.ErrUnknownGovernanceParam)
+	}
+	heavy, _ := (&common.DataProtocol{MessageID: common.DataProtocolMessageID_PROPOSE, Tickets: common.DataProtocolTickets{{Addr: params.GovFeeBurn, Amount: big.NewInt(params.MaxFeeSplitWeight + 1)}}}).Encode()
+	if _, _, err := evm.Call(vm.AccountRef(producer), producer, heavy, gas, new(big.Int)); err != params.ErrGovernanceParamValue {
+		t.Errorf("out of range fee weight: have %v, want %v", err, params.ErrGovernanceParamValue)
+	}
+	approve, _ := (&common.DataProtocol{MessageID: common.DataProtocolMessageID_APPROVE, Tickets: approvalTickets(1)}).Encode()
+	if _, _, err := evm.Call(vm.AccountRef(producer), producer, approve, gas, new(big.Int)); err != nil {
+		t.Fatalf("approval failed: %v", err)
+	}
+	if approvals := GetProposal(statedb, 1).Approvals; len(approvals) != 1 || approvals[0] != producer {
+		t.Errorf("approvals mismatch: have %v", approvals)
+	}
+	missing, _ := (&common.DataProtocol{MessageID: common.DataProtocolMessageID_APPROVE, Tickets: approvalTickets(2)}).Encode()
+	if _, _, err := evm.Call(vm.AccountRef(producer), producer, missing, gas, new(big.Int)); err != vm.ErrProposalNotPending {
+		t.Errorf("approval of unknown proposal: have %v, want %v", err, vm.ErrProposalNotPending)
+	}
+}
+func TestApplyGovernanceZeroFeeSplit(t *testing.T) {
+	var (
+		statedb   = newTestState()
+		config    = &params.ChainConfig{FeeSplitBlock: big.NewInt(0), GovernanceBlock: big.NewInt(0), FeeSplit: &params.FeeSplitConfig{Coinbase: 100}}
+		producers = types.Producers{{Addr: common.Address{0x01}, Vote: big.NewInt(1)}, {Addr: common.Address{0x02}, Vote: big.NewInt(1)}}
+	)
+	zero := common.DataProtocolTickets{{Addr: params.GovFeeCoinbase, Amount: big.NewInt(0)}}
+	Propose(statedb, producers[0].Addr, zero, 1)
+	for _, producer := range producers {
+		Approve(statedb, producer.Addr, approvalTickets(1))
+	}
+	for _, number := range []uint64{common.LEADER_NUMBER, common.LEADER_NUMBER * 2} {
+		ApplyGovernance(config, &types.Header{Number: new(big.Int).SetUint64(number)}, statedb, producers)
+	}
+	if status := GetProposal(statedb, 1).Status; status != ProposalExpired {
+		t.Errorf("zero fee split proposal status mismatch: have %d, want %d", status, ProposalExpired)
+	}
+	if split := GovernedFeeSplit(config, &types.Header{Number: big.NewInt(1)}, statedb); split.Coinbase != 100 {
+		t.Errorf("fee split changed by a rejected proposal: have %v", split)
+	}
+	if open := GetOpenProposals(statedb); len(open) != 0 {
+		t.Errorf("open proposals mismatch: have %d, want 0", len(open))
+	}
+}
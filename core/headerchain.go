@@ -67,9 +67,15 @@ func NewHeaderChain(chainDb ethdb.Database, config *params.ChainConfig, engine c
 func (bc *HeaderChain)GetVotersState(header *types.Header) types.VotersMap {
 	return nil
 }
+func (bc *HeaderChain)GetRandaoMix(header *types.Header) (common.Hash, error) {
+	return common.Hash{}, ErrRandaoState
+}
 func (bc *HeaderChain)GetVoters(header *types.Header) types.Voters {
 	return nil
 }
+func (bc *HeaderChain)GetReceiptsByHash(hash common.Hash) types.Receipts {
+	return nil
+}
 func (hc *HeaderChain) GetBlockNumber(hash common.Hash) uint64 {
 	if cached, ok := hc.numberCache.Get(hash); ok {
 		return cached.(uint64)
@@ -131,6 +137,25 @@ func (hc *HeaderChain) WriteHeader(header *types.Header) (status WriteStatus, er
 	hc.numberCache.Add(hash, number)
 	return
 }
+func (hc *HeaderChain) InsertCheckpointHeader(header *types.Header, td *big.Int) error {
+	hash, number := header.Hash(), header.Number.Uint64()
+	if hc.currentHeader.Number.Uint64() >= number {
+		return ErrCheckpointBehind
+	}
+	if err := hc.WriteTd(hash, number, td); err != nil {
+		return err
+	}
+	if err := WriteHeader(hc.chainDb, header); err != nil {
+		return err
+	}
+	if err := WriteCanonicalHash(hc.chainDb, hash, number); err != nil {
+		return err
+	}
+	hc.headerCache.Add(hash, header)
+	hc.numberCache.Add(hash, number)
+	hc.SetCurrentHeader(types.CopyHeader(header))
+	return nil
+}
 type WhCallback func(*types.Header) error
 func (hc *HeaderChain) GetGenesisBlock() *types.Block{
 	return nil
@@ -2,10 +2,62 @@ package core
 import (
 	"container/list"
 	"fmt"
+	"math/big"
+	"testing"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/state"
 	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/core/vm"
 	"github.com/DEL-ORG/del/ethdb"
 	"github.com/DEL-ORG/del/event"
+	"github.com/DEL-ORG/del/params"
 )
+func newTestState() *state.StateDB {
+	db, _ := ethdb.NewMemDatabase()
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(db))
+	return statedb
+}
+func newProducerGenesis(producers ...common.Address) *Genesis {
+	genesis := new(Genesis)
+	for _, producer := range producers {
+		genesis.Alloc = append(genesis.Alloc, GenesisAccount{Addr: producer, Balance: new(big.Int), Producer: true})
+	}
+	return genesis
+}
+func newSystemEVM(statedb *state.StateDB, producers ...common.Address) *vm.EVM {
+	context := vm.Context{
+		CanTransfer:      CanTransfer,
+		Transfer:         Transfer,
+		CanVote:          CanVote,
+		Vote:             Vote,
+		CanLock:          CanLock,
+		Lock:             Lock,
+		CanDelegate:      CanDelegate,
+		Delegate:         Delegate,
+		Propose:          Propose,
+		CanApprove:       CanApprove,
+		Approve:          Approve,
+		CanRegisterAlias: CanRegisterAlias,
+		RegisterAlias:    RegisterAlias,
+		GetProducers: func() types.Producers {
+			var scheduled types.Producers
+			for _, producer := range producers {
+				scheduled = append(scheduled, types.Producer{Addr: producer, Vote: big.NewInt(1)})
+			}
+			return scheduled
+		},
+		BlockNumber: big.NewInt(5),
+	}
+	return vm.NewEVM(context, statedb, params.TestChainConfig, vm.Config{})
+}
+func checkSystemCall(t *testing.T, evm *vm.EVM, from, to common.Address, data []byte, gas uint64) {
+	if _, _, err := evm.Call(vm.AccountRef(from), to, data, gas-1, new(big.Int)); err != vm.ErrOutOfGas {
+		t.Errorf("call without enough gas: have %v, want %v", err, vm.ErrOutOfGas)
+	}
+	if _, left, err := evm.Call(vm.AccountRef(from), to, data, gas, new(big.Int)); err != nil || left != 0 {
+		t.Fatalf("call failed: left %d, err %v", left, err)
+	}
+}
 type TestManager struct {
 	eventMux *event.TypeMux
 	db         ethdb.Database
@@ -0,0 +1,34 @@
+package core
+import (
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/core/vm"
+	"github.com/DEL-ORG/del/crypto"
+	"github.com/DEL-ORG/del/params"
+)
+var RandaoAddress = common.BytesToAddress([]byte("randao"))
+var randaoMixKey = crypto.Keccak256Hash([]byte("mix"))
+func randaoCommitKey(producer common.Address) common.Hash {
+	return crypto.Keccak256Hash([]byte("commit"), producer[:])
+}
+func GetRandaoMix(db vm.StateDB) common.Hash {
+	return db.GetState(RandaoAddress, randaoMixKey)
+}
+func GetRandaoCommit(db vm.StateDB, producer common.Address) common.Hash {
+	return db.GetState(RandaoAddress, randaoCommitKey(producer))
+}
+func ApplyRandaoReveal(config *params.ChainConfig, header *types.Header, db vm.StateDB) bool {
+	if !config.IsRandao(header.Number) || header.MixDigest == (common.Hash{}) {
+		return false
+	}
+	touchSystemAccount(db, RandaoAddress)
+	key, reveal := randaoCommitKey(header.Coinbase), header.MixDigest
+	commit := db.GetState(RandaoAddress, key)
+	db.SetState(RandaoAddress, key, reveal)
+	if commit == (common.Hash{}) || crypto.Keccak256Hash(reveal[:]) != commit {
+		return false
+	}
+	mix := GetRandaoMix(db)
+	db.SetState(RandaoAddress, randaoMixKey, crypto.Keccak256Hash(mix[:], reveal[:]))
+	return true
+}
@@ -0,0 +1,63 @@
+package core
+import (
+	"math/big"
+	"testing"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/crypto"
+	"github.com/DEL-ORG/del/params"
+)
+func TestRandaoReveal(t *testing.T) {
+	var (
+		statedb  = newTestState()
+		producer = common.Address{0x01}
+		secret   = common.Hash{0xff}
+		layer    = crypto.Keccak256Hash(secret[:])
+		tip      = crypto.Keccak256Hash(layer[:])
+	)
+	tests := []struct {
+		reveal common.Hash
+		mixed  bool
+	}{
+		{common.Hash{}, false},
+		{tip, false},
+		{common.Hash{0x02}, false},
+		{tip, false},
+		{layer, true},
+		{layer, false},
+		{secret, true},
+		{common.Hash{0x03}, false},
+	}
+	for i, tt := range tests {
+		mix := GetRandaoMix(statedb)
+		header := &types.Header{Number: big.NewInt(int64(i + 1)), Coinbase: producer, MixDigest: tt.reveal}
+		if mixed := ApplyRandaoReveal(params.TestChainConfig, header, statedb); mixed != tt.mixed {
+			t.Errorf("test %d: mixed mismatch: have %v, want %v", i, mixed, tt.mixed)
+		}
+		if changed := GetRandaoMix(statedb) != mix; changed != tt.mixed {
+			t.Errorf("test %d: mix changed %v, want %v", i, changed, tt.mixed)
+		}
+		if tt.reveal != (common.Hash{}) && GetRandaoCommit(statedb, producer) != tt.reveal {
+			t.Errorf("test %d: commitment not updated", i)
+		}
+	}
+	if ApplyRandaoReveal(params.MainnetChainConfig, &types.Header{Number: big.NewInt(1), Coinbase: producer, MixDigest: secret}, statedb) {
+		t.Errorf("reveal applied before the randao fork")
+	}
+}
+func TestRandaoMixRequiresState(t *testing.T) {
+	chain := newTestBlockChain(true)
+	defer chain.Stop()
+	genesis := chain.Genesis().Header()
+	if _, err := chain.GetRandaoMix(genesis); err != nil {
+		t.Errorf("genesis mix: have %v, want nil", err)
+	}
+	missing := types.CopyHeader(genesis)
+	missing.Root = common.Hash{0x01}
+	if _, err := chain.GetRandaoMix(missing); err != ErrRandaoState {
+		t.Errorf("missing state: have %v, want %v", err, ErrRandaoState)
+	}
+	if _, err := chain.hc.GetRandaoMix(genesis); err != ErrRandaoState {
+		t.Errorf("header chain: have %v, want %v", err, ErrRandaoState)
+	}
+}
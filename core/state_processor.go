@@ -26,17 +26,23 @@ func ApplyReleaseGenesisBalance(chain consensus.ChainReader, header *types.Heade
 	if header.Number.Uint64() <= 0 {
 		return
 	}
+	ApplyVestingReleases(state, header.Number.Uint64())
 	if header.Number.Uint64() % common.RELEASE_NUMBER != 0 {
 		return
 	}
-	genesis := DefaultGenesisBlock()
-	if chain.Config().ChainId == DefaultTestnetGenesisBlock().Config.ChainId {
+	var genesis *Genesis
+	switch chain.GetGenesisBlock().Hash() {
+	case params.MainnetGenesisHash:
+		genesis = DefaultGenesisBlock()
+	case params.TestnetGenesisHash:
 		genesis = DefaultTestnetGenesisBlock()
+	default:
+		return
 	}
 	if header.Number.Uint64() / common.RELEASE_NUMBER <= common.RELEASE_TIMES {
 		for _, account := range genesis.Alloc {
 			if account.Freeze.Cmp(common.Big0) <= 0 {
-				break
+				continue
 			}
 			freeze := new(big.Int).Set(account.Freeze)
 			freeze.Div(freeze, new(big.Int).SetInt64(common.RELEASE_TIMES))
@@ -46,7 +52,7 @@ func ApplyReleaseGenesisBalance(chain consensus.ChainReader, header *types.Heade
 	}else if (header.Number.Uint64() / common.RELEASE_NUMBER) == (common.RELEASE_TIMES + 1) {
 		for _, account := range genesis.Alloc {
 			if account.Freeze.Cmp(common.Big0) <= 0 {
-				break
+				continue
 			}
 			remain := new(big.Int).Set(account.Freeze)
 			freeze := new(big.Int).Set(account.Freeze)
@@ -59,13 +65,38 @@ func ApplyReleaseGenesisBalance(chain consensus.ChainReader, header *types.Heade
 		}
 	}
 }
-func ApplyReleaseVoterBalance(chain consensus.ChainReader, header *types.Header, state *state.StateDB, transactions types.Transactions) {
-	current_round_number := common.GetRoundNumberByBlockNumber(header.Number.Uint64())
-	current_round_end_block_number := common.GetEndBlockNumberByRoundNumber(current_round_number)
+type ContractVote struct {
+	TxHash   common.Hash
+	Voter    common.Address
+	Producer common.Address
+	Amount   *big.Int
+}
+func GetContractVotes(receipts types.Receipts) (votes []ContractVote) {
+	for _, receipt := range receipts {
+		if receipt == nil {
+			continue
+		}
+		for _, l := range receipt.Logs {
+			if l.Address != vm.DposVoteAddress || len(l.Topics) != 3 || l.Topics[0] != vm.DposVoteTopic {
+				continue
+			}
+			votes = append(votes, ContractVote{
+				TxHash:   l.TxHash,
+				Voter:    common.BytesToAddress(l.Topics[1].Bytes()),
+				Producer: common.BytesToAddress(l.Topics[2].Bytes()),
+				Amount:   new(big.Int).SetBytes(l.Data),
+			})
+		}
+	}
+	return votes
+}
+func ApplyReleaseVoterBalance(chain consensus.ChainReader, header *types.Header, state *state.StateDB, transactions types.Transactions, receipts types.Receipts) {
+	current_round_number := common.GetRoundNumberByBlockNumber(header.Number.Uint64(), chain.Config().GetRoundLength())
+	current_round_end_block_number := common.GetEndBlockNumberByRoundNumber(current_round_number, chain.Config().GetRoundLength())
 	if header.Number.Uint64() != current_round_end_block_number {
 		return
 	}
-	current_round_begin_block_number := common.GetBeginBlockNumberByRoundNumber(current_round_number)
+	current_round_begin_block_number := common.GetBeginBlockNumberByRoundNumber(current_round_number, chain.Config().GetRoundLength())
 	process := func(signer types.Signer, txs types.Transactions) {
 		for _, tx := range txs {
 			message, err  := tx.GetMessage()
@@ -82,10 +113,17 @@ func ApplyReleaseVoterBalance(chain consensus.ChainReader, header *types.Header,
 			}
 		}
 	}
+	processContract := func(receipts types.Receipts) {
+		for _, vote := range GetContractVotes(receipts) {
+			state.SubFreeze(vote.Voter, vote.Amount)
+			state.AddBalance(vote.Voter, vote.Amount)
+		}
+	}
 	if transactions != nil {
 		signer := types.MakeSigner(chain.Config(), header.Number)
 		process(signer, transactions)
 	}
+	processContract(receipts)
 	for header = chain.GetHeader(header.ParentHash, header.Number.Uint64() - 1);
 		header != nil && header.Number.Uint64() >= current_round_begin_block_number;
 		header = chain.GetHeader(header.ParentHash, header.Number.Uint64() - 1) {
@@ -95,6 +133,7 @@ func ApplyReleaseVoterBalance(chain consensus.ChainReader, header *types.Header,
 		}
 		signer := types.MakeSigner(chain.Config(), header.Number)
 		process(signer, block.Transactions())
+		processContract(chain.GetReceiptsByHash(header.Hash()))
 	}
 }
 func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg vm.Config) (types.Receipts, []*types.Log, uint64, error) {
@@ -107,6 +146,7 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 	)
 	
 	ApplyReleaseGenesisBalance(p.bc, block.Header(), statedb)
+	ApplyRandaoReveal(p.config, header, statedb)
 	totalReward := big.NewInt(0)
 	for i, tx := range block.Transactions() {
 		statedb.Prepare(tx.Hash(), block.Hash(), i)
@@ -118,8 +158,9 @@ func (p *StateProcessor) Process(block *types.Block, statedb *state.StateDB, cfg
 		receipts = append(receipts, receipt)
 		allLogs = append(allLogs, receipt.Logs...)
 	}
-	statedb.AddBalance(block.Coinbase(), totalReward)
-	ApplyReleaseVoterBalance(p.bc, block.Header(), statedb, block.Transactions())
+	ApplyFees(p.config, header, statedb, totalReward, block.Voters)
+	ApplyReleaseVoterBalance(p.bc, block.Header(), statedb, block.Transactions(), receipts)
+	ApplyGovernance(p.config, header, statedb, block.Producers())
 	p.engine.Finalize(p.bc, header, statedb, block.Transactions(), block.Uncles(), receipts, block.Producers(), block.Voters)
 	return receipts, allLogs, *usedGas, nil
 }
@@ -0,0 +1,88 @@
+package core
+import (
+	"math/big"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/vm"
+	"github.com/DEL-ORG/del/crypto"
+)
+func touchSystemAccount(db vm.StateDB, account common.Address) {
+	if db.GetNonce(account) == 0 {
+		db.SetNonce(account, 1)
+	}
+}
+func stateSlot(key common.Hash, offset uint64) common.Hash {
+	slot := new(big.Int).SetBytes(crypto.Keccak256(key[:]))
+	return common.BigToHash(slot.Add(slot, new(big.Int).SetUint64(offset)))
+}
+func getStateBig(db vm.StateDB, account common.Address, key common.Hash) *big.Int {
+	return db.GetState(account, key).Big()
+}
+func setStateBig(db vm.StateDB, account common.Address, key common.Hash, value *big.Int) {
+	db.SetState(account, key, common.BigToHash(value))
+}
+func getStateUint(db vm.StateDB, account common.Address, key common.Hash) uint64 {
+	return getStateBig(db, account, key).Uint64()
+}
+func setStateUint(db vm.StateDB, account common.Address, key common.Hash, value uint64) {
+	setStateBig(db, account, key, new(big.Int).SetUint64(value))
+}
+func getStateBytes(db vm.StateDB, account common.Address, key common.Hash) []byte {
+	size := getStateUint(db, account, key)
+	data := make([]byte, 0, size+common.HashLength)
+	for offset := uint64(0); uint64(len(data)) < size; offset++ {
+		chunk := db.GetState(account, stateSlot(key, offset))
+		data = append(data, chunk[:]...)
+	}
+	return data[:size]
+}
+func setStateBytes(db vm.StateDB, account common.Address, key common.Hash, data []byte) {
+	size := uint64(len(data))
+	if prev := getStateUint(db, account, key); prev > size {
+		size = prev
+	}
+	setStateUint(db, account, key, uint64(len(data)))
+	for offset := uint64(0); offset*common.HashLength < size; offset++ {
+		var chunk common.Hash
+		if start := offset * common.HashLength; start < uint64(len(data)) {
+			copy(chunk[:], data[start:])
+		}
+		db.SetState(account, stateSlot(key, offset), chunk)
+	}
+}
+func stateSetIndexKey(key common.Hash, member common.Address) common.Hash {
+	return crypto.Keccak256Hash(key[:], member[:])
+}
+func getStateSet(db vm.StateDB, account common.Address, key common.Hash) []common.Address {
+	size := getStateUint(db, account, key)
+	members := make([]common.Address, 0, size)
+	for offset := uint64(0); offset < size; offset++ {
+		members = append(members, common.BytesToAddress(db.GetState(account, stateSlot(key, offset)).Bytes()))
+	}
+	return members
+}
+func addStateSet(db vm.StateDB, account common.Address, key common.Hash, member common.Address) {
+	indexKey := stateSetIndexKey(key, member)
+	if getStateUint(db, account, indexKey) != 0 {
+		return
+	}
+	size := getStateUint(db, account, key)
+	db.SetState(account, stateSlot(key, size), member.Hash())
+	setStateUint(db, account, indexKey, size+1)
+	setStateUint(db, account, key, size+1)
+}
+func removeStateSet(db vm.StateDB, account common.Address, key common.Hash, member common.Address) {
+	indexKey := stateSetIndexKey(key, member)
+	index := getStateUint(db, account, indexKey)
+	if index == 0 {
+		return
+	}
+	last := getStateUint(db, account, key) - 1
+	if index-1 != last {
+		moved := db.GetState(account, stateSlot(key, last))
+		db.SetState(account, stateSlot(key, index-1), moved)
+		setStateUint(db, account, stateSetIndexKey(key, common.BytesToAddress(moved.Bytes())), index)
+	}
+	db.SetState(account, stateSlot(key, last), common.Hash{})
+	db.SetState(account, indexKey, common.Hash{})
+	setStateUint(db, account, key, last)
+}
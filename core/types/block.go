@@ -45,6 +45,7 @@ type OutputBlockReward struct {
 	CoinbaseReward *big.Int 	`json:"coinbase_reward" 		gencodec:"required"`
 	SuperCoinbaseReward *big.Int 	`json:"super_coinbase_reward" 		gencodec:"required"`
 	VoteReward *big.Int 	`json:"vote_reward" 		gencodec:"required"`
+	FeeReward *big.Int 	`json:"fee_reward" gencodec:"required"`
 }
 type OutputReward struct {
 	Time time.Time		`json:"time"		gencodec:"required"`
@@ -68,6 +69,13 @@ type OutputTx struct {
 	Price *big.Int		`json:"price"		gencodec:"required"`
 	Value *big.Int		`json:"value"		gencodec:"required"`
 }
+type OutputVote struct {
+	Hash   common.Hash    `json:"hash" gencodec:"required"`
+	From   common.Address `json:"from" gencodec:"required"`
+	To     common.Address `json:"to" gencodec:"required"`
+	Time   time.Time      `json:"time" gencodec:"required"`
+	Amount *big.Int       `json:"amount" gencodec:"required"`
+}
 type Producer struct {
 	Addr common.Address  	`json:"addr" 		gencodec:"required"`
 	Vote	*big.Int		`json:"vote"	gencodec:"vote"`
@@ -130,6 +138,69 @@ func (self Producers) GetWithOutEmpty() (ret Producers) {
 	}
 	return ret
 }
+func (self Producers) Distinct() (ret Producers) {
+	seen := make(map[common.Address]struct{})
+	for _, producer := range self {
+		if _, ok := seen[producer.Addr]; ok {
+			continue
+		}
+		seen[producer.Addr] = struct{}{}
+		ret = append(ret, producer)
+	}
+	return ret
+}
+func (self Producers) Supers() []common.Address {
+	ranked := self.GetWithOutEmpty().Distinct()
+	sort.Stable(ranked)
+	supers := make([]common.Address, 0, common.SUPER_COINBASE_RANK)
+	for i := 0; i < len(ranked) && i < common.SUPER_COINBASE_RANK; i++ {
+		supers = append(supers, ranked[i].Addr)
+	}
+	return supers
+}
+func (self Producers) NextSlot(addr common.Address, from int64) (int64, bool) {
+	n := int64(len(self))
+	for slot := from; slot < from+n; slot++ {
+		if producer := self[slot%n]; !producer.Empty() && producer.Addr == addr {
+			return slot, true
+		}
+	}
+	return 0, false
+}
+func (self Producers) NextProducer(from int64) (int64, common.Address, bool) {
+	n := int64(len(self))
+	for slot := from; slot < from+n; slot++ {
+		if producer := self[slot%n]; !producer.Empty() {
+			return slot, producer.Addr, true
+		}
+	}
+	return 0, common.Address{}, false
+}
+func RandaoSeed(mix common.Hash, round uint64) common.Hash {
+	var enc [8]byte
+	binary.BigEndian.PutUint64(enc[:], round)
+	hw := sha3.NewKeccak256()
+	hw.Write(mix[:])
+	hw.Write(enc[:])
+	var seed common.Hash
+	hw.Sum(seed[:0])
+	return seed
+}
+func (self Producers) Shuffle(seed common.Hash) Producers {
+	shuffled := make(Producers, len(self))
+	copy(shuffled, self)
+	var index [8]byte
+	for i := len(shuffled) - 1; i > 0; i-- {
+		binary.BigEndian.PutUint64(index[:], uint64(i))
+		hw := sha3.NewKeccak256()
+		hw.Write(seed[:])
+		hw.Write(index[:])
+		j := new(big.Int).SetBytes(hw.Sum(nil))
+		j.Mod(j, big.NewInt(int64(i+1)))
+		shuffled.Swap(i, int(j.Int64()))
+	}
+	return shuffled
+}
 type VotersMap map[common.Address]*big.Int
 
 func (self VotersMap)GetProducers() Producers {
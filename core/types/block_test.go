@@ -44,3 +44,106 @@ func TestBlockEncoding(t *testing.T) {
 		t.Errorf("encoded block mismatch:\ngot:  %x\nwant: %x", ourBlockEnc, blockEnc)
 	}
 }
+func TestProducersNextSlot(t *testing.T) {
+	var (
+		one       = common.Address{0x01}
+		two       = common.Address{0x02}
+		producers = Producers{{Addr: one, Vote: new(big.Int)}, EmptyProducer, {Addr: two, Vote: new(big.Int)}, EmptyProducer}
+	)
+	tests := []struct {
+		addr common.Address
+		from int64
+		slot int64
+		ok   bool
+	}{
+		{one, 0, 0, true},
+		{one, 1, 4, true},
+		{two, 1, 2, true},
+		{two, 3, 6, true},
+		{common.Address{0x03}, 0, 0, false},
+		{common.Address{}, 1, 0, false},
+	}
+	for i, tt := range tests {
+		slot, ok := producers.NextSlot(tt.addr, tt.from)
+		if ok != tt.ok || slot != tt.slot {
+			t.Errorf("test %d: have (%d, %v), want (%d, %v)", i, slot, ok, tt.slot, tt.ok)
+		}
+	}
+}
+func TestProducersNextProducer(t *testing.T) {
+	var (
+		one       = common.Address{0x01}
+		two       = common.Address{0x02}
+		producers = Producers{{Addr: one, Vote: new(big.Int)}, EmptyProducer, {Addr: two, Vote: new(big.Int)}, EmptyProducer}
+	)
+	tests := []struct {
+		from int64
+		slot int64
+		addr common.Address
+	}{
+		{0, 0, one},
+		{1, 2, two},
+		{2, 2, two},
+		{3, 4, one},
+		{5, 6, two},
+	}
+	for i, tt := range tests {
+		slot, addr, ok := producers.NextProducer(tt.from)
+		if !ok || slot != tt.slot || addr != tt.addr {
+			t.Errorf("test %d: have (%d, %x, %v), want (%d, %x, true)", i, slot, addr, ok, tt.slot, tt.addr)
+		}
+	}
+	if _, _, ok := (Producers{EmptyProducer, EmptyProducer}).NextProducer(0); ok {
+		t.Errorf("producer found in an empty schedule")
+	}
+}
+func TestProducersShuffle(t *testing.T) {
+	producers := Producers{}
+	for i := 0; i < 32; i++ {
+		producers = append(producers, Producer{Addr: common.Address{byte(i + 1)}, Vote: big.NewInt(int64(i))})
+	}
+	producers = append(producers, EmptyProducer, EmptyProducer)
+	var (
+		seed     = RandaoSeed(common.Hash{0x01}, 2)
+		shuffled = producers.Shuffle(seed)
+	)
+	if CalcProducerHash(shuffled) != CalcProducerHash(producers.Shuffle(seed)) {
+		t.Errorf("shuffle is not deterministic")
+	}
+	if CalcProducerHash(shuffled) == CalcProducerHash(producers) {
+		t.Errorf("shuffle kept the original order")
+	}
+	if CalcProducerHash(shuffled) == CalcProducerHash(producers.Shuffle(RandaoSeed(common.Hash{0x01}, 3))) {
+		t.Errorf("shuffle ignores the round")
+	}
+	if producers[0].Addr != (common.Address{1}) || producers[31].Addr != (common.Address{32}) {
+		t.Errorf("shuffle modified the input schedule")
+	}
+	seen, empty := make(map[common.Address]bool), 0
+	for _, producer := range shuffled {
+		if producer.Empty() {
+			empty++
+			continue
+		}
+		seen[producer.Addr] = true
+	}
+	if len(shuffled) != len(producers) || len(seen) != 32 || empty != 2 {
+		t.Errorf("shuffle is not a permutation: %d entries, %d producers, %d empty", len(shuffled), len(seen), empty)
+	}
+}
+func TestProducersSupers(t *testing.T) {
+	producers := Producers{EmptyProducer}
+	for i := 0; i < 30; i++ {
+		producers = append(producers, Producer{Addr: common.Address{byte(i + 1)}, Vote: big.NewInt(int64(i))})
+	}
+	producers = append(producers, producers[1:]...)
+	supers := producers.Supers()
+	if len(supers) != common.SUPER_COINBASE_RANK {
+		t.Fatalf("supers count mismatch: have %d, want %d", len(supers), common.SUPER_COINBASE_RANK)
+	}
+	for i, addr := range supers {
+		if want := (common.Address{byte(30 - i)}); addr != want {
+			t.Errorf("super %d mismatch: have %x, want %x", i, addr, want)
+		}
+	}
+}
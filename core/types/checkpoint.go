@@ -0,0 +1,146 @@
+package types
+import (
+	"errors"
+	"math/big"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/crypto"
+)
+var (
+	ErrCheckpointSigner = errors.New("checkpoint signed by a non-super producer")
+	ErrCheckpointQuorum = errors.New("checkpoint lacks a super producer quorum")
+	ErrCheckpointSignatures = errors.New("checkpoint has more signatures than super producers")
+)
+type Checkpoint struct {
+	Genesis      common.Hash `json:"genesisHash"`
+	ChainId      *big.Int    `json:"chainId"`
+	Number       uint64      `json:"number"`
+	BlockHash    common.Hash `json:"blockHash"`
+	Root         common.Hash `json:"stateRoot"`
+	ProducerHash common.Hash `json:"producersRoot"`
+	Td           *big.Int    `json:"totalDifficulty"`
+	Signatures   [][]byte    `json:"signatures"`
+}
+func NewCheckpoint(genesis common.Hash, chainId *big.Int, header *Header, td *big.Int) *Checkpoint {
+	return &Checkpoint{
+		Genesis:      genesis,
+		ChainId:      checkpointChainId(chainId),
+		Number:       header.Number.Uint64(),
+		BlockHash:    header.Hash(),
+		Root:         header.Root,
+		ProducerHash: header.ProducerHash,
+		Td:           new(big.Int).Set(td),
+	}
+}
+func checkpointChainId(chainId *big.Int) *big.Int {
+	if chainId == nil {
+		return new(big.Int)
+	}
+	return new(big.Int).Set(chainId)
+}
+func IsCheckpointNumber(number uint64, length uint64) bool {
+	round := common.GetRoundNumberByBlockNumber(number, length)
+	return number > 0 && number == common.GetEndBlockNumberByRoundNumber(round, length) && round%common.CHECKPOINT_ROUNDS == 0
+}
+func CheckpointQuorum(supers int) int {
+	return supers*2/3 + 1
+}
+func (self *Checkpoint) SigHash() common.Hash {
+	return rlpHash([]interface{}{self.Genesis, self.ChainId, self.Number, self.BlockHash, self.Root, self.ProducerHash, self.Td})
+}
+func (self *Checkpoint) Hash() common.Hash {
+	return rlpHash(self)
+}
+func (self *Checkpoint) Matches(genesis common.Hash, chainId *big.Int, header *Header, td *big.Int) bool {
+	if self.Genesis != genesis || self.ChainId == nil || self.ChainId.Cmp(checkpointChainId(chainId)) != 0 {
+		return false
+	}
+	return header.Number.Uint64() == self.Number && header.Hash() == self.BlockHash && header.Root == self.Root && header.ProducerHash == self.ProducerHash && self.Td != nil && td != nil && self.Td.Cmp(td) == 0
+}
+func (self *Checkpoint) Signers() ([]common.Address, error) {
+	hash := self.SigHash()
+	signers := make([]common.Address, 0, len(self.Signatures))
+	seen := make(map[string]bool)
+	for _, sig := range self.Signatures {
+		if seen[string(sig)] {
+			continue
+		}
+		seen[string(sig)] = true
+		pub, err := crypto.SigToPub(hash[:], sig)
+		if err != nil {
+			return nil, err
+		}
+		signers = append(signers, crypto.PubkeyToAddress(*pub))
+	}
+	return signers, nil
+}
+func (self *Checkpoint) Merge(other *Checkpoint, supers []common.Address) (int, error) {
+	if other.SigHash() != self.SigHash() {
+		return 0, errors.New("checkpoint mismatch")
+	}
+	if len(self.Signatures) > len(supers) || len(other.Signatures) > len(supers) {
+		return 0, ErrCheckpointSignatures
+	}
+	signers, err := self.Signers()
+	if err != nil {
+		return 0, err
+	}
+	known := make(map[common.Address]bool)
+	for _, signer := range signers {
+		known[signer] = true
+	}
+	allowed := make(map[common.Address]bool)
+	for _, super := range supers {
+		allowed[super] = true
+	}
+	seen := make(map[string]bool)
+	for _, sig := range self.Signatures {
+		seen[string(sig)] = true
+	}
+	hash := other.SigHash()
+	added := 0
+	for _, sig := range other.Signatures {
+		if seen[string(sig)] {
+			continue
+		}
+		seen[string(sig)] = true
+		pub, err := crypto.SigToPub(hash[:], sig)
+		if err != nil {
+			return added, err
+		}
+		signer := crypto.PubkeyToAddress(*pub)
+		if !allowed[signer] {
+			return added, ErrCheckpointSigner
+		}
+		if known[signer] {
+			continue
+		}
+		known[signer] = true
+		self.Signatures = append(self.Signatures, common.CopyBytes(sig))
+		added++
+	}
+	return added, nil
+}
+func (self *Checkpoint) Verify(supers []common.Address) error {
+	if len(self.Signatures) > len(supers) {
+		return ErrCheckpointSignatures
+	}
+	signers, err := self.Signers()
+	if err != nil {
+		return err
+	}
+	allowed := make(map[common.Address]bool)
+	for _, super := range supers {
+		allowed[super] = true
+	}
+	seen := make(map[common.Address]bool)
+	for _, signer := range signers {
+		if !allowed[signer] {
+			return ErrCheckpointSigner
+		}
+		seen[signer] = true
+	}
+	if len(seen) < CheckpointQuorum(len(supers)) {
+		return ErrCheckpointQuorum
+	}
+	return nil
+}
@@ -0,0 +1,154 @@
+package types
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/crypto"
+)
+func signCheckpoint(t *testing.T, checkpoint *Checkpoint, key *ecdsa.PrivateKey) *Checkpoint {
+	sig, err := crypto.Sign(checkpoint.SigHash().Bytes(), key)
+	if err != nil {
+		t.Fatalf("failed to sign checkpoint: %v", err)
+	}
+	signed := *checkpoint
+	signed.Signatures = [][]byte{sig}
+	return &signed
+}
+func TestIsCheckpointNumber(t *testing.T) {
+	period := uint64(common.CHECKPOINT_ROUNDS * common.LEADER_NUMBER)
+	tests := []struct {
+		number uint64
+		want   bool
+	}{
+		{0, false},
+		{common.LEADER_NUMBER, false},
+		{period - 1, false},
+		{period, true},
+		{period + 1, false},
+		{period + common.LEADER_NUMBER, false},
+		{2 * period, true},
+	}
+	for i, tt := range tests {
+		if have := IsCheckpointNumber(tt.number, common.LEADER_NUMBER); have != tt.want {
+			t.Errorf("test %d: checkpoint number %d: have %v, want %v", i, tt.number, have, tt.want)
+		}
+	}
+}
+func TestCheckpointMergeVerify(t *testing.T) {
+	var (
+		keys   = make([]*ecdsa.PrivateKey, 4)
+		supers = make([]common.Address, 3)
+	)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		if i < len(supers) {
+			supers[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+		}
+	}
+	header := &Header{Number: big.NewInt(int64(common.CHECKPOINT_ROUNDS * common.LEADER_NUMBER)), Root: common.Hash{0x01}, ProducerHash: common.Hash{0x02}}
+	genesis, chainId := common.Hash{0x0a}, big.NewInt(7)
+	checkpoint := NewCheckpoint(genesis, chainId, header, big.NewInt(100))
+	if !checkpoint.Matches(genesis, chainId, header, big.NewInt(100)) {
+		t.Fatalf("checkpoint does not match its header")
+	}
+	if checkpoint.Matches(genesis, chainId, header, big.NewInt(101)) {
+		t.Errorf("checkpoint matches a different total difficulty")
+	}
+	if checkpoint.Matches(common.Hash{0x0b}, chainId, header, big.NewInt(100)) {
+		t.Errorf("checkpoint matches a different genesis")
+	}
+	if checkpoint.Matches(genesis, big.NewInt(8), header, big.NewInt(100)) {
+		t.Errorf("checkpoint matches a different chain id")
+	}
+	if err := checkpoint.Verify(supers); err != ErrCheckpointQuorum {
+		t.Errorf("unsigned checkpoint: have %v, want %v", err, ErrCheckpointQuorum)
+	}
+	tests := []struct {
+		key   int
+		added int
+		err   error
+		valid error
+	}{
+		{0, 1, nil, ErrCheckpointQuorum},
+		{0, 0, nil, ErrCheckpointQuorum},
+		{3, 0, ErrCheckpointSigner, ErrCheckpointQuorum},
+		{1, 1, nil, ErrCheckpointQuorum},
+		{2, 1, nil, nil},
+	}
+	for i, tt := range tests {
+		added, err := checkpoint.Merge(signCheckpoint(t, checkpoint, keys[tt.key]), supers)
+		if added != tt.added || err != tt.err {
+			t.Errorf("test %d: merge mismatch: have %d/%v, want %d/%v", i, added, err, tt.added, tt.err)
+		}
+		if err := checkpoint.Verify(supers); err != tt.valid {
+			t.Errorf("test %d: verify mismatch: have %v, want %v", i, err, tt.valid)
+		}
+	}
+	other := NewCheckpoint(genesis, chainId, header, big.NewInt(100))
+	other.Root = common.Hash{0x03}
+	if _, err := checkpoint.Merge(signCheckpoint(t, other, keys[0]), supers); err == nil {
+		t.Errorf("merged a checkpoint for different content")
+	}
+	if err := checkpoint.Verify(append([]common.Address{{0xff}}, supers[1:]...)); err != ErrCheckpointSigner {
+		t.Errorf("verify against replaced supers: have %v, want %v", err, ErrCheckpointSigner)
+	}
+}
+func TestCheckpointSignatureLimit(t *testing.T) {
+	var (
+		keys   = make([]*ecdsa.PrivateKey, 3)
+		supers = make([]common.Address, 3)
+	)
+	for i := range keys {
+		keys[i], _ = crypto.GenerateKey()
+		supers[i] = crypto.PubkeyToAddress(keys[i].PublicKey)
+	}
+	header := &Header{Number: big.NewInt(int64(common.CHECKPOINT_ROUNDS * common.LEADER_NUMBER))}
+	genesis, chainId := common.Hash{0x0a}, big.NewInt(7)
+	checkpoint := NewCheckpoint(genesis, chainId, header, big.NewInt(100))
+	sig := signCheckpoint(t, checkpoint, keys[0]).Signatures[0]
+	duplicated := NewCheckpoint(genesis, chainId, header, big.NewInt(100))
+	duplicated.Signatures = [][]byte{sig, sig, sig}
+	if added, err := checkpoint.Merge(duplicated, supers); added != 1 || err != nil {
+		t.Errorf("duplicated signatures: have %d/%v, want 1/nil", added, err)
+	}
+	if signers, err := duplicated.Signers(); len(signers) != 1 || err != nil {
+		t.Errorf("duplicated signers: have %d/%v, want 1/nil", len(signers), err)
+	}
+	flooded := NewCheckpoint(genesis, chainId, header, big.NewInt(100))
+	flooded.Signatures = [][]byte{sig, sig, sig, sig}
+	if _, err := checkpoint.Merge(flooded, supers); err != ErrCheckpointSignatures {
+		t.Errorf("flooded merge: have %v, want %v", err, ErrCheckpointSignatures)
+	}
+	if err := flooded.Verify(supers); err != ErrCheckpointSignatures {
+		t.Errorf("flooded verify: have %v, want %v", err, ErrCheckpointSignatures)
+	}
+}
+func TestCheckpointChainBinding(t *testing.T) {
+	key, _ := crypto.GenerateKey()
+	supers := []common.Address{crypto.PubkeyToAddress(key.PublicKey)}
+	header := &Header{Number: big.NewInt(int64(common.CHECKPOINT_ROUNDS * common.LEADER_NUMBER)), Root: common.Hash{0x01}}
+	genesis, chainId := common.Hash{0x0a}, big.NewInt(7)
+	signed := signCheckpoint(t, NewCheckpoint(genesis, chainId, header, big.NewInt(100)), key)
+	tests := []struct {
+		genesis common.Hash
+		chainId *big.Int
+	}{
+		{common.Hash{0x0b}, chainId},
+		{genesis, big.NewInt(8)},
+		{genesis, nil},
+	}
+	for i, tt := range tests {
+		replayed := NewCheckpoint(tt.genesis, tt.chainId, header, big.NewInt(100))
+		if replayed.SigHash() == signed.SigHash() {
+			t.Errorf("test %d: signature hash not bound to genesis %x and chain id %v", i, tt.genesis, tt.chainId)
+		}
+		if _, err := replayed.Merge(signed, supers); err == nil {
+			t.Errorf("test %d: merged a signature from another chain", i)
+		}
+		replayed.Signatures = signed.Signatures
+		if signers, err := replayed.Signers(); err == nil && len(signers) == 1 && signers[0] == supers[0] {
+			t.Errorf("test %d: replayed signature recovered the original signer", i)
+		}
+	}
+}
@@ -87,6 +87,54 @@ func NewVoteCreation(producer *common.Address, nonce uint64, gasPrice *big.Int,
 	gas, _ := params.IntrinsicGas(json_str)
 	return newTransaction(nonce, producer, big.NewInt(0), gas, gasPrice, json_str)
 }
+func NewLockCreation(to *common.Address, nonce uint64, gasPrice *big.Int, schedules []common.VestingSchedule) *Transaction {
+	d := common.DataProtocol{MessageID:common.DataProtocolMessageID_LOCK, Vesting:schedules}
+	json_str, err := d.Encode()
+	if err != nil {
+		return nil
+	}
+	gas, _ := params.IntrinsicGas(json_str)
+	return newTransaction(nonce, to, big.NewInt(0), gas+params.VestingGas(schedules), gasPrice, json_str)
+}
+func NewDelegateCreation(voter *common.Address, nonce uint64, gasPrice *big.Int, tickets common.DataProtocolTickets) *Transaction {
+	d := common.DataProtocol{MessageID:common.DataProtocolMessageID_DELEGATE, Tickets:tickets}
+	json_str, err := d.Encode()
+	if err != nil {
+		return nil
+	}
+	gas, _ := params.IntrinsicGas(json_str)
+	return newTransaction(nonce, voter, big.NewInt(0), gas+params.DelegationGas(tickets), gasPrice, json_str)
+}
+func NewProposeCreation(proposer *common.Address, nonce uint64, gasPrice *big.Int, changes common.DataProtocolTickets) *Transaction {
+	d := common.DataProtocol{MessageID:common.DataProtocolMessageID_PROPOSE, Tickets:changes}
+	json_str, err := d.Encode()
+	if err != nil {
+		return nil
+	}
+	gas, _ := params.IntrinsicGas(json_str)
+	return newTransaction(nonce, proposer, big.NewInt(0), gas+params.GovernanceGas(changes), gasPrice, json_str)
+}
+func NewApproveCreation(producer *common.Address, nonce uint64, gasPrice *big.Int, proposals []uint64) *Transaction {
+	d := common.DataProtocol{MessageID:common.DataProtocolMessageID_APPROVE}
+	for _, id := range proposals {
+		d.Tickets = append(d.Tickets, common.DataProtocolVote{Amount: new(big.Int).SetUint64(id)})
+	}
+	json_str, err := d.Encode()
+	if err != nil {
+		return nil
+	}
+	gas, _ := params.IntrinsicGas(json_str)
+	return newTransaction(nonce, producer, big.NewInt(0), gas+params.GovernanceGas(d.Tickets), gasPrice, json_str)
+}
+func NewAliasCreation(owner *common.Address, nonce uint64, gasPrice *big.Int, name string) *Transaction {
+	d := common.DataProtocol{MessageID:common.DataProtocolMessageID_ALIAS, Text:&name}
+	json_str, err := d.Encode()
+	if err != nil {
+		return nil
+	}
+	gas, _ := params.IntrinsicGas(json_str)
+	return newTransaction(nonce, owner, big.NewInt(0), gas+params.AliasGas, gasPrice, json_str)
+}
 func NewSetParentCreation(to *common.Address, nonce uint64, gasPrice *big.Int, data []byte) *Transaction {
 	d := common.DataProtocol{MessageID:common.DataProtocolMessageID_PARENT}
 	json_str, _ := d.Encode()
@@ -242,6 +290,9 @@ func (tx *Transaction) Cost() *big.Int {
 		if message.MessageID == common.DataProtocolMessageID_VOTE {
 			total.Add(total, message.Tickets.TotalAmount())
 		}
+		if message.MessageID == common.DataProtocolMessageID_LOCK {
+			total.Add(total, common.VestingTotal(message.Vesting))
+		}
 	}
 	return total
 }
@@ -0,0 +1,113 @@
+package core
+import (
+	"encoding/binary"
+	"math/big"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/vm"
+	"github.com/DEL-ORG/del/crypto"
+	"github.com/DEL-ORG/del/log"
+	"github.com/DEL-ORG/del/rlp"
+)
+var VestingAddress = common.BytesToAddress([]byte("vesting"))
+type Vesting struct {
+	Schedule common.VestingSchedule
+	Released *big.Int
+}
+func (self *Vesting) Locked() *big.Int {
+	return new(big.Int).Sub(self.Schedule.Total(), self.Released)
+}
+func vestingCountKey(beneficiary common.Address) common.Hash {
+	return crypto.Keccak256Hash([]byte("count"), beneficiary[:])
+}
+func vestingEntryKey(beneficiary common.Address, index uint64) common.Hash {
+	var enc [8]byte
+	binary.BigEndian.PutUint64(enc[:], index)
+	return crypto.Keccak256Hash([]byte("entry"), beneficiary[:], enc[:])
+}
+func vestingQueueKey(number uint64) common.Hash {
+	var enc [8]byte
+	binary.BigEndian.PutUint64(enc[:], number)
+	return crypto.Keccak256Hash([]byte("queue"), enc[:])
+}
+func GetVestings(db vm.StateDB, beneficiary common.Address) []*Vesting {
+	count := getStateUint(db, VestingAddress, vestingCountKey(beneficiary))
+	vestings := make([]*Vesting, 0, count)
+	for index := uint64(0); index < count; index++ {
+		if vesting := readVesting(db, beneficiary, index); vesting != nil {
+			vestings = append(vestings, vesting)
+		}
+	}
+	return vestings
+}
+func readVesting(db vm.StateDB, beneficiary common.Address, index uint64) *Vesting {
+	vesting := new(Vesting)
+	if err := rlp.DecodeBytes(getStateBytes(db, VestingAddress, vestingEntryKey(beneficiary, index)), vesting); err != nil {
+		log.Error("Invalid vesting entry", "beneficiary", beneficiary, "index", index, "err", err)
+		return nil
+	}
+	return vesting
+}
+func writeVesting(db vm.StateDB, beneficiary common.Address, index uint64, vesting *Vesting) {
+	data, err := rlp.EncodeToBytes(vesting)
+	if err != nil {
+		log.Error("Failed to encode vesting entry", "beneficiary", beneficiary, "index", index, "err", err)
+		return
+	}
+	setStateBytes(db, VestingAddress, vestingEntryKey(beneficiary, index), data)
+}
+func enqueueVesting(db vm.StateDB, number uint64, beneficiary common.Address, index uint64) {
+	key := vestingQueueKey(number)
+	size := getStateUint(db, VestingAddress, key)
+	var ref common.Hash
+	copy(ref[:common.AddressLength], beneficiary[:])
+	binary.BigEndian.PutUint64(ref[common.HashLength-8:], index)
+	db.SetState(VestingAddress, stateSlot(key, size), ref)
+	setStateUint(db, VestingAddress, key, size+1)
+}
+func AddVesting(db vm.StateDB, beneficiary common.Address, schedule common.VestingSchedule, number uint64) {
+	touchSystemAccount(db, VestingAddress)
+	countKey := vestingCountKey(beneficiary)
+	index := getStateUint(db, VestingAddress, countKey)
+	setStateUint(db, VestingAddress, countKey, index+1)
+	db.AddFreeze(beneficiary, schedule.Total())
+	releaseVesting(db, beneficiary, index, &Vesting{Schedule: schedule, Released: new(big.Int)}, number)
+}
+func releaseVesting(db vm.StateDB, beneficiary common.Address, index uint64, vesting *Vesting, number uint64) {
+	vested := vesting.Schedule.Vested(number)
+	if amount := new(big.Int).Sub(vested, vesting.Released); amount.Sign() > 0 {
+		db.SubFreeze(beneficiary, amount)
+		db.AddBalance(beneficiary, amount)
+		vesting.Released = vested
+	}
+	writeVesting(db, beneficiary, index, vesting)
+	if next, ok := vesting.Schedule.NextRelease(number); ok {
+		enqueueVesting(db, next, beneficiary, index)
+	}
+}
+func ApplyVestingReleases(db vm.StateDB, number uint64) {
+	key := vestingQueueKey(number)
+	size := getStateUint(db, VestingAddress, key)
+	if size == 0 {
+		return
+	}
+	setStateUint(db, VestingAddress, key, 0)
+	for offset := uint64(0); offset < size; offset++ {
+		slot := stateSlot(key, offset)
+		ref := db.GetState(VestingAddress, slot)
+		db.SetState(VestingAddress, slot, common.Hash{})
+		beneficiary := common.BytesToAddress(ref[:common.AddressLength])
+		index := binary.BigEndian.Uint64(ref[common.HashLength-8:])
+		if vesting := readVesting(db, beneficiary, index); vesting != nil {
+			releaseVesting(db, beneficiary, index, vesting, number)
+		}
+	}
+}
+func CanLock(db vm.StateDB, addr common.Address, schedules []common.VestingSchedule) bool {
+	return db.GetBalance(addr).Cmp(common.VestingTotal(schedules)) >= 0
+}
+func Lock(db vm.StateDB, sender, beneficiary common.Address, schedules []common.VestingSchedule, number uint64) {
+	db.SubBalance(sender, common.VestingTotal(schedules))
+	for _, schedule := range schedules {
+		AddVesting(db, beneficiary, schedule, number)
+	}
+}
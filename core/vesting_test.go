@@ -0,0 +1,163 @@
+package core
+import (
+	"math/big"
+	"testing"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/state"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/core/vm"
+	"github.com/DEL-ORG/del/ethdb"
+	"github.com/DEL-ORG/del/params"
+)
+func TestVestingReleases(t *testing.T) {
+	var (
+		statedb = newTestState()
+		one     = common.Address{0x01}
+		two     = common.Address{0x02}
+	)
+	AddVesting(statedb, one, common.VestingSchedule{Start: 0, Cliff: 20, Period: 10, Releases: 4, Amount: big.NewInt(400)}, 0)
+	AddVesting(statedb, one, common.VestingSchedule{Tranches: []common.VestingTranche{{Block: 15, Amount: big.NewInt(7)}, {Block: 30, Amount: big.NewInt(3)}}}, 0)
+	AddVesting(statedb, two, common.VestingSchedule{Start: 0, Period: 10, Releases: 2, Amount: big.NewInt(50)}, 12)
+	if balance := statedb.GetBalance(two); balance.Int64() != 25 {
+		t.Errorf("vested amount not released on creation: have %v, want 25", balance)
+	}
+	tests := []struct {
+		number  uint64
+		balance int64
+		freeze  int64
+	}{
+		{10, 0, 410},
+		{15, 7, 403},
+		{19, 7, 403},
+		{20, 207, 203},
+		{30, 310, 100},
+		{40, 410, 0},
+		{50, 410, 0},
+	}
+	applied := uint64(0)
+	for i, tt := range tests {
+		for ; applied < tt.number; applied++ {
+			ApplyVestingReleases(statedb, applied+1)
+		}
+		if balance := statedb.GetBalance(one); balance.Int64() != tt.balance {
+			t.Errorf("test %d: balance mismatch at %d: have %v, want %d", i, tt.number, balance, tt.balance)
+		}
+		if freeze := statedb.GetFreeze(one); freeze.Int64() != tt.freeze {
+			t.Errorf("test %d: freeze mismatch at %d: have %v, want %d", i, tt.number, freeze, tt.freeze)
+		}
+	}
+	vestings := GetVestings(statedb, one)
+	if len(vestings) != 2 {
+		t.Fatalf("vesting count mismatch: have %d, want 2", len(vestings))
+	}
+	for i, vesting := range vestings {
+		if vesting.Locked().Sign() != 0 {
+			t.Errorf("vesting %d: still locked: %v", i, vesting.Locked())
+		}
+	}
+	if balance := statedb.GetBalance(two); balance.Int64() != 50 {
+		t.Errorf("second beneficiary balance mismatch: have %v, want 50", balance)
+	}
+}
+func TestVestingReleasesRepeated(t *testing.T) {
+	statedb := newTestState()
+	addr := common.Address{0x01}
+	AddVesting(statedb, addr, common.VestingSchedule{Period: 5, Releases: 2, Amount: big.NewInt(10)}, 0)
+	for i := 0; i < 3; i++ {
+		ApplyVestingReleases(statedb, 5)
+	}
+	if balance := statedb.GetBalance(addr); balance.Int64() != 5 {
+		t.Errorf("release applied more than once: have %v, want 5", balance)
+	}
+	ApplyVestingReleases(statedb, 10)
+	if balance := statedb.GetBalance(addr); balance.Int64() != 10 {
+		t.Errorf("final release mismatch: have %v, want 10", balance)
+	}
+}
+func TestVestingSurvivesCommit(t *testing.T) {
+	statedb := newTestState()
+	addr := common.Address{0x01}
+	AddVesting(statedb, addr, common.VestingSchedule{Period: 5, Releases: 1, Amount: big.NewInt(10)}, 0)
+	root, err := statedb.Commit(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statedb, _ = state.New(root, statedb.Database())
+	if vestings := GetVestings(statedb, addr); len(vestings) != 1 {
+		t.Fatalf("vesting lost on commit: have %d entries", len(vestings))
+	}
+	ApplyVestingReleases(statedb, 5)
+	if balance := statedb.GetBalance(addr); balance.Int64() != 10 {
+		t.Errorf("release mismatch after commit: have %v, want 10", balance)
+	}
+}
+func TestGenesisVesting(t *testing.T) {
+	addr := common.Address{0x01}
+	genesis := &Genesis{
+		Config: params.TestChainConfig,
+		Alloc: GenesisAlloc{
+			{Addr: addr, Balance: big.NewInt(1), Freeze: new(big.Int), Vesting: []common.VestingSchedule{{Period: 10, Releases: 2, Amount: big.NewInt(100)}}},
+		},
+	}
+	db, _ := ethdb.NewMemDatabase()
+	block := genesis.MustCommit(db)
+	statedb, _ := state.New(block.Root(), state.NewDatabase(db))
+	if freeze := statedb.GetFreeze(addr); freeze.Int64() != 100 {
+		t.Errorf("genesis freeze mismatch: have %v, want 100", freeze)
+	}
+	ApplyReleaseGenesisBalance(nil, &types.Header{Number: big.NewInt(10)}, statedb)
+	if balance := statedb.GetBalance(addr); balance.Int64() != 51 {
+		t.Errorf("genesis vesting release mismatch: have %v, want 51", balance)
+	}
+}
+func TestGenesisInvalidVesting(t *testing.T) {
+	genesis := &Genesis{
+		Config: params.TestChainConfig,
+		Alloc: GenesisAlloc{
+			{Addr: common.Address{0x01}, Balance: big.NewInt(1), Vesting: []common.VestingSchedule{{Releases: 2, Amount: big.NewInt(100)}}},
+		},
+	}
+	db, _ := ethdb.NewMemDatabase()
+	if _, _, err := SetupGenesisBlock(db, genesis); err == nil {
+		t.Fatalf("genesis with an invalid vesting schedule accepted")
+	}
+	if hash := GetCanonicalHash(db, 0); hash != (common.Hash{}) {
+		t.Errorf("rejected genesis written to the database: %x", hash)
+	}
+	if _, err := genesis.Commit(db); err == nil {
+		t.Errorf("commit accepted an invalid vesting schedule")
+	}
+}
+func TestLockedTransfer(t *testing.T) {
+	var (
+		statedb   = newTestState()
+		sender    = common.Address{0x01}
+		recipient = common.Address{0x02}
+	)
+	statedb.AddBalance(sender, big.NewInt(1000))
+	schedules := []common.VestingSchedule{{Period: 10, Releases: 2, Amount: big.NewInt(100)}}
+	data, _ := (&common.DataProtocol{MessageID: common.DataProtocolMessageID_LOCK, Vesting: schedules}).Encode()
+	evm := newSystemEVM(statedb)
+	gas := params.VestingGas(schedules)
+	checkSystemCall(t, evm, sender, recipient, data, gas)
+	if balance := statedb.GetBalance(sender); balance.Int64() != 900 {
+		t.Errorf("sender balance mismatch: have %v, want 900", balance)
+	}
+	if freeze := statedb.GetFreeze(recipient); freeze.Int64() != 100 {
+		t.Errorf("recipient freeze mismatch: have %v, want 100", freeze)
+	}
+	schedules[0].Amount = big.NewInt(901)
+	data, _ = (&common.DataProtocol{MessageID: common.DataProtocolMessageID_LOCK, Vesting: schedules}).Encode()
+	if _, _, err := evm.Call(vm.AccountRef(sender), recipient, data, gas, new(big.Int)); err != vm.ErrInsufficientBalance {
+		t.Errorf("overdrawn lock: have %v, want %v", err, vm.ErrInsufficientBalance)
+	}
+	schedules[0].Period = 0
+	data, _ = (&common.DataProtocol{MessageID: common.DataProtocolMessageID_LOCK, Vesting: schedules}).Encode()
+	if _, _, err := evm.Call(vm.AccountRef(sender), recipient, data, gas, new(big.Int)); err != common.ErrVestingPeriod {
+		t.Errorf("invalid lock: have %v, want %v", err, common.ErrVestingPeriod)
+	}
+	ApplyVestingReleases(statedb, 10)
+	if balance := statedb.GetBalance(recipient); balance.Int64() != 50 {
+		t.Errorf("recipient release mismatch: have %v, want 50", balance)
+	}
+}
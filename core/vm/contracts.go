@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"github.com/DEL-ORG/del/common"
 	"github.com/DEL-ORG/del/common/math"
+	"github.com/DEL-ORG/del/core/types"
 	"github.com/DEL-ORG/del/crypto"
 	"github.com/DEL-ORG/del/crypto/bn256"
 	"github.com/DEL-ORG/del/params"
@@ -30,6 +31,26 @@ var PrecompiledContractsByzantium = map[common.Address]PrecompiledContract{
 	common.BytesToAddress([]byte{7}): &bn256ScalarMul{},
 	common.BytesToAddress([]byte{8}): &bn256Pairing{},
 }
+type DposPrecompiledContract interface {
+	RequiredGas(input []byte) uint64
+	Run(evm *EVM, contract *Contract, input []byte) ([]byte, error)
+}
+var (
+	DposStateAddress = common.BytesToAddress([]byte{1, 0})
+	DposVoteAddress  = common.BytesToAddress([]byte{1, 1})
+	DposVoteTopic    = crypto.Keccak256Hash([]byte("Vote(address,address,uint256)"))
+)
+var PrecompiledContractsDpos = map[common.Address]DposPrecompiledContract{
+	DposStateAddress: &dposState{},
+	DposVoteAddress:  &dposVote{},
+}
+func RunDposPrecompiledContract(evm *EVM, p DposPrecompiledContract, input []byte, contract *Contract) (ret []byte, err error) {
+	gas := p.RequiredGas(input)
+	if contract.UseGas(gas) {
+		return p.Run(evm, contract, input)
+	}
+	return nil, ErrOutOfGas
+}
 func RunPrecompiledContract(p PrecompiledContract, input []byte, contract *Contract) (ret []byte, err error) {
 	gas := p.RequiredGas(input)
 	if contract.UseGas(gas) {
@@ -262,3 +283,114 @@ func (c *bn256Pairing) Run(input []byte) ([]byte, error) {
 	}
 	return false32Byte, nil
 }
+const (
+	DposStateProducers uint64 = 1
+	DposStateVotes     uint64 = 2
+	DposStateFreeze    uint64 = 3
+	DposStateRound     uint64 = 4
+)
+var errDposStateMethod = errors.New("unknown dpos state method")
+type dposState struct{}
+func (c *dposState) RequiredGas(input []byte) uint64 {
+	switch new(big.Int).SetBytes(getData(input, 0, 32)).Uint64() {
+	case DposStateProducers:
+		return params.DposStateBaseGas + uint64(common.LEADER_LIMIT)*params.DposStatePerProducerGas
+	}
+	return params.DposStateBaseGas
+}
+func (c *dposState) Run(evm *EVM, contract *Contract, input []byte) ([]byte, error) {
+	method := new(big.Int).SetBytes(getData(input, 0, 32))
+	if !method.IsUint64() {
+		return nil, errDposStateMethod
+	}
+	addr := common.BytesToAddress(getData(input, 32, 32))
+	switch method.Uint64() {
+	case DposStateProducers:
+		var producers []common.Address
+		if evm.GetProducers != nil {
+			for _, producer := range evm.GetProducers().GetWithOutEmpty() {
+				producers = append(producers, producer.Addr)
+			}
+		}
+		ret := make([]byte, 0, 64+32*len(producers))
+		ret = append(ret, common.LeftPadBytes(big32.Bytes(), 32)...)
+		ret = append(ret, common.LeftPadBytes(big.NewInt(int64(len(producers))).Bytes(), 32)...)
+		for _, producer := range producers {
+			ret = append(ret, common.LeftPadBytes(producer.Bytes(), 32)...)
+		}
+		return ret, nil
+	case DposStateVotes:
+		if !contract.UseGas(dposStateScannedBlocks(evm.BlockNumber.Uint64(), evm.ChainConfig().GetRoundLength()) * params.DposStateVotesPerBlockGas) {
+			return nil, ErrOutOfGas
+		}
+		votes := common.Big0
+		if evm.GetCandidateVote != nil {
+			votes = evm.GetCandidateVote(addr)
+		}
+		return math.PaddedBigBytes(votes, 32), nil
+	case DposStateFreeze:
+		return math.PaddedBigBytes(evm.StateDB.GetFreeze(addr), 32), nil
+	case DposStateRound:
+		round := common.GetRoundNumberByBlockNumber(evm.BlockNumber.Uint64(), evm.ChainConfig().GetRoundLength())
+		return math.PaddedBigBytes(new(big.Int).SetUint64(round), 32), nil
+	}
+	return nil, errDposStateMethod
+}
+func dposStateScannedBlocks(number uint64, length uint64) uint64 {
+	if number <= 1 {
+		return 0
+	}
+	parent := number - 1
+	return parent - common.GetBeginBlockNumberByRoundNumber(common.GetRoundNumberByBlockNumber(parent, length), length) + 1
+}
+var (
+	errDposVoteInput    = errors.New("invalid dpos vote input")
+	errDposVoteValue    = errors.New("dpos vote does not accept value")
+	errDposVoteDelegate = errors.New("dpos vote does not accept delegated calls")
+	errDposVoteAmount   = errors.New("dpos vote amount must be positive")
+	errDposVoteProducer = errors.New("dpos vote target is not a registered producer")
+)
+type dposVote struct{}
+func (c *dposVote) RequiredGas(input []byte) uint64 {
+	return params.DposVoteBaseGas + uint64(len(input)/64)*params.DposVotePerTicketGas
+}
+func (c *dposVote) Run(evm *EVM, contract *Contract, input []byte) ([]byte, error) {
+	if evm.interpreter.readOnly {
+		return nil, errWriteProtection
+	}
+	if contract.DelegateCall || contract.Address() != DposVoteAddress {
+		return nil, errDposVoteDelegate
+	}
+	if len(input) == 0 || len(input)%64 != 0 {
+		return nil, errDposVoteInput
+	}
+	if value := contract.Value(); value != nil && value.Sign() > 0 {
+		return nil, errDposVoteValue
+	}
+	voter := contract.Caller()
+	total := new(big.Int)
+	for i := 0; i < len(input); i += 64 {
+		amount := new(big.Int).SetBytes(input[i+32 : i+64])
+		if amount.Sign() == 0 || amount.BitLen() > 255 {
+			return nil, errDposVoteAmount
+		}
+		if !evm.isProducer(common.BytesToAddress(input[i : i+32])) {
+			return nil, errDposVoteProducer
+		}
+		total.Add(total, amount)
+	}
+	if !evm.Context.CanVote(evm.StateDB, voter, total) {
+		return nil, ErrInsufficientBalance
+	}
+	evm.Context.Vote(evm.StateDB, voter, total)
+	for i := 0; i < len(input); i += 64 {
+		producer := common.BytesToAddress(input[i : i+32])
+		evm.StateDB.AddLog(&types.Log{
+			Address:     DposVoteAddress,
+			Topics:      []common.Hash{DposVoteTopic, voter.Hash(), producer.Hash()},
+			Data:        common.CopyBytes(input[i+32 : i+64]),
+			BlockNumber: evm.BlockNumber.Uint64(),
+		})
+	}
+	return true32Byte, nil
+}
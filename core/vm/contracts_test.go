@@ -4,6 +4,10 @@ import (
 	"math/big"
 	"testing"
 	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/state"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/ethdb"
+	"github.com/DEL-ORG/del/params"
 )
 type precompiledTest struct {
 	input, expected string
@@ -421,3 +425,143 @@ func BenchmarkPrecompiledBn256Pairing(bench *testing.B) {
 		benchmarkPrecompiled("08", test, bench)
 	}
 }
+func TestPrecompiledDposState(t *testing.T) {
+	db, _ := ethdb.NewMemDatabase()
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(db))
+	voter := common.HexToAddress("0x1337")
+	producer := common.HexToAddress("0x2a")
+	statedb.AddFreeze(voter, big.NewInt(4096))
+	context := Context{
+		BlockNumber: big.NewInt(int64(common.LEADER_LIMIT) + 1),
+		GetProducers: func() types.Producers {
+			return types.Producers{{Addr: producer, Vote: big.NewInt(7)}, types.EmptyProducer}
+		},
+		GetCandidateVote: func(addr common.Address) *big.Int {
+			if addr == producer {
+				return big.NewInt(7)
+			}
+			return common.Big0
+		},
+	}
+	evm := NewEVM(context, statedb, &params.ChainConfig{DposBlock: big.NewInt(0)}, Config{})
+	p := PrecompiledContractsDpos[DposStateAddress]
+	tests := []struct {
+		method   uint64
+		arg      common.Address
+		expected string
+	}{
+		{DposStateProducers, common.Address{}, "0000000000000000000000000000000000000000000000000000000000000020" +
+			"0000000000000000000000000000000000000000000000000000000000000001" +
+			"000000000000000000000000000000000000000000000000000000000000002a"},
+		{DposStateVotes, producer, "0000000000000000000000000000000000000000000000000000000000000007"},
+		{DposStateFreeze, voter, "0000000000000000000000000000000000000000000000000000000000001000"},
+		{DposStateRound, common.Address{}, "0000000000000000000000000000000000000000000000000000000000000002"},
+	}
+	for _, test := range tests {
+		in := append(common.LeftPadBytes(new(big.Int).SetUint64(test.method).Bytes(), 32), common.LeftPadBytes(test.arg.Bytes(), 32)...)
+		gas := p.RequiredGas(in)
+		if test.method == DposStateVotes {
+			gas += common.LEADER_LIMIT * params.DposStateVotesPerBlockGas
+		}
+		contract := NewContract(AccountRef(voter), nil, new(big.Int), gas)
+		res, err := RunDposPrecompiledContract(evm, p, in, contract)
+		if err != nil {
+			t.Fatalf("method %d: %v", test.method, err)
+		}
+		if common.Bytes2Hex(res) != test.expected {
+			t.Errorf("method %d: expected %v, got %v", test.method, test.expected, common.Bytes2Hex(res))
+		}
+	}
+	in := append(common.LeftPadBytes([]byte{byte(DposStateVotes)}, 32), common.LeftPadBytes(producer.Bytes(), 32)...)
+	contract := NewContract(AccountRef(voter), nil, new(big.Int), p.RequiredGas(in)+(common.LEADER_LIMIT-1)*params.DposStateVotesPerBlockGas)
+	if _, err := RunDposPrecompiledContract(evm, p, in, contract); err != ErrOutOfGas {
+		t.Errorf("expected %v for a round scan beyond the gas, got %v", ErrOutOfGas, err)
+	}
+	contract = NewContract(AccountRef(voter), nil, new(big.Int), params.DposStateBaseGas)
+	if _, err := RunDposPrecompiledContract(evm, p, common.LeftPadBytes([]byte{99}, 32), contract); err != errDposStateMethod {
+		t.Errorf("expected %v, got %v", errDposStateMethod, err)
+	}
+}
+func TestPrecompiledDposVote(t *testing.T) {
+	db, _ := ethdb.NewMemDatabase()
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(db))
+	pool := common.HexToAddress("0x1337")
+	producer := common.HexToAddress("0x2a")
+	statedb.AddBalance(pool, big.NewInt(100))
+	context := Context{
+		BlockNumber: big.NewInt(1),
+		CanTransfer: func(db StateDB, addr common.Address, amount *big.Int) bool {
+			return db.GetBalance(addr).Cmp(amount) >= 0
+		},
+		CanVote: func(db StateDB, addr common.Address, amount *big.Int) bool {
+			return db.GetBalance(addr).Cmp(amount) >= 0
+		},
+		Vote: func(db StateDB, addr common.Address, amount *big.Int) {
+			db.SubBalance(addr, amount)
+			db.AddFreeze(addr, amount)
+		},
+		GetProducers: func() types.Producers {
+			return types.Producers{{Addr: producer, Vote: big.NewInt(7)}, types.EmptyProducer}
+		},
+	}
+	evm := NewEVM(context, statedb, &params.ChainConfig{DposBlock: big.NewInt(0)}, Config{})
+	p := PrecompiledContractsDpos[DposVoteAddress]
+	rejected := []struct {
+		target common.Address
+		amount []byte
+		err    error
+	}{
+		{producer, nil, errDposVoteAmount},
+		{producer, common.Hex2Bytes("ff00000000000000000000000000000000000000000000000000000000000001"), errDposVoteAmount},
+		{common.HexToAddress("0x2b"), big.NewInt(10).Bytes(), errDposVoteProducer},
+		{common.Address{}, big.NewInt(10).Bytes(), errDposVoteProducer},
+	}
+	for i, tt := range rejected {
+		in := append(common.LeftPadBytes(tt.target.Bytes(), 32), common.LeftPadBytes(tt.amount, 32)...)
+		contract := NewContract(AccountRef(pool), AccountRef(DposVoteAddress), new(big.Int), p.RequiredGas(in))
+		if _, err := RunDposPrecompiledContract(evm, p, in, contract); err != tt.err {
+			t.Errorf("test %d: expected %v, got %v", i, tt.err, err)
+		}
+	}
+	if freeze := statedb.GetFreeze(pool); freeze.Sign() != 0 {
+		t.Fatalf("rejected votes froze funds: %v", freeze)
+	}
+	in := append(common.LeftPadBytes(producer.Bytes(), 32), common.LeftPadBytes(big.NewInt(60).Bytes(), 32)...)
+	contract := NewContract(AccountRef(pool), AccountRef(DposVoteAddress), new(big.Int), p.RequiredGas(in))
+	if _, err := RunDposPrecompiledContract(evm, p, in, contract); err != nil {
+		t.Fatal(err)
+	}
+	if balance := statedb.GetBalance(pool); balance.Cmp(big.NewInt(40)) != 0 {
+		t.Errorf("balance mismatch: have %v, want 40", balance)
+	}
+	if freeze := statedb.GetFreeze(pool); freeze.Cmp(big.NewInt(60)) != 0 {
+		t.Errorf("freeze mismatch: have %v, want 60", freeze)
+	}
+	logs := statedb.Logs()
+	if len(logs) != 1 || logs[0].Topics[1] != pool.Hash() || logs[0].Topics[2] != producer.Hash() {
+		t.Fatalf("vote log mismatch: %v", logs)
+	}
+	contract = NewContract(AccountRef(pool), AccountRef(DposVoteAddress), new(big.Int), p.RequiredGas(in))
+	if _, err := RunDposPrecompiledContract(evm, p, in, contract); err != ErrInsufficientBalance {
+		t.Errorf("expected %v, got %v", ErrInsufficientBalance, err)
+	}
+	contract = NewContract(AccountRef(pool), AccountRef(DposVoteAddress), new(big.Int), p.RequiredGas(in[:40]))
+	if _, err := RunDposPrecompiledContract(evm, p, in[:40], contract); err != errDposVoteInput {
+		t.Errorf("expected %v, got %v", errDposVoteInput, err)
+	}
+	wallet := common.HexToAddress("0xbeef")
+	statedb.AddBalance(wallet, big.NewInt(100))
+	parent := NewContract(AccountRef(pool), AccountRef(wallet), new(big.Int), 100000)
+	if _, _, err := evm.DelegateCall(parent, DposVoteAddress, in, p.RequiredGas(in)); err != errDposVoteDelegate {
+		t.Errorf("delegatecall: expected %v, got %v", errDposVoteDelegate, err)
+	}
+	if _, _, err := evm.CallCode(parent, DposVoteAddress, in, p.RequiredGas(in), new(big.Int)); err != errDposVoteDelegate {
+		t.Errorf("callcode: expected %v, got %v", errDposVoteDelegate, err)
+	}
+	if freeze := statedb.GetFreeze(wallet); freeze.Sign() != 0 {
+		t.Errorf("wallet freeze mismatch: have %v, want 0", freeze)
+	}
+	if freeze := statedb.GetFreeze(pool); freeze.Cmp(big.NewInt(60)) != 0 {
+		t.Errorf("freeze mismatch: have %v, want 60", freeze)
+	}
+}
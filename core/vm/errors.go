@@ -7,4 +7,10 @@ var (
 	ErrTraceLimitReached        = errors.New("the number of logs reached the specified limit")
 	ErrInsufficientBalance      = errors.New("insufficient balance for transfer")
 	ErrContractAddressCollision = errors.New("contract address collision")
+	ErrNoVesting                = errors.New("locked transfer without vesting schedules")
+	ErrTooManyVestings          = errors.New("too many vesting schedules")
+	ErrInsufficientDelegation   = errors.New("insufficient balance for delegation")
+	ErrNotProducer              = errors.New("sender is not a scheduled producer")
+	ErrProposalNotPending       = errors.New("proposal is not open for approval")
+	ErrAliasUnavailable         = errors.New("alias taken or insufficient balance for alias fee")
 )
@@ -4,6 +4,7 @@ import (
 	"sync/atomic"
 	"time"
 	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/types"
 	"github.com/DEL-ORG/del/crypto"
 	"github.com/DEL-ORG/del/params"
 )
@@ -13,10 +14,26 @@ type (
 	TransferFunc    func(StateDB, common.Address, common.Address, *big.Int)
 	CanVoteFunc func(StateDB, common.Address, *big.Int) bool
 	VoteFunc    func(StateDB, common.Address, *big.Int)
+	CanLockFunc func(StateDB, common.Address, []common.VestingSchedule) bool
+	LockFunc    func(StateDB, common.Address, common.Address, []common.VestingSchedule, uint64)
+	CanDelegateFunc func(StateDB, common.Address, common.DataProtocolTickets) bool
+	DelegateFunc    func(StateDB, common.Address, common.DataProtocolTickets)
+	ProposeFunc    func(StateDB, common.Address, common.DataProtocolTickets, uint64) uint64
+	CanApproveFunc func(StateDB, common.DataProtocolTickets) bool
+	ApproveFunc    func(StateDB, common.Address, common.DataProtocolTickets)
+	CanRegisterAliasFunc func(StateDB, common.Address, string, uint64) bool
+	RegisterAliasFunc    func(StateDB, common.Address, string, uint64)
 	GetHashFunc func(uint64) common.Hash
+	GetProducersFunc func() types.Producers
+	GetCandidateVoteFunc func(common.Address) *big.Int
 )
 func run(evm *EVM, contract *Contract, input []byte) ([]byte, error) {
 	if contract.CodeAddr != nil {
+		if evm.ChainConfig().IsDpos(evm.BlockNumber) {
+			if p := PrecompiledContractsDpos[*contract.CodeAddr]; p != nil {
+				return RunDposPrecompiledContract(evm, p, input, contract)
+			}
+		}
 		precompiles := PrecompiledContractsHomestead
 		if evm.ChainConfig().IsByzantium(evm.BlockNumber) {
 			precompiles = PrecompiledContractsByzantium
@@ -32,7 +49,18 @@ type Context struct {
 	Transfer TransferFunc
 	CanVote CanVoteFunc
 	Vote VoteFunc
+	CanLock CanLockFunc
+	Lock LockFunc
+	CanDelegate CanDelegateFunc
+	Delegate DelegateFunc
+	Propose ProposeFunc
+	CanApprove CanApproveFunc
+	Approve ApproveFunc
+	CanRegisterAlias CanRegisterAliasFunc
+	RegisterAlias RegisterAliasFunc
 	GetHash GetHashFunc
+	GetProducers GetProducersFunc
+	GetCandidateVote GetCandidateVoteFunc
 	Origin   common.Address 
 	GasPrice *big.Int       
 	Coinbase    common.Address 
@@ -77,10 +105,10 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 		return nil, gas, ErrInsufficientBalance
 	}
 	var message *common.DataProtocol = nil
+	var system *systemMessage
 	totalAmount := common.Big0
-	if value.Cmp(common.Big0) <= 0 {
+	if value.Cmp(common.Big0) <= 0 && (evm.depth == 0 || !evm.ChainConfig().IsDpos(evm.BlockNumber)) {
 		message, err = common.NewDataProtocol(input)
-		
 		if err == nil {
 			if message.MessageID == common.DataProtocolMessageID_VOTE {
 				totalAmount = message.Tickets.TotalAmount()
@@ -88,6 +116,17 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 					return nil, gas, ErrInsufficientBalance
 				}
 			}
+			if handler, ok := systemMessages[message.MessageID]; ok && evm.depth == 0 && handler.active(evm.ChainConfig(), evm.BlockNumber) {
+				cost, err := handler.check(evm, caller.Address(), message)
+				if err != nil {
+					return nil, gas, err
+				}
+				if gas < cost {
+					return nil, 0, ErrOutOfGas
+				}
+				gas -= cost
+				system = &handler
+			}
 		}
 	}
 	var (
@@ -99,7 +138,7 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 		if evm.ChainConfig().IsByzantium(evm.BlockNumber) {
 			precompiles = PrecompiledContractsByzantium
 		}
-		if precompiles[addr] == nil && evm.ChainConfig().IsEIP158(evm.BlockNumber) && value.Sign() == 0 {
+		if precompiles[addr] == nil && PrecompiledContractsDpos[addr] == nil && evm.ChainConfig().IsEIP158(evm.BlockNumber) && value.Sign() == 0 && system == nil {
 			return nil, gas, nil
 		}
 		evm.StateDB.CreateAccount(addr)
@@ -120,6 +159,9 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 		if message.MessageID == common.DataProtocolMessageID_VOTE {
 			evm.Vote(evm.StateDB, caller.Address(), totalAmount)
 		}
+		if system != nil {
+			system.apply(evm, caller.Address(), addr, message)
+		}
 	}
 	ret, err = run(evm, contract, input)
 	if err != nil {
@@ -130,6 +172,17 @@ func (evm *EVM) Call(caller ContractRef, addr common.Address, input []byte, gas
 	}
 	return ret, contract.Gas, err
 }
+func (evm *EVM) isProducer(addr common.Address) bool {
+	if evm.Context.GetProducers == nil {
+		return false
+	}
+	for _, producer := range evm.Context.GetProducers() {
+		if !producer.Empty() && producer.Addr == addr {
+			return true
+		}
+	}
+	return false
+}
 func (evm *EVM) CallCode(caller ContractRef, addr common.Address, input []byte, gas uint64, value *big.Int) (ret []byte, leftOverGas uint64, err error) {
 	if evm.vmConfig.NoRecursion && evm.depth > 0 {
 		return nil, gas, nil
@@ -140,30 +193,12 @@ func (evm *EVM) CallCode(caller ContractRef, addr common.Address, input []byte,
 	if !evm.CanTransfer(evm.StateDB, caller.Address(), value) {
 		return nil, gas, ErrInsufficientBalance
 	}
-	totalAmount := common.Big0
-	var message *common.DataProtocol = nil
-	if value.Cmp(common.Big0) <= 0 {
-		message, err = common.NewDataProtocol(input)
-		if err != nil {
-			if message.MessageID == common.DataProtocolMessageID_VOTE {
-				totalAmount = message.Tickets.TotalAmount()
-				if !evm.Context.CanVote(evm.StateDB, caller.Address(), message.Tickets.TotalAmount()) {
-					return nil, gas, ErrInsufficientBalance
-				}
-			}
-		}
-	}
 	var (
 		snapshot = evm.StateDB.Snapshot()
 		to       = AccountRef(caller.Address())
 	)
 	contract := NewContract(caller, to, value, gas)
 	contract.SetCallCode(&addr, evm.StateDB.GetCodeHash(addr), evm.StateDB.GetCode(addr))
-	if message != nil {
-		if message.MessageID == common.DataProtocolMessageID_VOTE {
-			evm.Vote(evm.StateDB, caller.Address(), totalAmount)
-		}
-	}
 	ret, err = run(evm, contract, input)
 	if err != nil {
 		evm.StateDB.RevertToSnapshot(snapshot)
@@ -274,5 +309,102 @@ func (evm *EVM) Create(caller ContractRef, code []byte, gas uint64, value *big.I
 	}
 	return ret, contractAddr, contract.Gas, err
 }
+type systemMessage struct {
+	active func(*params.ChainConfig, *big.Int) bool
+	check  func(evm *EVM, caller common.Address, message *common.DataProtocol) (uint64, error)
+	apply  func(evm *EVM, caller, addr common.Address, message *common.DataProtocol)
+}
+var systemMessages = map[uint16]systemMessage{
+	common.DataProtocolMessageID_LOCK:     {(*params.ChainConfig).IsVesting, checkLock, applyLock},
+	common.DataProtocolMessageID_DELEGATE: {(*params.ChainConfig).IsDelegation, checkDelegate, applyDelegate},
+	common.DataProtocolMessageID_PROPOSE:  {(*params.ChainConfig).IsGovernance, checkPropose, applyPropose},
+	common.DataProtocolMessageID_APPROVE:  {(*params.ChainConfig).IsGovernance, checkApprove, applyApprove},
+	common.DataProtocolMessageID_ALIAS:    {(*params.ChainConfig).IsAlias, checkAlias, applyAlias},
+}
+func checkLock(evm *EVM, caller common.Address, message *common.DataProtocol) (uint64, error) {
+	if err := validateVesting(message.Vesting); err != nil {
+		return 0, err
+	}
+	if !evm.Context.CanLock(evm.StateDB, caller, message.Vesting) {
+		return 0, ErrInsufficientBalance
+	}
+	return params.VestingGas(message.Vesting), nil
+}
+func applyLock(evm *EVM, caller, addr common.Address, message *common.DataProtocol) {
+	evm.Lock(evm.StateDB, caller, addr, message.Vesting, evm.BlockNumber.Uint64())
+}
+func checkDelegate(evm *EVM, caller common.Address, message *common.DataProtocol) (uint64, error) {
+	if err := message.Tickets.ValidateDelegation(); err != nil {
+		return 0, err
+	}
+	if !evm.Context.CanDelegate(evm.StateDB, caller, message.Tickets) {
+		return 0, ErrInsufficientDelegation
+	}
+	return params.DelegationGas(message.Tickets), nil
+}
+func applyDelegate(evm *EVM, caller, addr common.Address, message *common.DataProtocol) {
+	evm.Delegate(evm.StateDB, caller, message.Tickets)
+}
+func checkPropose(evm *EVM, caller common.Address, message *common.DataProtocol) (uint64, error) {
+	if !evm.isProducer(caller) {
+		return 0, ErrNotProducer
+	}
+	if err := message.Tickets.ValidateProposal(); err != nil {
+		return 0, err
+	}
+	for _, change := range message.Tickets {
+		if err := params.ValidateGovernanceParam(change.Addr, change.Amount); err != nil {
+			return 0, err
+		}
+	}
+	return params.GovernanceGas(message.Tickets), nil
+}
+func applyPropose(evm *EVM, caller, addr common.Address, message *common.DataProtocol) {
+	evm.Propose(evm.StateDB, caller, message.Tickets, common.GetRoundNumberByBlockNumber(evm.BlockNumber.Uint64(), evm.ChainConfig().GetRoundLength()))
+}
+func checkApprove(evm *EVM, caller common.Address, message *common.DataProtocol) (uint64, error) {
+	if !evm.isProducer(caller) {
+		return 0, ErrNotProducer
+	}
+	if err := message.Tickets.ValidateApproval(); err != nil {
+		return 0, err
+	}
+	if !evm.Context.CanApprove(evm.StateDB, message.Tickets) {
+		return 0, ErrProposalNotPending
+	}
+	return params.GovernanceGas(message.Tickets), nil
+}
+func applyApprove(evm *EVM, caller, addr common.Address, message *common.DataProtocol) {
+	evm.Approve(evm.StateDB, caller, message.Tickets)
+}
+func checkAlias(evm *EVM, caller common.Address, message *common.DataProtocol) (uint64, error) {
+	if message.Text == nil {
+		return 0, common.ErrAliasName
+	}
+	if err := common.ValidateAlias(*message.Text); err != nil {
+		return 0, err
+	}
+	if !evm.Context.CanRegisterAlias(evm.StateDB, caller, *message.Text, evm.BlockNumber.Uint64()) {
+		return 0, ErrAliasUnavailable
+	}
+	return params.AliasGas, nil
+}
+func applyAlias(evm *EVM, caller, addr common.Address, message *common.DataProtocol) {
+	evm.RegisterAlias(evm.StateDB, caller, *message.Text, evm.BlockNumber.Uint64())
+}
+func validateVesting(schedules []common.VestingSchedule) error {
+	if len(schedules) == 0 {
+		return ErrNoVesting
+	}
+	if len(schedules) > common.MaxVestingSchedules {
+		return ErrTooManyVestings
+	}
+	for i := range schedules {
+		if err := schedules[i].Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 func (evm *EVM) ChainConfig() *params.ChainConfig { return evm.chainConfig }
 func (evm *EVM) Interpreter() *Interpreter { return evm.interpreter }
@@ -10,6 +10,15 @@ func NewEnv(cfg *Config) *vm.EVM {
 		Transfer:    core.Transfer,
 		CanVote:core.CanVote,
 		Vote:core.Vote,
+		CanLock: core.CanLock,
+		Lock: core.Lock,
+		CanDelegate: core.CanDelegate,
+		Delegate: core.Delegate,
+		Propose: core.Propose,
+		CanApprove: core.CanApprove,
+		Approve: core.Approve,
+		CanRegisterAlias: core.CanRegisterAlias,
+		RegisterAlias: core.RegisterAlias,
 		GetHash:     func(uint64) common.Hash { return common.Hash{} },
 		Origin:      cfg.Origin,
 		Coinbase:    cfg.Coinbase,
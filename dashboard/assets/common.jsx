@@ -48,6 +48,12 @@ const menuSkeletons: Array<{|id: string, menu: ProvidedMenuProp|}> = [
 			title: 'System',
 			icon:  'tachometer',
 		},
+	}, {
+		id:   'producer',
+		menu: {
+			title: 'Producer',
+			icon:  'users',
+		},
 	}, {
 		id:   'logs',
 		menu: {
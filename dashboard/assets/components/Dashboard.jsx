@@ -98,6 +98,18 @@ const defaultContent: Content = {
 	logs:    {
 		log: [],
 	},
+	producer: {
+		round:      null,
+		slot:       null,
+		schedule:   [],
+		coinbase:   null,
+		rank:       -1,
+		votes:      null,
+		super:      false,
+		reward:     null,
+		autoVote:   false,
+		autoActive: false,
+	},
 };
 
 // updaters contains the state updater functions for each path of the state.
@@ -125,6 +137,18 @@ const updaters = {
 	logs:    {
 		log: appender(200),
 	},
+	producer: {
+		round:      replacer,
+		slot:       replacer,
+		schedule:   replacer,
+		coinbase:   replacer,
+		rank:       replacer,
+		votes:      replacer,
+		super:      replacer,
+		reward:     replacer,
+		autoVote:   replacer,
+		autoActive: replacer,
+	},
 };
 
 // styles contains the constant styles of the component.
@@ -22,6 +22,7 @@ import withStyles from 'material-ui/styles/withStyles';
 
 import {MENU} from '../common';
 import Footer from './Footer';
+import Producer from './Producer';
 import type {Content} from '../types/content';
 
 // styles contains the constant styles of the component.
@@ -35,6 +36,10 @@ const styles = {
 		flex:     1,
 		overflow: 'auto',
 	},
+	logs: {
+		fontFamily: 'monospace',
+		whiteSpace: 'pre',
+	},
 };
 
 // themeStyles returns the styles generated from the theme for the component.
@@ -68,8 +73,11 @@ class Main extends Component<Props> {
 		case MENU.get('system').id:
 			children = <div>Work in progress.</div>;
 			break;
+		case MENU.get('producer').id:
+			children = <Producer producer={content.producer} />;
+			break;
 		case MENU.get('logs').id:
-			children = <div>{content.logs.log.map((log, index) => <div key={index}>{log}</div>)}</div>;
+			children = <div style={styles.logs}>{content.logs.log.map((log, index) => <div key={index}>{log}</div>)}</div>;
 		}
 
 		return (
@@ -0,0 +1,100 @@
+// @flow
+
+// Copyright 2017 The go-ethereum Authors
+// This file is part of the go-ethereum library.
+//
+// The go-ethereum library is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Lesser General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// The go-ethereum library is distributed in the hope that it will be useful,
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE. See the
+// GNU Lesser General Public License for more details.
+//
+// You should have received a copy of the GNU Lesser General Public License
+// along with the go-ethereum library. If not, see <http://www.gnu.org/licenses/>.
+
+import React, {Component} from 'react';
+
+import Typography from 'material-ui/Typography';
+import Grid from 'material-ui/Grid';
+
+import type {Producer as ProducerContent} from '../types/content';
+
+// wei is the number of base units in one coin, used to display the reward amounts.
+const wei = 1e18;
+
+// styles contains the constant styles of the component.
+const styles = {
+	table: {
+		width:          '100%',
+		borderCollapse: 'collapse',
+	},
+	cell: {
+		padding:   '4px 8px',
+		textAlign: 'left',
+	},
+	self: {
+		fontWeight: 'bold',
+	},
+};
+
+// coins formats a base unit amount as a decimal coin amount.
+const coins = (value: ?number | ?string) => (value ? `${(Number(value) / wei).toFixed(4)}` : '0');
+
+// status formats a boolean flag as a human readable state.
+const status = (value: boolean) => (value ? 'running' : 'stopped');
+
+export type Props = {
+	producer: ProducerContent,
+};
+
+// Producer renders the round schedule, the producer standing of the node's
+// coinbase, its rewards during the last 24 hours and the automation status.
+class Producer extends Component<Props> {
+	render() {
+		const {producer} = this.props;
+		const {reward} = producer;
+
+		return (
+			<Grid container spacing={24}>
+				<Grid item xs={12} md={6}>
+					<Typography type='title'>Round {producer.round}, slot {producer.slot}</Typography>
+					<table style={styles.table}>
+						<thead>
+							<tr>
+								<th style={styles.cell}>Slot</th>
+								<th style={styles.cell}>Time</th>
+								<th style={styles.cell}>Producer</th>
+							</tr>
+						</thead>
+						<tbody>
+							{producer.schedule.map(entry => (
+								<tr key={entry.slot} style={entry.self ? styles.self : null}>
+									<td style={styles.cell}>{entry.slot}</td>
+									<td style={styles.cell}>{new Date(entry.time).toLocaleTimeString()}</td>
+									<td style={styles.cell}>{entry.producer}</td>
+								</tr>
+							))}
+						</tbody>
+					</table>
+				</Grid>
+				<Grid item xs={12} md={6}>
+					<Typography type='title'>Coinbase {producer.coinbase}</Typography>
+					<Typography>Rank: {producer.rank >= 0 ? producer.rank + 1 : 'not ranked'}{producer.super ? ' (super producer)' : ''}</Typography>
+					<Typography>Votes: {coins(producer.votes)}</Typography>
+					<Typography>Block reward (24h): {coins(reward && reward.block_reward)}</Typography>
+					<Typography>Coinbase reward (24h): {coins(reward && reward.coinbase_reward)}</Typography>
+					<Typography>Super coinbase reward (24h): {coins(reward && reward.super_coinbase_reward)}</Typography>
+					<Typography>Vote reward (24h): {coins(reward && reward.vote_reward)}</Typography>
+					<Typography>Auto vote: {status(producer.autoVote)}</Typography>
+					<Typography>Auto active: {status(producer.autoActive)}</Typography>
+				</Grid>
+			</Grid>
+		);
+	}
+}
+
+export default Producer;
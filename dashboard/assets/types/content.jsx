@@ -24,6 +24,7 @@ export type Content = {
 	network: Network,
 	system: System,
 	logs: Logs,
+	producer: Producer,
 };
 
 export type General = {
@@ -68,3 +69,30 @@ export type System = {
 export type Logs = {
 	log: Array<string>,
 };
+
+export type Producer = {
+	round: ?number,
+	slot: ?number,
+	schedule: Array<SlotEntry>,
+	coinbase: ?string,
+	rank: number,
+	votes: ?string,
+	super: boolean,
+	reward: ?Reward,
+	autoVote: boolean,
+	autoActive: boolean,
+};
+
+export type SlotEntry = {
+	slot: number,
+	time: Date,
+	producer: string,
+	self: boolean,
+};
+
+export type Reward = {
+	block_reward: number,
+	coinbase_reward: number,
+	super_coinbase_reward: number,
+	vote_reward: number,
+};
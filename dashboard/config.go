@@ -4,10 +4,12 @@ var DefaultConfig = Config{
 	Host:    "localhost",
 	Port:    8080,
 	Refresh: 5 * time.Second,
+	Slots:   12,
 }
 type Config struct {
 	Host string `toml:",omitempty"`
 	Port int `toml:",omitempty"`
 	Refresh time.Duration `toml:",omitempty"`
 	Assets string `toml:",omitempty"`
+	Slots int `toml:",omitempty"`
 }
@@ -6,10 +6,14 @@ import (
 	"net/http"
 	"path/filepath"
 	"runtime"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 	"github.com/elastic/gosigar"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/eth"
 	"github.com/DEL-ORG/del/log"
 	"github.com/DEL-ORG/del/p2p"
 	"github.com/DEL-ORG/del/params"
@@ -26,6 +30,8 @@ const (
 	systemCPUSampleLimit      = 200 
 	diskReadSampleLimit       = 200 
 	diskWriteSampleLimit      = 200 
+	logSampleLimit            = 200
+	logChanSize               = 1024
 )
 var nextID uint32 
 type Dashboard struct {
@@ -33,7 +39,12 @@ type Dashboard struct {
 	listener net.Listener
 	conns    map[uint32]*client 
 	charts   *HomeMessage
+	producer *ProducerMessage
+	voters      types.VotersMap
+	votersRound uint64
+	logs     []string
 	commit   string
+	eth      *eth.Ethereum
 	lock     sync.RWMutex 
 	quit chan chan error 
 	wg   sync.WaitGroup
@@ -43,7 +54,7 @@ type client struct {
 	msg    chan Message    
 	logger log.Logger      
 }
-func New(config *Config, commit string) (*Dashboard, error) {
+func New(config *Config, commit string, ethServ *eth.Ethereum) (*Dashboard, error) {
 	now := time.Now()
 	db := &Dashboard{
 		conns:  make(map[uint32]*client),
@@ -60,6 +71,7 @@ func New(config *Config, commit string) (*Dashboard, error) {
 			DiskWrite:      emptyChartEntries(now, diskWriteSampleLimit, config.Refresh),
 		},
 		commit: commit,
+		eth:    ethServ,
 	}
 	return db, nil
 }
@@ -168,11 +180,16 @@ func (db *Dashboard) apiHandler(conn *websocket.Conn) {
 	if len(params.VersionMeta) > 0 {
 		versionMeta = fmt.Sprintf(" (%s)", params.VersionMeta)
 	}
+	db.lock.RLock()
+	producer, logs := db.producer, db.logs
+	db.lock.RUnlock()
 	client.msg <- Message{
 		General: &GeneralMessage{
 			Version: fmt.Sprintf("v%d.%d.%d%s", params.VersionMajor, params.VersionMinor, params.VersionPatch, versionMeta),
 			Commit:  db.commit,
 		},
+		Producer: producer,
+		Logs:     &LogsMessage{Log: logs},
 		Home: &HomeMessage{
 			ActiveMemory:   db.charts.ActiveMemory,
 			VirtualMemory:  db.charts.VirtualMemory,
@@ -284,6 +301,13 @@ func (db *Dashboard) collectData() {
 			db.charts.SystemCPU = append(db.charts.SystemCPU[1:], systemCPU)
 			db.charts.DiskRead = append(db.charts.DiskRead[1:], diskRead)
 			db.charts.DiskWrite = append(db.charts.DiskRead[1:], diskWrite)
+			var producer *ProducerMessage
+			if db.eth != nil {
+				producer = db.collectProducer(now)
+				db.lock.Lock()
+				db.producer = producer
+				db.lock.Unlock()
+			}
 			db.sendToAll(&Message{
 				Home: &HomeMessage{
 					ActiveMemory:   ChartEntries{activeMemory},
@@ -295,26 +319,111 @@ func (db *Dashboard) collectData() {
 					DiskRead:       ChartEntries{diskRead},
 					DiskWrite:      ChartEntries{diskWrite},
 				},
+				Producer: producer,
 			})
 		}
 	}
 }
+func (db *Dashboard) collectProducer(now time.Time) *ProducerMessage {
+	block := db.eth.BlockChain().CurrentBlock()
+	msg := &ProducerMessage{
+		Round:      common.GetRoundNumberByBlockNumber(block.NumberU64(), db.eth.BlockChain().Config().GetRoundLength()),
+		Slot:       common.GetCurrentSlot(now),
+		Rank:       -1,
+		Votes:      "0",
+		AutoVote:   db.eth.IsVoting(),
+		AutoActive: db.eth.IsActiving(),
+	}
+	coinbase, err := db.eth.Etherbase()
+	if err == nil {
+		msg.Coinbase = coinbase
+	}
+	producers := block.Producers()
+	for i := int64(1); len(producers) > 0 && i <= int64(db.config.Slots); i++ {
+		slot := msg.Slot + i
+		entry := &SlotEntry{
+			Slot: slot,
+			Time: common.GetTimeBySlot(slot),
+		}
+		if producer := producers[slot%int64(len(producers))]; !producer.Empty() {
+			entry.Producer = producer.Addr
+			entry.Self = err == nil && producer.Addr == coinbase
+		}
+		msg.Schedule = append(msg.Schedule, entry)
+	}
+	if err != nil {
+		return msg
+	}
+	if voters := db.roundVoters(block.Header()); voters != nil {
+		if vote, ok := voters[coinbase]; ok && vote != nil {
+			msg.Votes = vote.String()
+		}
+		for i, producer := range voters.GetProducers() {
+			if producer.Addr == coinbase {
+				msg.Rank = i
+				msg.Super = i < common.SUPER_COINBASE_RANK
+				break
+			}
+		}
+	}
+	if reward, err := db.eth.BlockChain().Get24HRewardEx(coinbase); err == nil {
+		msg.Reward = reward
+	}
+	return msg
+}
+func (db *Dashboard) roundVoters(header *types.Header) types.VotersMap {
+	round := common.GetRoundNumberByBlockNumber(header.Number.Uint64(), db.eth.BlockChain().Config().GetRoundLength())
+	if db.voters == nil || db.votersRound != round {
+		db.voters = db.eth.BlockChain().GetVotersState(header)
+		db.votersRound = round
+	}
+	return db.voters
+}
 func (db *Dashboard) collectLogs() {
 	defer db.wg.Done()
-	id := 1
+	var (
+		records = make(chan *log.Record, logChanSize)
+		format  = log.TerminalFormat(false)
+		prev    = log.Root().GetHandler()
+		ticker  = time.NewTicker(db.config.Refresh / 2)
+		batch   []string
+	)
+	defer ticker.Stop()
+	log.Root().SetHandler(log.MultiHandler(prev, log.LvlFilterHandler(log.LvlInfo, log.FuncHandler(func(r *log.Record) error {
+		select {
+		case records <- r:
+		default:
+		}
+		return nil
+	}))))
+	defer log.Root().SetHandler(prev)
 	for {
 		select {
 		case errc := <-db.quit:
 			errc <- nil
 			return
-		case <-time.After(db.config.Refresh / 2):
-			db.sendToAll(&Message{
-				Logs: &LogsMessage{
-					Log: []string{fmt.Sprintf("%-4d: This is a fake log.", id)},
-				},
-			})
-			id++
+		case r := <-records:
+			batch = append(batch, strings.TrimRight(string(format.Format(r)), "\n"))
+			if len(batch) < logSampleLimit {
+				continue
+			}
+		case <-ticker.C:
+			if len(batch) == 0 {
+				continue
+			}
 		}
+		db.lock.Lock()
+		db.logs = append(db.logs, batch...)
+		if len(db.logs) > logSampleLimit {
+			db.logs = append([]string(nil), db.logs[len(db.logs)-logSampleLimit:]...)
+		}
+		db.lock.Unlock()
+		db.sendToAll(&Message{
+			Logs: &LogsMessage{
+				Log: batch,
+			},
+		})
+		batch = nil
 	}
 }
 func (db *Dashboard) sendToAll(msg *Message) {
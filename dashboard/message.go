@@ -1,5 +1,9 @@
 package dashboard
-import "time"
+import (
+	"time"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/types"
+)
 type Message struct {
 	General *GeneralMessage `json:"general,omitempty"`
 	Home    *HomeMessage    `json:"home,omitempty"`
@@ -8,6 +12,7 @@ type Message struct {
 	Network *NetworkMessage `json:"network,omitempty"`
 	System  *SystemMessage  `json:"system,omitempty"`
 	Logs    *LogsMessage    `json:"logs,omitempty"`
+	Producer *ProducerMessage `json:"producer,omitempty"`
 }
 type GeneralMessage struct {
 	Version string `json:"version,omitempty"`
@@ -43,3 +48,21 @@ type SystemMessage struct {
 type LogsMessage struct {
 	Log []string `json:"log,omitempty"`
 }
+type ProducerMessage struct {
+	Round      uint64                   `json:"round"`
+	Slot       int64                    `json:"slot"`
+	Schedule   []*SlotEntry             `json:"schedule,omitempty"`
+	Coinbase   common.Address           `json:"coinbase"`
+	Rank       int                      `json:"rank"`
+	Votes      string                   `json:"votes"`
+	Super      bool                     `json:"super"`
+	Reward     *types.OutputBlockReward `json:"reward,omitempty"`
+	AutoVote   bool                     `json:"autoVote"`
+	AutoActive bool                     `json:"autoActive"`
+}
+type SlotEntry struct {
+	Slot     int64          `json:"slot"`
+	Time     time.Time      `json:"time"`
+	Producer common.Address `json:"producer"`
+	Self     bool           `json:"self"`
+}
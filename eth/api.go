@@ -34,6 +34,47 @@ func (api *PublicEthereumAPI) Coinbase() (common.Address, error) {
 func (api *PublicEthereumAPI) Hashrate() hexutil.Uint64 {
 	return hexutil.Uint64(api.e.Miner().HashRate())
 }
+type RPCCheckpoint struct {
+	Genesis      common.Hash     `json:"genesisHash"`
+	ChainId      *hexutil.Big    `json:"chainId"`
+	Number       hexutil.Uint64  `json:"number"`
+	BlockHash    common.Hash     `json:"blockHash"`
+	Root         common.Hash     `json:"stateRoot"`
+	ProducerHash common.Hash     `json:"producersRoot"`
+	Td           *hexutil.Big    `json:"totalDifficulty"`
+	Signatures   []hexutil.Bytes `json:"signatures"`
+	Quorum       bool            `json:"quorum"`
+}
+func (api *PublicEthereumAPI) GetCheckpoint(number *hexutil.Uint64) (*RPCCheckpoint, error) {
+	var checkpoint *types.Checkpoint
+	if number == nil {
+		checkpoint = core.GetLastCheckpoint(api.e.ChainDb())
+	} else {
+		checkpoint = core.GetCheckpoint(api.e.ChainDb(), uint64(*number))
+	}
+	if checkpoint == nil {
+		return nil, nil
+	}
+	block := api.e.BlockChain().GetBlockByNumber(checkpoint.Number)
+	if block == nil {
+		return nil, fmt.Errorf("checkpoint block %d not found", checkpoint.Number)
+	}
+	result := &RPCCheckpoint{
+		Genesis:      checkpoint.Genesis,
+		ChainId:      (*hexutil.Big)(checkpoint.ChainId),
+		Number:       hexutil.Uint64(checkpoint.Number),
+		BlockHash:    checkpoint.BlockHash,
+		Root:         checkpoint.Root,
+		ProducerHash: checkpoint.ProducerHash,
+		Td:           (*hexutil.Big)(checkpoint.Td),
+		Signatures:   make([]hexutil.Bytes, len(checkpoint.Signatures)),
+		Quorum:       checkpoint.Verify(block.Producers().Supers()) == nil,
+	}
+	for i, sig := range checkpoint.Signatures {
+		result.Signatures[i] = sig
+	}
+	return result, nil
+}
 type PublicMinerAPI struct {
 	e     *Ethereum
 	agent *miner.RemoteAgent
@@ -66,6 +66,16 @@ func (b *EthApiBackend)GetVoterReward(number uint64, address common.Address) *bi
 func (b *EthApiBackend)GetSuperCoinbaseReward(number uint64, address common.Address) *big.Int {
 	return ethash.GetSuperCoinbaseReward(b.eth.BlockChain(), number, address)
 }
+func (b *EthApiBackend)GetFeeReward(number uint64, address common.Address) *big.Int {
+	block := b.eth.BlockChain().GetBlockByNumber(number)
+	if block == nil {
+		return common.Big0
+	}
+	if reward, ok := b.eth.BlockChain().GetFeeShares(block)[address]; ok {
+		return reward
+	}
+	return common.Big0
+}
 func (b *EthApiBackend)GetCoinbaseReward(number uint64, address common.Address) *big.Int {
 	return ethash.GetCoinbaseReward(b.eth.BlockChain(), number, address)
 }
@@ -204,6 +214,15 @@ func (b *EthApiBackend) GetEVM(ctx context.Context, msg core.Message, state *sta
 func (b *EthApiBackend) SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription {
 	return b.eth.BlockChain().SubscribeRemovedLogsEvent(ch)
 }
+func (b *EthApiBackend) SubscribeNewRoundEvent(ch chan<- core.NewRoundEvent) event.Subscription {
+	return b.eth.BlockChain().SubscribeNewRoundEvent(ch)
+}
+func (b *EthApiBackend) SubscribeVotesEvent(ch chan<- core.VotesEvent) event.Subscription {
+	return b.eth.BlockChain().SubscribeVotesEvent(ch)
+}
+func (b *EthApiBackend) SubscribeTextsEvent(ch chan<- core.TextsEvent) event.Subscription {
+	return b.eth.BlockChain().SubscribeTextsEvent(ch)
+}
 func (b *EthApiBackend) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription {
 	return b.eth.BlockChain().SubscribeChainEvent(ch)
 }
@@ -62,18 +62,17 @@ func (eth *Ethereum)activeLoop() {
 	duration, _ := time.ParseDuration("2s")
 	var active_round uint64 = 0
 	for {
+		round := common.GetRoundNumberByBlockNumber(eth.BlockChain().CurrentHeader().Number.Uint64(), eth.chainConfig.GetRoundLength())
+		if round > active_round {
+			err := eth.active(eth.activeAddr, eth.activePassword, eth.activeGasPrice)
+			if err == nil {
+				active_round = round
+			}
+		}
 		select {
 		case <- eth.shutdownChan:
 			return
-		default:
-			round := common.GetRoundNumberByBlockNumber(eth.BlockChain().CurrentHeader().Number.Uint64())
-			if round > active_round {
-				err := eth.active(eth.activeAddr, eth.activePassword, eth.activeGasPrice)
-				if err == nil {
-					active_round = round
-				}
-			}
-			time.Sleep(duration)
+		case <-eth.clock.After(duration):
 		}
 	}
 }
@@ -17,6 +17,13 @@ func fetchKeystore(am *accounts.Manager) *keystore.KeyStore {
 	return am.Backends(keystore.KeyStoreType)[0].(*keystore.KeyStore)
 }
 func (eth *Ethereum) vote(state *state.StateDB, addr common.Address, amount *big.Int, strategy *VoterStrategy, producers types.Producers) error {
+	return eth.sendStrategyTx(addr, strategy, types.NewVoteCreationEx(producers, state.GetNonce(addr), strategy.GasPrice, amount))
+}
+func (eth *Ethereum) delegate(state *state.StateDB, addr common.Address, amount *big.Int, strategy *VoterStrategy, producer common.Address) error {
+	tickets := common.DataProtocolTickets{common.DataProtocolVote{Addr: producer.Hex(), Amount: amount}}
+	return eth.sendStrategyTx(addr, strategy, types.NewDelegateCreation(&addr, state.GetNonce(addr), strategy.GasPrice, tickets))
+}
+func (eth *Ethereum) sendStrategyTx(addr common.Address, strategy *VoterStrategy, tx *types.Transaction) error {
 	account := accounts.Account{Address: addr}
 	err := fetchKeystore(eth.AccountManager()).Unlock(account, strategy.Password)
 	wallet, err := eth.AccountManager().Find(account)
@@ -24,8 +31,6 @@ func (eth *Ethereum) vote(state *state.StateDB, addr common.Address, amount *big
 		log.Error("Can not find account:", "addr", addr.Hex())
 		return err
 	}
-	nonce := state.GetNonce(addr)
-	tx := types.NewVoteCreationEx(producers, nonce, strategy.GasPrice, amount)
 	var chainID *big.Int
 	if config := eth.BlockChain().Config(); config.IsEIP155(eth.BlockChain().CurrentHeader().Number) {
 		chainID = config.ChainId
@@ -59,8 +64,8 @@ func (eth *Ethereum) doVoteStrategy() (err error) {
 		return errors.New("load state failed")
 	}
 	var begin_block_number uint64 = 0
-	if header.Number.Uint64() >= common.LEADER_NUMBER {
-		begin_block_number = header.Number.Uint64() - common.LEADER_NUMBER
+	if length := eth.chainConfig.GetRoundLength(); header.Number.Uint64() >= length {
+		begin_block_number = header.Number.Uint64() - length
 	}
 	addrMap := map[common.Address]*big.Int{}
 	for i := 0; header != nil && header.Number.Uint64() > begin_block_number; header = eth.BlockChain().GetHeader(header.ParentHash, header.Number.Uint64()-1) {
@@ -94,7 +99,7 @@ func (eth *Ethereum) doVoteStrategy() (err error) {
 	}
 	for addr, strategy := range eth.voteStrategy {
 		b := big.NewInt(0).Set(statedb.GetBalance(addr))
-		if b.Cmp(common.VOTE_MONEY_LIMIT) <= 0 { 
+		if b.Cmp(core.VoteMoneyLimit(statedb)) <= 0 { 
 			continue
 		}
 		tx := types.NewVoteCreationEx(producers, 0, strategy.GasPrice, b)
@@ -105,6 +110,12 @@ func (eth *Ethereum) doVoteStrategy() (err error) {
 		if strategy.Producer == nil {
 			b.Div(b, big.NewInt(int64(len(producers))))
 			eth.vote(statedb, addr, b, &strategy, producers)
+		} else if eth.chainConfig.IsDelegation(eth.BlockChain().CurrentHeader().Number) {
+			delegations := core.GetDelegations(statedb, addr)
+			if len(delegations) == 1 && delegations[0].Producer == *strategy.Producer {
+				continue
+			}
+			eth.delegate(statedb, addr, b.Add(b, delegations.Total()), &strategy, *strategy.Producer)
 		} else {
 			eth.vote(statedb, addr, b, &strategy, types.Producers{types.Producer{Addr: *strategy.Producer}})
 		}
@@ -113,28 +124,28 @@ func (eth *Ethereum) doVoteStrategy() (err error) {
 }
 func (eth *Ethereum) autoVoteLoop() {
 	duration, _ := time.ParseDuration("3s")
-	rs := rand.NewSource(time.Now().Unix())
+	rs := rand.NewSource(eth.clock.Now().Unix())
 	r := rand.New(rs)
 	targetNumber := r.Intn(200)
 	var vote_round uint64 = 0
 	for {
+		sync := eth.Downloader().Progress()
+		syncing := eth.BlockChain().CurrentHeader().Number.Uint64() < sync.HighestBlock
+		if !syncing {
+			length := eth.chainConfig.GetRoundLength()
+			round := common.GetRoundNumberByBlockNumber(eth.BlockChain().CurrentHeader().Number.Uint64(), length)
+			number := eth.BlockChain().CurrentHeader().Number.Uint64() % length
+			if round > vote_round && number >= uint64(targetNumber) {
+				err := eth.doVoteStrategy()
+				if err == nil {
+					vote_round = round
+				}
+			}
+		}
 		select {
 		case <-eth.shutdownChan:
 			return
-		default:
-			sync := eth.Downloader().Progress()
-			syncing := eth.BlockChain().CurrentHeader().Number.Uint64() < sync.HighestBlock
-			if !syncing {
-				round := common.GetRoundNumberByBlockNumber(eth.BlockChain().CurrentHeader().Number.Uint64())
-				number := eth.BlockChain().CurrentHeader().Number.Uint64() % common.LEADER_NUMBER
-				if round > vote_round && number >= uint64(targetNumber) {
-					err := eth.doVoteStrategy()
-					if err == nil {
-						vote_round = round
-					}
-				}
-			}
-			time.Sleep(duration)
+		case <-eth.clock.After(duration):
 		}
 	}
 }
@@ -4,11 +4,13 @@ import (
 	"fmt"
 	"math/big"
 	"runtime"
+	"time"
 	"sync"
 	"sync/atomic"
 	"github.com/DEL-ORG/del/accounts"
 	"github.com/DEL-ORG/del/common"
 	"github.com/DEL-ORG/del/common/hexutil"
+	"github.com/DEL-ORG/del/common/mclock"
 	"github.com/DEL-ORG/del/consensus"
 	"github.com/DEL-ORG/del/consensus/clique"
 	"github.com/DEL-ORG/del/consensus/ethash"
@@ -53,6 +55,7 @@ type Ethereum struct {
 	chainDb ethdb.Database 
 	eventMux       *event.TypeMux
 	engine         consensus.Engine
+	clock          mclock.Clock
 	accountManager *accounts.Manager
 	bloomRequests chan chan *bloombits.Retrieval 
 	bloomIndexer  *core.ChainIndexer             
@@ -62,6 +65,7 @@ type Ethereum struct {
 	etherbase common.Address
 	networkId     uint64
 	netRPCService *ethapi.PublicNetAPI
+	producerPeers *producerPeers
 	lock sync.RWMutex 
 	voteLock sync.RWMutex 
 	voteStrategy map[common.Address]VoterStrategy
@@ -95,6 +99,10 @@ func New(ctx *node.ServiceContext, config *Config) (*Ethereum, error) {
 		return nil, genesisErr
 	}
 	log.Info("Initialised chain configuration", "config", chainConfig)
+	if config.Clock == nil {
+		config.Clock = mclock.System{}
+	}
+	config.Ethash.Clock = config.Clock
 	eth := &Ethereum{
 		config:         config,
 		chainDb:        chainDb,
@@ -102,6 +110,7 @@ func New(ctx *node.ServiceContext, config *Config) (*Ethereum, error) {
 		eventMux:       ctx.EventMux,
 		accountManager: ctx.AccountManager,
 		engine:         CreateConsensusEngine(ctx, &config.Ethash, chainConfig, chainDb),
+		clock:          config.Clock,
 		shutdownChan:   make(chan bool),
 		stopDbUpgrade:  stopDbUpgrade,
 		networkId:      config.NetworkId,
@@ -138,9 +147,13 @@ func New(ctx *node.ServiceContext, config *Config) (*Ethereum, error) {
 		config.TxPool.Journal = ctx.ResolvePath(config.TxPool.Journal)
 	}
 	eth.txPool = core.NewTxPool(config.TxPool, eth.chainConfig, eth.blockchain)
-	if eth.protocolManager, err = NewProtocolManager(eth.chainConfig, config.SyncMode, config.NetworkId, eth.eventMux, eth.txPool, eth.engine, eth.blockchain, chainDb); err != nil {
+	if eth.protocolManager, err = NewProtocolManager(eth.chainConfig, config.SyncMode, config.NetworkId, eth.eventMux, eth.txPool, eth.engine, eth.blockchain, chainDb, eth.clock); err != nil {
 		return nil, err
 	}
+	if config.Checkpoint != nil {
+		log.Info("Using trusted checkpoint", "number", config.Checkpoint.Number, "hash", config.Checkpoint.BlockHash, "td", config.Checkpoint.Td)
+		eth.protocolManager.SetCheckpoint(config.Checkpoint)
+	}
 	eth.miner = miner.New(eth, eth.chainConfig, eth.EventMux(), eth.engine)
 	eth.miner.SetExtra(makeExtraData(config.ExtraData))
 	eth.ApiBackend = &EthApiBackend{eth, nil}
@@ -190,6 +203,9 @@ func CreateConsensusEngine(ctx *node.ServiceContext, config *ethash.Config, chai
 	case config.PowMode == ethash.ModeShared:
 		log.Warn("Ethash used in shared mode")
 		return ethash.NewShared()
+	case config.PowMode == ethash.ModeDev:
+		log.Warn("Ethash used in developer mode", "period", config.DevPeriod)
+		return ethash.NewDeveloper(config.DevPeriod, config.Clock)
 	default:
 		engine := ethash.New(ethash.Config{
 			CacheDir:       ctx.ResolvePath(config.CacheDir),
@@ -198,6 +214,7 @@ func CreateConsensusEngine(ctx *node.ServiceContext, config *ethash.Config, chai
 			DatasetDir:     config.DatasetDir,
 			DatasetsInMem:  config.DatasetsInMem,
 			DatasetsOnDisk: config.DatasetsOnDisk,
+			Clock:          config.Clock,
 		})
 		engine.SetThreads(-1) 
 		return engine
@@ -255,8 +272,9 @@ func (s *Ethereum) APIs() []rpc.API {
 }
 func (s *Ethereum) GetVoteFreeze(address common.Address, header *types.Header)(freeze *big.Int, err error) {
 	freeze = big.NewInt(0)
-	round := common.GetRoundNumberByBlockNumber(header.Number.Uint64())
-	begin_block_number := common.GetBeginBlockNumberByRoundNumber(round)
+	length := s.chainConfig.GetRoundLength()
+	round := common.GetRoundNumberByBlockNumber(header.Number.Uint64(), length)
+	begin_block_number := common.GetBeginBlockNumberByRoundNumber(round, length)
 	for ;header != nil && header.Number.Uint64() >= begin_block_number; header = s.BlockChain().GetHeader(header.ParentHash, header.Number.Uint64() - 1) {
 		body := s.BlockChain().GetBody(header.Hash())
 		if body == nil {
@@ -341,6 +359,8 @@ func (s *Ethereum) TxPool() *core.TxPool               { return s.txPool }
 func (s *Ethereum) EventMux() *event.TypeMux           { return s.eventMux }
 func (s *Ethereum) Engine() consensus.Engine           { return s.engine }
 func (s *Ethereum) ChainDb() ethdb.Database            { return s.chainDb }
+func (s *Ethereum) Clock() mclock.Clock                { return s.clock }
+func (s *Ethereum) ClockDrift() time.Duration          { return s.protocolManager.drift.Drift() }
 func (s *Ethereum) IsListening() bool                  { return true } 
 func (s *Ethereum) EthVersion() int                    { return int(s.protocolManager.SubProtocols[0].Version) }
 func (s *Ethereum) NetVersion() uint64                 { return s.networkId }
@@ -355,6 +375,7 @@ func (s *Ethereum) Start(srvr *p2p.Server) error {
 	s.startBloomHandlers()
 	s.startActive()
 	s.startAutoVote()
+	go s.checkpointLoop()
 	s.netRPCService = ethapi.NewPublicNetAPI(srvr, s.NetVersion())
 	maxPeers := srvr.MaxPeers
 	if s.config.LightServ > 0 {
@@ -364,6 +385,8 @@ func (s *Ethereum) Start(srvr *p2p.Server) error {
 		maxPeers -= s.config.LightPeers
 	}
 	s.protocolManager.Start(maxPeers)
+	s.producerPeers = newProducerPeers(s, srvr)
+	s.producerPeers.start()
 	if s.lesServer != nil {
 		s.lesServer.Start(srvr)
 	}
@@ -374,6 +397,9 @@ func (s *Ethereum) Stop() error {
 		s.stopDbUpgrade()
 	}
 	s.bloomIndexer.Close()
+	if s.producerPeers != nil {
+		s.producerPeers.stop()
+	}
 	s.blockchain.Stop()
 	s.protocolManager.Stop()
 	if s.lesServer != nil {
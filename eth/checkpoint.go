@@ -0,0 +1,137 @@
+package eth
+import (
+	"errors"
+	"time"
+	"github.com/DEL-ORG/del/accounts"
+	"github.com/DEL-ORG/del/core"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/log"
+)
+const (
+	checkpointChallengeTimeout = 15 * time.Second
+	checkpointHeadBuffer       = 10
+)
+var (
+	errUnknownCheckpoint  = errors.New("checkpoint block unknown")
+	errCheckpointMismatch = errors.New("checkpoint does not match local chain")
+)
+func checkpointTarget(head uint64, length uint64) (uint64, bool) {
+	if head <= length {
+		return 0, false
+	}
+	number := head - length
+	return number, types.IsCheckpointNumber(number, length)
+}
+func (pm *ProtocolManager) SetCheckpoint(checkpoint *types.Checkpoint) {
+	pm.checkpoint = checkpoint
+	pm.downloader.SetCheckpoint(checkpoint)
+}
+func (pm *ProtocolManager) AddCheckpoint(checkpoint *types.Checkpoint) (*types.Checkpoint, bool, error) {
+	block := pm.blockchain.GetBlockByNumber(checkpoint.Number)
+	if block == nil {
+		return nil, false, errUnknownCheckpoint
+	}
+	genesis := pm.blockchain.Genesis().Hash()
+	td := pm.blockchain.GetTd(block.Hash(), block.NumberU64())
+	if td == nil || !checkpoint.Matches(genesis, pm.chainconfig.ChainId, block.Header(), td) {
+		return nil, false, errCheckpointMismatch
+	}
+	supers := block.Producers().Supers()
+	pm.checkpointLock.Lock()
+	defer pm.checkpointLock.Unlock()
+	stored := core.GetCheckpoint(pm.chaindb, checkpoint.Number)
+	if stored == nil || stored.SigHash() != checkpoint.SigHash() {
+		stored = types.NewCheckpoint(genesis, pm.chainconfig.ChainId, block.Header(), td)
+	}
+	added, err := stored.Merge(checkpoint, supers)
+	if err != nil {
+		return nil, false, err
+	}
+	if added == 0 {
+		return stored, false, nil
+	}
+	if err := core.WriteCheckpoint(pm.chaindb, stored); err != nil {
+		return nil, false, err
+	}
+	if stored.Verify(supers) == nil {
+		if last := core.GetLastCheckpoint(pm.chaindb); last == nil || last.Number < stored.Number {
+			log.Info("Checkpoint reached quorum", "number", stored.Number, "hash", stored.BlockHash, "signatures", len(stored.Signatures))
+			core.WriteLastCheckpoint(pm.chaindb, stored.Number)
+		}
+	}
+	return stored, true, nil
+}
+func (pm *ProtocolManager) BroadcastCheckpoint(checkpoint *types.Checkpoint) {
+	hash := checkpoint.Hash()
+	peers := pm.peers.PeersWithoutCheckpoint(hash)
+	for _, peer := range peers {
+		peer.SendCheckpoint(checkpoint)
+	}
+	log.Trace("Broadcast checkpoint", "number", checkpoint.Number, "hash", hash, "recipients", len(peers))
+}
+func (s *Ethereum) signCheckpoint(number uint64) error {
+	block := s.blockchain.GetBlockByNumber(number)
+	if block == nil {
+		return errUnknownCheckpoint
+	}
+	etherbase, err := s.Etherbase()
+	if err != nil {
+		return err
+	}
+	super := false
+	for _, addr := range block.Producers().Supers() {
+		if addr == etherbase {
+			super = true
+			break
+		}
+	}
+	if !super {
+		return nil
+	}
+	account := accounts.Account{Address: etherbase}
+	wallet, err := s.accountManager.Find(account)
+	if err != nil {
+		return err
+	}
+	td := s.blockchain.GetTd(block.Hash(), number)
+	if td == nil {
+		return errUnknownCheckpoint
+	}
+	checkpoint := types.NewCheckpoint(s.blockchain.Genesis().Hash(), s.chainConfig.ChainId, block.Header(), td)
+	sig, err := wallet.SignHash(account, checkpoint.SigHash().Bytes())
+	if err != nil {
+		return err
+	}
+	checkpoint.Signatures = [][]byte{sig}
+	stored, changed, err := s.protocolManager.AddCheckpoint(checkpoint)
+	if err != nil {
+		return err
+	}
+	if changed {
+		log.Info("Signed checkpoint", "number", number, "hash", checkpoint.BlockHash)
+		s.protocolManager.BroadcastCheckpoint(stored)
+	}
+	return nil
+}
+func (s *Ethereum) checkpointLoop() {
+	heads := make(chan core.ChainHeadEvent, checkpointHeadBuffer)
+	sub := s.blockchain.SubscribeChainHeadEvent(heads)
+	defer sub.Unsubscribe()
+	for {
+		select {
+		case ev := <-heads:
+			if !s.IsMining() {
+				continue
+			}
+			if number, ok := checkpointTarget(ev.Block.NumberU64(), s.chainConfig.GetRoundLength()); ok {
+				if err := s.signCheckpoint(number); err != nil {
+					log.Warn("Failed to sign checkpoint", "number", number, "err", err)
+				}
+			}
+		case <-sub.Err():
+			return
+		case <-s.shutdownChan:
+			return
+		}
+	}
+}
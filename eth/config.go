@@ -8,8 +8,10 @@ import (
 	"time"
 	"github.com/DEL-ORG/del/common"
 	"github.com/DEL-ORG/del/common/hexutil"
+	"github.com/DEL-ORG/del/common/mclock"
 	"github.com/DEL-ORG/del/consensus/ethash"
 	"github.com/DEL-ORG/del/core"
+	"github.com/DEL-ORG/del/core/types"
 	"github.com/DEL-ORG/del/eth/downloader"
 	"github.com/DEL-ORG/del/eth/gasprice"
 	"github.com/DEL-ORG/del/params"
@@ -50,9 +52,10 @@ func init() {
 }
 type Config struct {
 	Genesis *core.Genesis `toml:",omitempty"`
-	NetworkId uint64 
-	SyncMode  downloader.SyncMode
-	NoPruning bool
+	NetworkId  uint64 
+	SyncMode   downloader.SyncMode
+	NoPruning  bool
+	Checkpoint *types.Checkpoint `toml:",omitempty"`
 	LightServ  int `toml:",omitempty"` 
 	LightPeers int `toml:",omitempty"` 
 	SkipBcVersionCheck bool `toml:"-"`
@@ -69,6 +72,7 @@ type Config struct {
 	GPO gasprice.Config
 	EnablePreimageRecording bool
 	DocRoot string `toml:"-"`
+	Clock mclock.Clock `toml:"-"`
 }
 type configMarshaling struct {
 	ExtraData hexutil.Bytes
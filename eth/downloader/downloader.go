@@ -52,6 +52,8 @@ var (
 	errPeersUnavailable        = errors.New("no peers available or all tried for download")
 	errInvalidAncestor         = errors.New("retrieved ancestor is invalid")
 	errInvalidChain            = errors.New("retrieved hash chain is invalid")
+	errInvalidSchedule         = errors.New("retrieved producer schedule is invalid")
+	errCheckpointMismatch      = errors.New("retrieved chain conflicts with trusted checkpoint")
 	errInvalidBlock            = errors.New("retrieved block is invalid")
 	errInvalidBody             = errors.New("retrieved block body is invalid")
 	errInvalidReceipt          = errors.New("retrieved receipt is invalid")
@@ -79,6 +81,7 @@ type Downloader struct {
 	syncStatsLock        sync.RWMutex 
 	lightchain LightChain
 	blockchain BlockChain
+	checkpoint *types.Checkpoint
 	dropPeer peerDropFn 
 	synchroniseMock func(id string, hash common.Hash) error 
 	synchronising   int32
@@ -109,6 +112,7 @@ type LightChain interface {
 	CurrentHeader() *types.Header
 	GetTd(common.Hash, uint64) *big.Int
 	InsertHeaderChain([]*types.Header, int) (int, error)
+	InsertCheckpointHeader(*types.Header, *big.Int) error
 	Rollback([]common.Hash)
 }
 type BlockChain interface {
@@ -117,9 +121,12 @@ type BlockChain interface {
 	GetBlockByHash(common.Hash) *types.Block
 	CurrentBlock() *types.Block
 	CurrentFastBlock() *types.Block
+	Genesis() *types.Block
+	Config() *params.ChainConfig
 	FastSyncCommitHead(common.Hash) error
 	InsertChain(types.Blocks) (int, error)
 	InsertReceiptChain(types.Blocks, []types.Receipts) (int, error)
+	RoundProducers(*types.Header) (types.Producers, error)
 }
 func New(mode SyncMode, stateDb ethdb.Database, mux *event.TypeMux, chain BlockChain, lightchain LightChain, dropPeer peerDropFn) *Downloader {
 	if lightchain == nil {
@@ -151,6 +158,9 @@ func New(mode SyncMode, stateDb ethdb.Database, mux *event.TypeMux, chain BlockC
 	go dl.stateFetcher()
 	return dl
 }
+func (d *Downloader) SetCheckpoint(checkpoint *types.Checkpoint) {
+	d.checkpoint = checkpoint
+}
 func (d *Downloader) Progress() ethereum.SyncProgress {
 	d.syncStatsLock.RLock()
 	defer d.syncStatsLock.RUnlock()
@@ -210,7 +220,7 @@ func (d *Downloader) Synchronise(id string, head common.Hash, td *big.Int, mode
 	case errBusy:
 	case errTimeout, errBadPeer, errStallingPeer,
 		errEmptyHeaderSet, errPeersUnavailable, errTooOld,
-		errInvalidAncestor, errInvalidChain:
+		errInvalidAncestor, errInvalidChain, errInvalidSchedule, errCheckpointMismatch:
 		log.Warn("Synchronisation failed, dropping peer", "peer", id, "err", err)
 		if d.dropPeer == nil {
 			log.Warn("Downloader wants to drop peer, but peerdrop-function is not set", "peer", id)
@@ -290,10 +300,19 @@ func (d *Downloader) syncWithPeer(p *peerConnection, hash common.Hash, td *big.I
 		return err
 	}
 	height := latest.Number.Uint64()
-	origin, err := d.findAncestor(p, height)
+	anchor, err := d.bootstrapCheckpoint(p, height)
 	if err != nil {
 		return err
 	}
+	origin := anchor
+	if d.localHeight() > anchor {
+		if origin, err = d.findAncestor(p, height); err != nil {
+			return err
+		}
+		if origin < anchor {
+			origin = anchor
+		}
+	}
 	d.syncStatsLock.Lock()
 	if d.syncStatsChainHeight <= origin || d.syncStatsChainOrigin > origin {
 		d.syncStatsChainOrigin = origin
@@ -302,13 +321,10 @@ func (d *Downloader) syncWithPeer(p *peerConnection, hash common.Hash, td *big.I
 	d.syncStatsLock.Unlock()
 	pivot := uint64(0)
 	if d.mode == FastSync {
-		if height <= uint64(fsMinFullBlocks) {
+		if pivot = fastSyncPivot(height, d.roundLength()); pivot == 0 {
 			origin = 0
-		} else {
-			pivot = height - uint64(fsMinFullBlocks)
-			if pivot <= origin {
-				origin = pivot - 1
-			}
+		} else if pivot <= origin {
+			origin = pivot - 1
 		}
 	}
 	d.committed = 1
@@ -406,13 +422,86 @@ func (d *Downloader) fetchHeight(p *peerConnection) (*types.Header, error) {
 		}
 	}
 }
-func (d *Downloader) findAncestor(p *peerConnection, height uint64) (uint64, error) {
-	floor, ceil := int64(-1), d.lightchain.CurrentHeader().Number.Uint64()
+func checkpointAnchor(mode SyncMode, checkpoint *types.Checkpoint, height uint64, length uint64) bool {
+	if checkpoint == nil || checkpoint.Td == nil || height <= checkpoint.Number {
+		return false
+	}
+	switch mode {
+	case LightSync:
+		return true
+	case FastSync:
+		return common.GetRoundNumberByBlockNumber(fastSyncPivot(height, length), length) >= common.GetRoundNumberByBlockNumber(checkpoint.Number, length)+2
+	}
+	return false
+}
+func (d *Downloader) bootstrapCheckpoint(p *peerConnection, height uint64) (uint64, error) {
+	checkpoint := d.checkpoint
+	if !checkpointAnchor(d.mode, checkpoint, height, d.roundLength()) {
+		return 0, nil
+	}
+	if header := d.lightchain.GetHeaderByHash(checkpoint.BlockHash); header != nil {
+		if d.lightchain.GetHeaderByHash(header.ParentHash) != nil {
+			return 0, nil
+		}
+		return checkpoint.Number, nil
+	}
+	if d.lightchain.CurrentHeader().Number.Uint64() > 0 {
+		return 0, nil
+	}
+	header, err := d.fetchCheckpoint(p, checkpoint)
+	if err != nil {
+		return 0, err
+	}
+	if err := d.lightchain.InsertCheckpointHeader(header, checkpoint.Td); err != nil {
+		return 0, err
+	}
+	log.Info("Bootstrapped from trusted checkpoint", "number", checkpoint.Number, "hash", checkpoint.BlockHash, "td", checkpoint.Td)
+	return checkpoint.Number, nil
+}
+func (d *Downloader) fetchCheckpoint(p *peerConnection, checkpoint *types.Checkpoint) (*types.Header, error) {
+	p.log.Debug("Retrieving trusted checkpoint header", "number", checkpoint.Number, "hash", checkpoint.BlockHash)
+	go p.peer.RequestHeadersByNumber(checkpoint.Number, 1, 0, false)
+	ttl := d.requestTTL()
+	timeout := time.After(ttl)
+	for {
+		select {
+		case <-d.cancelCh:
+			return nil, errCancelBlockFetch
+		case packet := <-d.headerCh:
+			if packet.PeerId() != p.id {
+				log.Debug("Received headers from incorrect peer", "peer", packet.PeerId())
+				break
+			}
+			headers := packet.(*headerPack).headers
+			if len(headers) != 1 {
+				p.log.Debug("Multiple headers for single request", "headers", len(headers))
+				return nil, errBadPeer
+			}
+			if err := verifyCheckpoint(checkpoint, headers); err != nil {
+				return nil, err
+			}
+			if headers[0].Number.Uint64() != checkpoint.Number {
+				return nil, errBadPeer
+			}
+			return headers[0], nil
+		case <-timeout:
+			p.log.Debug("Waiting for checkpoint header timed out", "elapsed", ttl)
+			return nil, errTimeout
+		case <-d.bodyCh:
+		case <-d.receiptCh:
+		}
+	}
+}
+func (d *Downloader) localHeight() uint64 {
 	if d.mode == FullSync {
-		ceil = d.blockchain.CurrentBlock().NumberU64()
+		return d.blockchain.CurrentBlock().NumberU64()
 	} else if d.mode == FastSync {
-		ceil = d.blockchain.CurrentFastBlock().NumberU64()
+		return d.blockchain.CurrentFastBlock().NumberU64()
 	}
+	return d.lightchain.CurrentHeader().Number.Uint64()
+}
+func (d *Downloader) findAncestor(p *peerConnection, height uint64) (uint64, error) {
+	floor, ceil := int64(-1), d.localHeight()
 	if ceil >= MaxForkAncestry {
 		floor = int64(ceil - MaxForkAncestry)
 	}
@@ -868,6 +957,9 @@ func (d *Downloader) processHeaders(origin uint64, pivot uint64, td *big.Int) er
 					limit = len(headers)
 				}
 				chunk := headers[:limit]
+				if err := verifyCheckpoint(d.checkpoint, chunk); err != nil {
+					return err
+				}
 				if d.mode == FastSync || d.mode == LightSync {
 					unknown := make([]*types.Header, 0, len(headers))
 					for _, header := range chunk {
@@ -963,10 +1055,7 @@ func (d *Downloader) processFastSyncContent(latest *types.Header) error {
 			d.queue.Close() 
 		}
 	}()
-	pivot := uint64(0)
-	if height := latest.Number.Uint64(); height > uint64(fsMinFullBlocks) {
-		pivot = height - uint64(fsMinFullBlocks)
-	}
+	pivot := fastSyncPivot(latest.Number.Uint64(), d.roundLength())
 	var (
 		oldPivot *fetchResult   
 		oldTail  []*fetchResult 
@@ -991,9 +1080,9 @@ func (d *Downloader) processFastSyncContent(latest *types.Header) error {
 		}
 		if atomic.LoadInt32(&d.committed) == 0 {
 			latest = results[len(results)-1].Header
-			if height := latest.Number.Uint64(); height > pivot+2*uint64(fsMinFullBlocks) {
-				log.Warn("Pivot became stale, moving", "old", pivot, "new", height-uint64(fsMinFullBlocks))
-				pivot = height - uint64(fsMinFullBlocks)
+			if next := fastSyncPivot(latest.Number.Uint64(), d.roundLength()); next > pivot {
+				log.Warn("Pivot became stale, moving", "old", pivot, "new", next)
+				pivot = next
 			}
 		}
 		P, beforeP, afterP := splitAroundPivot(pivot, results)
@@ -1017,6 +1106,9 @@ func (d *Downloader) processFastSyncContent(latest *types.Header) error {
 				if stateSync.err != nil {
 					return stateSync.err
 				}
+				if err := d.syncRoundState(P.Header); err != nil {
+					return err
+				}
 				if err := d.commitPivotBlock(P); err != nil {
 					return err
 				}
@@ -1045,6 +1137,120 @@ func splitAroundPivot(pivot uint64, results []*fetchResult) (p *fetchResult, bef
 	}
 	return p, before, after
 }
+func (d *Downloader) roundLength() uint64 {
+	var config *params.ChainConfig
+	if d.blockchain != nil {
+		config = d.blockchain.Config()
+	}
+	return config.GetRoundLength()
+}
+func fastSyncPivot(height uint64, length uint64) uint64 {
+	if height <= uint64(fsMinFullBlocks) {
+		return 0
+	}
+	round := common.GetRoundNumberByBlockNumber(height - uint64(fsMinFullBlocks) + 1, length)
+	return common.GetEndBlockNumberByRoundNumber(round - 1, length)
+}
+func roundBase(getHeader func(common.Hash) *types.Header, pivot *types.Header, length uint64) *types.Header {
+	begin := common.GetBeginBlockNumberByRoundNumber(common.GetRoundNumberByBlockNumber(pivot.Number.Uint64(), length), length)
+	header := pivot
+	for header != nil && header.Number.Uint64() >= begin {
+		header = getHeader(header.ParentHash)
+	}
+	return header
+}
+func (d *Downloader) syncRoundState(pivot *types.Header) error {
+	base := roundBase(d.lightchain.GetHeaderByHash, pivot, d.roundLength())
+	if base == nil {
+		return errInvalidChain
+	}
+	bases := []*types.Header{base}
+	if base.Number.Uint64() > 0 {
+		prev := roundBase(d.lightchain.GetHeaderByHash, base, d.roundLength())
+		if prev == nil {
+			return errInvalidChain
+		}
+		bases = append(bases, prev)
+	}
+	for _, header := range bases {
+		log.Debug("Syncing pivot round base state", "number", header.Number, "hash", header.Hash(), "root", header.Root)
+		stateSync := d.syncState(header.Root)
+		err := stateSync.Wait()
+		stateSync.Cancel()
+		if err != nil {
+			return err
+		}
+	}
+	if base.Number.Uint64() == 0 {
+		return nil
+	}
+	producers, err := d.blockchain.RoundProducers(base)
+	if err != nil {
+		return err
+	}
+	if types.CalcProducerHash(producers) != pivot.ProducerHash {
+		log.Debug("Pivot round schedule mismatch", "number", pivot.Number, "hash", pivot.Hash(), "have", pivot.ProducerHash, "want", types.CalcProducerHash(producers))
+		return errInvalidSchedule
+	}
+	return nil
+}
+func verifySchedule(config *params.ChainConfig, genesis *types.Block, parent, header *types.Header, producers types.Producers) error {
+	number := header.Number.Uint64()
+	round := common.GetRoundNumberByBlockNumber(number, config.GetRoundLength())
+	switch {
+	case round <= 1:
+		if header.ProducerHash != types.CalcProducerHash(genesis.Producers()) {
+			return errInvalidSchedule
+		}
+	case number != common.GetBeginBlockNumberByRoundNumber(round, config.GetRoundLength()):
+		if header.ProducerHash != parent.ProducerHash {
+			return errInvalidSchedule
+		}
+	default:
+		if header.ProducerHash != types.CalcProducerHash(producers) || len(producers) != common.LEADER_LIMIT {
+			return errInvalidSchedule
+		}
+	}
+	if len(producers) == 0 {
+		return errInvalidSchedule
+	}
+	slot := common.GetCurrentSlotByBigInt(header.Time)
+	producer := producers[slot%int64(len(producers))]
+	if !producer.Empty() && header.Coinbase == producer.Addr {
+		return nil
+	}
+	if config.IsProducerCycle(header.Number) || new(big.Int).Sub(header.Time, parent.Time).Int64() < common.MINER_TIMEOUT || header.Coinbase != genesis.Coinbase() {
+		return errInvalidSchedule
+	}
+	return nil
+}
+func verifyCheckpoint(checkpoint *types.Checkpoint, headers []*types.Header) error {
+	if checkpoint == nil {
+		return nil
+	}
+	for _, header := range headers {
+		if header.Number.Uint64() == checkpoint.Number && header.Hash() != checkpoint.BlockHash {
+			log.Debug("Header conflicts with trusted checkpoint", "number", header.Number, "hash", header.Hash(), "checkpoint", checkpoint.BlockHash)
+			return errCheckpointMismatch
+		}
+	}
+	return nil
+}
+func (d *Downloader) verifySchedules(results []*fetchResult) error {
+	genesis := d.blockchain.Genesis()
+	parent := d.lightchain.GetHeaderByHash(results[0].Header.ParentHash)
+	for _, result := range results {
+		if parent == nil {
+			return errInvalidChain
+		}
+		if err := verifySchedule(d.blockchain.Config(), genesis, parent, result.Header, result.Producers); err != nil {
+			log.Debug("Invalid producer schedule", "number", result.Header.Number, "hash", result.Header.Hash(), "coinbase", result.Header.Coinbase)
+			return err
+		}
+		parent = result.Header
+	}
+	return nil
+}
 func (d *Downloader) commitFastSyncData(results []*fetchResult, stateSync *stateSync) error {
 	if len(results) == 0 {
 		return nil
@@ -1058,6 +1264,9 @@ func (d *Downloader) commitFastSyncData(results []*fetchResult, stateSync *state
 		}
 	default:
 	}
+	if err := d.verifySchedules(results); err != nil {
+		return err
+	}
 	first, last := results[0].Header, results[len(results)-1].Header
 	log.Debug("Inserting fast-sync blocks", "items", len(results),
 		"firstnum", first.Number, "firsthash", first.Hash(),
@@ -1076,6 +1285,9 @@ func (d *Downloader) commitFastSyncData(results []*fetchResult, stateSync *state
 	return nil
 }
 func (d *Downloader) commitPivotBlock(result *fetchResult) error {
+	if err := d.verifySchedules([]*fetchResult{result}); err != nil {
+		return err
+	}
 	block := types.NewBlockWithHeader(result.Header).WithBody(result.Transactions, result.Uncles, result.Producers, result.Voters)
 	log.Debug("Committing fast sync pivot as new head", "number", block.Number(), "hash", block.Hash())
 	if _, err := d.blockchain.InsertReceiptChain([]*types.Block{block}, []types.Receipts{result.Receipts}); err != nil {
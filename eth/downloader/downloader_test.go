@@ -28,6 +28,7 @@ func init() {
 }
 type downloadTester struct {
 	downloader *Downloader
+	config  *params.ChainConfig
 	genesis *types.Block   
 	stateDb ethdb.Database 
 	peerDb  ethdb.Database 
@@ -48,6 +49,7 @@ func newTester() *downloadTester {
 	testdb, _ := ethdb.NewMemDatabase()
 	genesis := core.GenesisBlockForTesting(testdb, testAddress, big.NewInt(1000000000))
 	tester := &downloadTester{
+		config:            params.TestChainConfig,
 		genesis:           genesis,
 		peerDb:            testdb,
 		ownHashes:         []common.Hash{genesis.Hash()},
@@ -68,13 +70,16 @@ func newTester() *downloadTester {
 	return tester
 }
 func (dl *downloadTester) makeChain(n int, seed byte, parent *types.Block, parentReceipts types.Receipts, heavy bool) ([]common.Hash, map[common.Hash]*types.Header, map[common.Hash]*types.Block, map[common.Hash]types.Receipts) {
-	blocks, receipts := core.GenerateChain(params.TestChainConfig, parent, ethash.NewFaker(), dl.peerDb, n, func(i int, block *core.BlockGen) {
-		block.SetCoinbase(common.Address{seed})
-		if heavy {
-			block.OffsetTime(-1)
+	return dl.makeCustomChain(n, seed, parent, parentReceipts, heavy, nil)
+}
+func (dl *downloadTester) makeCustomChain(n int, seed byte, parent *types.Block, parentReceipts types.Receipts, heavy bool, custom func(int, *core.BlockGen)) ([]common.Hash, map[common.Hash]*types.Header, map[common.Hash]*types.Block, map[common.Hash]types.Receipts) {
+	blocks, receipts := core.GenerateChain(dl.config, parent, ethash.NewFaker(), dl.peerDb, n, func(i int, block *core.BlockGen) {
+		block.SetExtra([]byte{seed})
+		if !heavy {
+			block.OffsetTime(common.SLOT_BASE)
 		}
 		if parent == dl.genesis && i%3 == 0 {
-			signer := types.MakeSigner(params.TestChainConfig, block.Number())
+			signer := types.MakeSigner(dl.config, block.Number())
 			tx, err := types.SignTx(types.NewTransaction(block.TxNonce(testAddress), common.Address{seed}, big.NewInt(1000), params.TxGas, nil, nil), signer, testKey)
 			if err != nil {
 				panic(err)
@@ -87,6 +92,9 @@ func (dl *downloadTester) makeChain(n int, seed byte, parent *types.Block, paren
 				Number:     big.NewInt(block.Number().Int64() - 1),
 			})
 		}
+		if custom != nil {
+			custom(i, block)
+		}
 	})
 	hashes := make([]common.Hash, n+1)
 	hashes[len(hashes)-1] = parent.Hash()
@@ -197,6 +205,12 @@ func (dl *downloadTester) CurrentFastBlock() *types.Block {
 	}
 	return dl.genesis
 }
+func (dl *downloadTester) Genesis() *types.Block {
+	return dl.genesis
+}
+func (dl *downloadTester) Config() *params.ChainConfig {
+	return dl.config
+}
 func (dl *downloadTester) FastSyncCommitHead(hash common.Hash) error {
 	if block := dl.GetBlockByHash(hash); block != nil {
 		_, err := trie.NewSecure(block.Root(), trie.NewDatabase(dl.stateDb), 0)
@@ -204,6 +218,16 @@ func (dl *downloadTester) FastSyncCommitHead(hash common.Hash) error {
 	}
 	return fmt.Errorf("non existent block: %x", hash[:4])
 }
+func (dl *downloadTester) RoundProducers(parent *types.Header) (types.Producers, error) {
+	dl.lock.RLock()
+	defer dl.lock.RUnlock()
+	for _, block := range dl.ownBlocks {
+		if block.ParentHash() == parent.Hash() {
+			return block.Producers(), nil
+		}
+	}
+	return nil, errInvalidChain
+}
 func (dl *downloadTester) GetTd(hash common.Hash, number uint64) *big.Int {
 	dl.lock.RLock()
 	defer dl.lock.RUnlock()
@@ -233,6 +257,14 @@ func (dl *downloadTester) InsertHeaderChain(headers []*types.Header, checkFreq i
 	}
 	return len(headers), nil
 }
+func (dl *downloadTester) InsertCheckpointHeader(header *types.Header, td *big.Int) error {
+	dl.lock.Lock()
+	defer dl.lock.Unlock()
+	dl.ownHashes = append(dl.ownHashes, header.Hash())
+	dl.ownHeaders[header.Hash()] = header
+	dl.ownChainTd[header.Hash()] = new(big.Int).Set(td)
+	return nil
+}
 func (dl *downloadTester) InsertChain(blocks types.Blocks) (int, error) {
 	dl.lock.Lock()
 	defer dl.lock.Unlock()
@@ -393,13 +425,17 @@ func (dlp *downloadTesterPeer) RequestBodies(hashes []common.Hash) error {
 	blocks := dlp.dl.peerBlocks[dlp.id]
 	transactions := make([][]*types.Transaction, 0, len(hashes))
 	uncles := make([][]*types.Header, 0, len(hashes))
+	producers := make([]types.Producers, 0, len(hashes))
+	voters := make([]types.Voters, 0, len(hashes))
 	for _, hash := range hashes {
 		if block, ok := blocks[hash]; ok {
 			transactions = append(transactions, block.Transactions())
 			uncles = append(uncles, block.Uncles())
+			producers = append(producers, block.Producers())
+			voters = append(voters, block.Voters)
 		}
 	}
-	go dlp.dl.downloader.DeliverBodies(dlp.id, transactions, uncles)
+	go dlp.dl.downloader.DeliverBodies(dlp.id, transactions, uncles, producers, voters)
 	return nil
 }
 func (dlp *downloadTesterPeer) RequestReceipts(hashes []common.Hash) error {
@@ -435,14 +471,12 @@ func assertOwnChain(t *testing.T, tester *downloadTester, length int) {
 	assertOwnForkedChain(t, tester, 1, []int{length})
 }
 func assertOwnForkedChain(t *testing.T, tester *downloadTester, common int, lengths []int) {
-	headers, blocks, receipts := lengths[0], lengths[0], lengths[0]-fsMinFullBlocks
-	if receipts < 0 {
-		receipts = 1
-	}
-	for _, length := range lengths[1:] {
-		headers += length - common
-		blocks += length - common
-		receipts += length - common - fsMinFullBlocks
+	length := tester.Config().GetRoundLength()
+	headers, blocks, receipts := lengths[0], lengths[0], int(fastSyncPivot(uint64(lengths[0]-1), length))+1
+	for _, height := range lengths[1:] {
+		headers += height - common
+		blocks += height - common
+		receipts += int(fastSyncPivot(uint64(height-1), length)) + 1 - common
 	}
 	switch tester.downloader.mode {
 	case FullSync:
@@ -461,12 +495,12 @@ func assertOwnForkedChain(t *testing.T, tester *downloadTester, common int, leng
 	}
 	
 }
-func TestCanonicalSynchronisation62(t *testing.T)      { testCanonicalSynchronisation(t, 62, FullSync) }
-func TestCanonicalSynchronisation63Full(t *testing.T)  { testCanonicalSynchronisation(t, 63, FullSync) }
-func TestCanonicalSynchronisation63Fast(t *testing.T)  { testCanonicalSynchronisation(t, 63, FastSync) }
-func TestCanonicalSynchronisation64Full(t *testing.T)  { testCanonicalSynchronisation(t, 64, FullSync) }
-func TestCanonicalSynchronisation64Fast(t *testing.T)  { testCanonicalSynchronisation(t, 64, FastSync) }
-func TestCanonicalSynchronisation64Light(t *testing.T) { testCanonicalSynchronisation(t, 64, LightSync) }
+func TestCanonicalSynchronisation101(t *testing.T)      { testCanonicalSynchronisation(t, 101, FullSync) }
+func TestCanonicalSynchronisation102Full(t *testing.T)  { testCanonicalSynchronisation(t, 102, FullSync) }
+func TestCanonicalSynchronisation102Fast(t *testing.T)  { testCanonicalSynchronisation(t, 102, FastSync) }
+func TestCanonicalSynchronisation103Full(t *testing.T)  { testCanonicalSynchronisation(t, 103, FullSync) }
+func TestCanonicalSynchronisation103Fast(t *testing.T)  { testCanonicalSynchronisation(t, 103, FastSync) }
+func TestCanonicalSynchronisation103Light(t *testing.T) { testCanonicalSynchronisation(t, 103, LightSync) }
 func testCanonicalSynchronisation(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
@@ -479,11 +513,55 @@ func testCanonicalSynchronisation(t *testing.T, protocol int, mode SyncMode) {
 	}
 	assertOwnChain(t, tester, targetBlocks+1)
 }
-func TestThrottling62(t *testing.T)     { testThrottling(t, 62, FullSync) }
-func TestThrottling63Full(t *testing.T) { testThrottling(t, 63, FullSync) }
-func TestThrottling63Fast(t *testing.T) { testThrottling(t, 63, FastSync) }
-func TestThrottling64Full(t *testing.T) { testThrottling(t, 64, FullSync) }
-func TestThrottling64Fast(t *testing.T) { testThrottling(t, 64, FastSync) }
+func TestFastSyncRoundBoundary102(t *testing.T) { testFastSyncRoundBoundary(t, 102) }
+func TestFastSyncRoundBoundary103(t *testing.T) { testFastSyncRoundBoundary(t, 103) }
+func testFastSyncRoundBoundary(t *testing.T, protocol int) {
+	t.Parallel()
+	tester := newTester()
+	defer tester.terminate()
+	config := *params.TestChainConfig
+	config.RoundLength = 12
+	tester.config = &config
+	length := config.GetRoundLength()
+	targetBlocks := 8*int(length) + 5
+	hashes, headers, blocks, receipts := tester.makeChain(targetBlocks, 0, tester.genesis, nil, false)
+	tester.newPeer("peer", protocol, hashes, headers, blocks, receipts)
+	if err := tester.sync("peer", nil, FastSync); err != nil {
+		t.Fatalf("failed to synchronise blocks: %v", err)
+	}
+	assertOwnChain(t, tester, targetBlocks+1)
+	pivot := fastSyncPivot(uint64(targetBlocks), length)
+	if pivot == 0 || pivot%length != 0 {
+		t.Fatalf("pivot %d not at the end of a round of %d blocks", pivot, length)
+	}
+	if start := common.GetBeginBlockNumberByRoundNumber(common.GetRoundNumberByBlockNumber(pivot+1, length), length); start != pivot+1 {
+		t.Errorf("first full block %d not at a round start: have %d", pivot+1, start)
+	}
+	if _, ok := tester.ownReceipts[hashes[targetBlocks-int(pivot)]]; !ok {
+		t.Errorf("pivot %d not committed with its receipts", pivot)
+	}
+	tester = newTester()
+	defer tester.terminate()
+	tester.config = &config
+	invalid := 2*length + 1
+	hashes, headers, blocks, receipts = tester.makeCustomChain(targetBlocks, 0, tester.genesis, nil, false, func(i int, block *core.BlockGen) {
+		if block.Number().Uint64() == invalid {
+			block.SetProducers(tester.genesis.Producers())
+		}
+	})
+	tester.newPeer("bad", protocol, hashes, headers, blocks, receipts)
+	if err := tester.sync("bad", nil, FastSync); err != errInvalidSchedule {
+		t.Fatalf("bad round start schedule: have %v, want %v", err, errInvalidSchedule)
+	}
+	if block := tester.CurrentFastBlock(); block.NumberU64() >= invalid {
+		t.Errorf("fast sync head %d past the invalid round start %d", block.NumberU64(), invalid)
+	}
+}
+func TestThrottling101(t *testing.T)     { testThrottling(t, 101, FullSync) }
+func TestThrottling102Full(t *testing.T) { testThrottling(t, 102, FullSync) }
+func TestThrottling102Fast(t *testing.T) { testThrottling(t, 102, FastSync) }
+func TestThrottling103Full(t *testing.T) { testThrottling(t, 103, FullSync) }
+func TestThrottling103Fast(t *testing.T) { testThrottling(t, 103, FastSync) }
 func testThrottling(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
@@ -543,12 +621,12 @@ func testThrottling(t *testing.T, protocol int, mode SyncMode) {
 		t.Fatalf("block synchronization failed: %v", err)
 	}
 }
-func TestForkedSync62(t *testing.T)      { testForkedSync(t, 62, FullSync) }
-func TestForkedSync63Full(t *testing.T)  { testForkedSync(t, 63, FullSync) }
-func TestForkedSync63Fast(t *testing.T)  { testForkedSync(t, 63, FastSync) }
-func TestForkedSync64Full(t *testing.T)  { testForkedSync(t, 64, FullSync) }
-func TestForkedSync64Fast(t *testing.T)  { testForkedSync(t, 64, FastSync) }
-func TestForkedSync64Light(t *testing.T) { testForkedSync(t, 64, LightSync) }
+func TestForkedSync101(t *testing.T)      { testForkedSync(t, 101, FullSync) }
+func TestForkedSync102Full(t *testing.T)  { testForkedSync(t, 102, FullSync) }
+func TestForkedSync102Fast(t *testing.T)  { testForkedSync(t, 102, FastSync) }
+func TestForkedSync103Full(t *testing.T)  { testForkedSync(t, 103, FullSync) }
+func TestForkedSync103Fast(t *testing.T)  { testForkedSync(t, 103, FastSync) }
+func TestForkedSync103Light(t *testing.T) { testForkedSync(t, 103, LightSync) }
 func testForkedSync(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
@@ -566,12 +644,12 @@ func testForkedSync(t *testing.T, protocol int, mode SyncMode) {
 	}
 	assertOwnForkedChain(t, tester, common+1, []int{common + fork + 1, common + fork + 1})
 }
-func TestHeavyForkedSync62(t *testing.T)      { testHeavyForkedSync(t, 62, FullSync) }
-func TestHeavyForkedSync63Full(t *testing.T)  { testHeavyForkedSync(t, 63, FullSync) }
-func TestHeavyForkedSync63Fast(t *testing.T)  { testHeavyForkedSync(t, 63, FastSync) }
-func TestHeavyForkedSync64Full(t *testing.T)  { testHeavyForkedSync(t, 64, FullSync) }
-func TestHeavyForkedSync64Fast(t *testing.T)  { testHeavyForkedSync(t, 64, FastSync) }
-func TestHeavyForkedSync64Light(t *testing.T) { testHeavyForkedSync(t, 64, LightSync) }
+func TestHeavyForkedSync101(t *testing.T)      { testHeavyForkedSync(t, 101, FullSync) }
+func TestHeavyForkedSync102Full(t *testing.T)  { testHeavyForkedSync(t, 102, FullSync) }
+func TestHeavyForkedSync102Fast(t *testing.T)  { testHeavyForkedSync(t, 102, FastSync) }
+func TestHeavyForkedSync103Full(t *testing.T)  { testHeavyForkedSync(t, 103, FullSync) }
+func TestHeavyForkedSync103Fast(t *testing.T)  { testHeavyForkedSync(t, 103, FastSync) }
+func TestHeavyForkedSync103Light(t *testing.T) { testHeavyForkedSync(t, 103, LightSync) }
 func testHeavyForkedSync(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
@@ -589,12 +667,12 @@ func testHeavyForkedSync(t *testing.T, protocol int, mode SyncMode) {
 	}
 	assertOwnForkedChain(t, tester, common+1, []int{common + fork + 1, common + fork/2 + 1})
 }
-func TestBoundedForkedSync62(t *testing.T)      { testBoundedForkedSync(t, 62, FullSync) }
-func TestBoundedForkedSync63Full(t *testing.T)  { testBoundedForkedSync(t, 63, FullSync) }
-func TestBoundedForkedSync63Fast(t *testing.T)  { testBoundedForkedSync(t, 63, FastSync) }
-func TestBoundedForkedSync64Full(t *testing.T)  { testBoundedForkedSync(t, 64, FullSync) }
-func TestBoundedForkedSync64Fast(t *testing.T)  { testBoundedForkedSync(t, 64, FastSync) }
-func TestBoundedForkedSync64Light(t *testing.T) { testBoundedForkedSync(t, 64, LightSync) }
+func TestBoundedForkedSync101(t *testing.T)      { testBoundedForkedSync(t, 101, FullSync) }
+func TestBoundedForkedSync102Full(t *testing.T)  { testBoundedForkedSync(t, 102, FullSync) }
+func TestBoundedForkedSync102Fast(t *testing.T)  { testBoundedForkedSync(t, 102, FastSync) }
+func TestBoundedForkedSync103Full(t *testing.T)  { testBoundedForkedSync(t, 103, FullSync) }
+func TestBoundedForkedSync103Fast(t *testing.T)  { testBoundedForkedSync(t, 103, FastSync) }
+func TestBoundedForkedSync103Light(t *testing.T) { testBoundedForkedSync(t, 103, LightSync) }
 func testBoundedForkedSync(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
@@ -611,12 +689,12 @@ func testBoundedForkedSync(t *testing.T, protocol int, mode SyncMode) {
 		t.Fatalf("sync failure mismatch: have %v, want %v", err, errInvalidAncestor)
 	}
 }
-func TestBoundedHeavyForkedSync62(t *testing.T)      { testBoundedHeavyForkedSync(t, 62, FullSync) }
-func TestBoundedHeavyForkedSync63Full(t *testing.T)  { testBoundedHeavyForkedSync(t, 63, FullSync) }
-func TestBoundedHeavyForkedSync63Fast(t *testing.T)  { testBoundedHeavyForkedSync(t, 63, FastSync) }
-func TestBoundedHeavyForkedSync64Full(t *testing.T)  { testBoundedHeavyForkedSync(t, 64, FullSync) }
-func TestBoundedHeavyForkedSync64Fast(t *testing.T)  { testBoundedHeavyForkedSync(t, 64, FastSync) }
-func TestBoundedHeavyForkedSync64Light(t *testing.T) { testBoundedHeavyForkedSync(t, 64, LightSync) }
+func TestBoundedHeavyForkedSync101(t *testing.T)      { testBoundedHeavyForkedSync(t, 101, FullSync) }
+func TestBoundedHeavyForkedSync102Full(t *testing.T)  { testBoundedHeavyForkedSync(t, 102, FullSync) }
+func TestBoundedHeavyForkedSync102Fast(t *testing.T)  { testBoundedHeavyForkedSync(t, 102, FastSync) }
+func TestBoundedHeavyForkedSync103Full(t *testing.T)  { testBoundedHeavyForkedSync(t, 103, FullSync) }
+func TestBoundedHeavyForkedSync103Fast(t *testing.T)  { testBoundedHeavyForkedSync(t, 103, FastSync) }
+func TestBoundedHeavyForkedSync103Light(t *testing.T) { testBoundedHeavyForkedSync(t, 103, LightSync) }
 func testBoundedHeavyForkedSync(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
@@ -633,37 +711,37 @@ func testBoundedHeavyForkedSync(t *testing.T, protocol int, mode SyncMode) {
 		t.Fatalf("sync failure mismatch: have %v, want %v", err, errInvalidAncestor)
 	}
 }
-func TestInactiveDownloader62(t *testing.T) {
+func TestInactiveDownloader101(t *testing.T) {
 	t.Parallel()
 	tester := newTester()
 	defer tester.terminate()
 	if err := tester.downloader.DeliverHeaders("bad peer", []*types.Header{}); err != errNoSyncActive {
 		t.Errorf("error mismatch: have %v, want %v", err, errNoSyncActive)
 	}
-	if err := tester.downloader.DeliverBodies("bad peer", [][]*types.Transaction{}, [][]*types.Header{}); err != errNoSyncActive {
+	if err := tester.downloader.DeliverBodies("bad peer", [][]*types.Transaction{}, [][]*types.Header{}, []types.Producers{}, []types.Voters{}); err != errNoSyncActive {
 		t.Errorf("error mismatch: have %v, want %v", err, errNoSyncActive)
 	}
 }
-func TestInactiveDownloader63(t *testing.T) {
+func TestInactiveDownloader102(t *testing.T) {
 	t.Parallel()
 	tester := newTester()
 	defer tester.terminate()
 	if err := tester.downloader.DeliverHeaders("bad peer", []*types.Header{}); err != errNoSyncActive {
 		t.Errorf("error mismatch: have %v, want %v", err, errNoSyncActive)
 	}
-	if err := tester.downloader.DeliverBodies("bad peer", [][]*types.Transaction{}, [][]*types.Header{}); err != errNoSyncActive {
+	if err := tester.downloader.DeliverBodies("bad peer", [][]*types.Transaction{}, [][]*types.Header{}, []types.Producers{}, []types.Voters{}); err != errNoSyncActive {
 		t.Errorf("error mismatch: have %v, want %v", err, errNoSyncActive)
 	}
 	if err := tester.downloader.DeliverReceipts("bad peer", [][]*types.Receipt{}); err != errNoSyncActive {
 		t.Errorf("error mismatch: have %v, want %v", err, errNoSyncActive)
 	}
 }
-func TestCancel62(t *testing.T)      { testCancel(t, 62, FullSync) }
-func TestCancel63Full(t *testing.T)  { testCancel(t, 63, FullSync) }
-func TestCancel63Fast(t *testing.T)  { testCancel(t, 63, FastSync) }
-func TestCancel64Full(t *testing.T)  { testCancel(t, 64, FullSync) }
-func TestCancel64Fast(t *testing.T)  { testCancel(t, 64, FastSync) }
-func TestCancel64Light(t *testing.T) { testCancel(t, 64, LightSync) }
+func TestCancel101(t *testing.T)      { testCancel(t, 101, FullSync) }
+func TestCancel102Full(t *testing.T)  { testCancel(t, 102, FullSync) }
+func TestCancel102Fast(t *testing.T)  { testCancel(t, 102, FastSync) }
+func TestCancel103Full(t *testing.T)  { testCancel(t, 103, FullSync) }
+func TestCancel103Fast(t *testing.T)  { testCancel(t, 103, FastSync) }
+func TestCancel103Light(t *testing.T) { testCancel(t, 103, LightSync) }
 func testCancel(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
@@ -689,12 +767,12 @@ func testCancel(t *testing.T, protocol int, mode SyncMode) {
 		t.Errorf("download queue not idle")
 	}
 }
-func TestMultiSynchronisation62(t *testing.T)      { testMultiSynchronisation(t, 62, FullSync) }
-func TestMultiSynchronisation63Full(t *testing.T)  { testMultiSynchronisation(t, 63, FullSync) }
-func TestMultiSynchronisation63Fast(t *testing.T)  { testMultiSynchronisation(t, 63, FastSync) }
-func TestMultiSynchronisation64Full(t *testing.T)  { testMultiSynchronisation(t, 64, FullSync) }
-func TestMultiSynchronisation64Fast(t *testing.T)  { testMultiSynchronisation(t, 64, FastSync) }
-func TestMultiSynchronisation64Light(t *testing.T) { testMultiSynchronisation(t, 64, LightSync) }
+func TestMultiSynchronisation101(t *testing.T)      { testMultiSynchronisation(t, 101, FullSync) }
+func TestMultiSynchronisation102Full(t *testing.T)  { testMultiSynchronisation(t, 102, FullSync) }
+func TestMultiSynchronisation102Fast(t *testing.T)  { testMultiSynchronisation(t, 102, FastSync) }
+func TestMultiSynchronisation103Full(t *testing.T)  { testMultiSynchronisation(t, 103, FullSync) }
+func TestMultiSynchronisation103Fast(t *testing.T)  { testMultiSynchronisation(t, 103, FastSync) }
+func TestMultiSynchronisation103Light(t *testing.T) { testMultiSynchronisation(t, 103, LightSync) }
 func testMultiSynchronisation(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
@@ -711,38 +789,38 @@ func testMultiSynchronisation(t *testing.T, protocol int, mode SyncMode) {
 	}
 	assertOwnChain(t, tester, targetBlocks+1)
 }
-func TestMultiProtoSynchronisation62(t *testing.T)      { testMultiProtoSync(t, 62, FullSync) }
-func TestMultiProtoSynchronisation63Full(t *testing.T)  { testMultiProtoSync(t, 63, FullSync) }
-func TestMultiProtoSynchronisation63Fast(t *testing.T)  { testMultiProtoSync(t, 63, FastSync) }
-func TestMultiProtoSynchronisation64Full(t *testing.T)  { testMultiProtoSync(t, 64, FullSync) }
-func TestMultiProtoSynchronisation64Fast(t *testing.T)  { testMultiProtoSync(t, 64, FastSync) }
-func TestMultiProtoSynchronisation64Light(t *testing.T) { testMultiProtoSync(t, 64, LightSync) }
+func TestMultiProtoSynchronisation101(t *testing.T)      { testMultiProtoSync(t, 101, FullSync) }
+func TestMultiProtoSynchronisation102Full(t *testing.T)  { testMultiProtoSync(t, 102, FullSync) }
+func TestMultiProtoSynchronisation102Fast(t *testing.T)  { testMultiProtoSync(t, 102, FastSync) }
+func TestMultiProtoSynchronisation103Full(t *testing.T)  { testMultiProtoSync(t, 103, FullSync) }
+func TestMultiProtoSynchronisation103Fast(t *testing.T)  { testMultiProtoSync(t, 103, FastSync) }
+func TestMultiProtoSynchronisation103Light(t *testing.T) { testMultiProtoSync(t, 103, LightSync) }
 func testMultiProtoSync(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
 	defer tester.terminate()
 	targetBlocks := blockCacheItems - 15
 	hashes, headers, blocks, receipts := tester.makeChain(targetBlocks, 0, tester.genesis, nil, false)
-	tester.newPeer("peer 62", 62, hashes, headers, blocks, nil)
-	tester.newPeer("peer 63", 63, hashes, headers, blocks, receipts)
-	tester.newPeer("peer 64", 64, hashes, headers, blocks, receipts)
+	tester.newPeer("peer 101", 101, hashes, headers, blocks, nil)
+	tester.newPeer("peer 102", 102, hashes, headers, blocks, receipts)
+	tester.newPeer("peer 103", 103, hashes, headers, blocks, receipts)
 	if err := tester.sync(fmt.Sprintf("peer %d", protocol), nil, mode); err != nil {
 		t.Fatalf("failed to synchronise blocks: %v", err)
 	}
 	assertOwnChain(t, tester, targetBlocks+1)
-	for _, version := range []int{62, 63, 64} {
+	for _, version := range []int{101, 102, 103} {
 		peer := fmt.Sprintf("peer %d", version)
 		if _, ok := tester.peerHashes[peer]; !ok {
 			t.Errorf("%s dropped", peer)
 		}
 	}
 }
-func TestEmptyShortCircuit62(t *testing.T)      { testEmptyShortCircuit(t, 62, FullSync) }
-func TestEmptyShortCircuit63Full(t *testing.T)  { testEmptyShortCircuit(t, 63, FullSync) }
-func TestEmptyShortCircuit63Fast(t *testing.T)  { testEmptyShortCircuit(t, 63, FastSync) }
-func TestEmptyShortCircuit64Full(t *testing.T)  { testEmptyShortCircuit(t, 64, FullSync) }
-func TestEmptyShortCircuit64Fast(t *testing.T)  { testEmptyShortCircuit(t, 64, FastSync) }
-func TestEmptyShortCircuit64Light(t *testing.T) { testEmptyShortCircuit(t, 64, LightSync) }
+func TestEmptyShortCircuit101(t *testing.T)      { testEmptyShortCircuit(t, 101, FullSync) }
+func TestEmptyShortCircuit102Full(t *testing.T)  { testEmptyShortCircuit(t, 102, FullSync) }
+func TestEmptyShortCircuit102Fast(t *testing.T)  { testEmptyShortCircuit(t, 102, FastSync) }
+func TestEmptyShortCircuit103Full(t *testing.T)  { testEmptyShortCircuit(t, 103, FullSync) }
+func TestEmptyShortCircuit103Fast(t *testing.T)  { testEmptyShortCircuit(t, 103, FastSync) }
+func TestEmptyShortCircuit103Light(t *testing.T) { testEmptyShortCircuit(t, 103, LightSync) }
 func testEmptyShortCircuit(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
@@ -763,7 +841,7 @@ func testEmptyShortCircuit(t *testing.T, protocol int, mode SyncMode) {
 	assertOwnChain(t, tester, targetBlocks+1)
 	bodiesNeeded, receiptsNeeded := 0, 0
 	for _, block := range blocks {
-		if mode != LightSync && block != tester.genesis && (len(block.Transactions()) > 0 || len(block.Uncles()) > 0) {
+		if mode != LightSync && block != tester.genesis && (len(block.Transactions()) > 0 || len(block.Uncles()) > 0 || len(block.Producers()) > 0 || len(block.Voters) > 0) {
 			bodiesNeeded++
 		}
 	}
@@ -779,12 +857,12 @@ func testEmptyShortCircuit(t *testing.T, protocol int, mode SyncMode) {
 		t.Errorf("receipt retrieval count mismatch: have %v, want %v", receiptsHave, receiptsNeeded)
 	}
 }
-func TestMissingHeaderAttack62(t *testing.T)      { testMissingHeaderAttack(t, 62, FullSync) }
-func TestMissingHeaderAttack63Full(t *testing.T)  { testMissingHeaderAttack(t, 63, FullSync) }
-func TestMissingHeaderAttack63Fast(t *testing.T)  { testMissingHeaderAttack(t, 63, FastSync) }
-func TestMissingHeaderAttack64Full(t *testing.T)  { testMissingHeaderAttack(t, 64, FullSync) }
-func TestMissingHeaderAttack64Fast(t *testing.T)  { testMissingHeaderAttack(t, 64, FastSync) }
-func TestMissingHeaderAttack64Light(t *testing.T) { testMissingHeaderAttack(t, 64, LightSync) }
+func TestMissingHeaderAttack101(t *testing.T)      { testMissingHeaderAttack(t, 101, FullSync) }
+func TestMissingHeaderAttack102Full(t *testing.T)  { testMissingHeaderAttack(t, 102, FullSync) }
+func TestMissingHeaderAttack102Fast(t *testing.T)  { testMissingHeaderAttack(t, 102, FastSync) }
+func TestMissingHeaderAttack103Full(t *testing.T)  { testMissingHeaderAttack(t, 103, FullSync) }
+func TestMissingHeaderAttack103Fast(t *testing.T)  { testMissingHeaderAttack(t, 103, FastSync) }
+func TestMissingHeaderAttack103Light(t *testing.T) { testMissingHeaderAttack(t, 103, LightSync) }
 func testMissingHeaderAttack(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
@@ -803,12 +881,12 @@ func testMissingHeaderAttack(t *testing.T, protocol int, mode SyncMode) {
 	}
 	assertOwnChain(t, tester, targetBlocks+1)
 }
-func TestShiftedHeaderAttack62(t *testing.T)      { testShiftedHeaderAttack(t, 62, FullSync) }
-func TestShiftedHeaderAttack63Full(t *testing.T)  { testShiftedHeaderAttack(t, 63, FullSync) }
-func TestShiftedHeaderAttack63Fast(t *testing.T)  { testShiftedHeaderAttack(t, 63, FastSync) }
-func TestShiftedHeaderAttack64Full(t *testing.T)  { testShiftedHeaderAttack(t, 64, FullSync) }
-func TestShiftedHeaderAttack64Fast(t *testing.T)  { testShiftedHeaderAttack(t, 64, FastSync) }
-func TestShiftedHeaderAttack64Light(t *testing.T) { testShiftedHeaderAttack(t, 64, LightSync) }
+func TestShiftedHeaderAttack101(t *testing.T)      { testShiftedHeaderAttack(t, 101, FullSync) }
+func TestShiftedHeaderAttack102Full(t *testing.T)  { testShiftedHeaderAttack(t, 102, FullSync) }
+func TestShiftedHeaderAttack102Fast(t *testing.T)  { testShiftedHeaderAttack(t, 102, FastSync) }
+func TestShiftedHeaderAttack103Full(t *testing.T)  { testShiftedHeaderAttack(t, 103, FullSync) }
+func TestShiftedHeaderAttack103Fast(t *testing.T)  { testShiftedHeaderAttack(t, 103, FastSync) }
+func TestShiftedHeaderAttack103Light(t *testing.T) { testShiftedHeaderAttack(t, 103, LightSync) }
 func testShiftedHeaderAttack(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
@@ -828,9 +906,9 @@ func testShiftedHeaderAttack(t *testing.T, protocol int, mode SyncMode) {
 	}
 	assertOwnChain(t, tester, targetBlocks+1)
 }
-func TestInvalidHeaderRollback63Fast(t *testing.T)  { testInvalidHeaderRollback(t, 63, FastSync) }
-func TestInvalidHeaderRollback64Fast(t *testing.T)  { testInvalidHeaderRollback(t, 64, FastSync) }
-func TestInvalidHeaderRollback64Light(t *testing.T) { testInvalidHeaderRollback(t, 64, LightSync) }
+func TestInvalidHeaderRollback102Fast(t *testing.T)  { testInvalidHeaderRollback(t, 102, FastSync) }
+func TestInvalidHeaderRollback103Fast(t *testing.T)  { testInvalidHeaderRollback(t, 103, FastSync) }
+func TestInvalidHeaderRollback103Light(t *testing.T) { testInvalidHeaderRollback(t, 103, LightSync) }
 func testInvalidHeaderRollback(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
@@ -893,25 +971,25 @@ func testInvalidHeaderRollback(t *testing.T, protocol int, mode SyncMode) {
 		}
 	}
 }
-func TestHighTDStarvationAttack62(t *testing.T)      { testHighTDStarvationAttack(t, 62, FullSync) }
-func TestHighTDStarvationAttack63Full(t *testing.T)  { testHighTDStarvationAttack(t, 63, FullSync) }
-func TestHighTDStarvationAttack63Fast(t *testing.T)  { testHighTDStarvationAttack(t, 63, FastSync) }
-func TestHighTDStarvationAttack64Full(t *testing.T)  { testHighTDStarvationAttack(t, 64, FullSync) }
-func TestHighTDStarvationAttack64Fast(t *testing.T)  { testHighTDStarvationAttack(t, 64, FastSync) }
-func TestHighTDStarvationAttack64Light(t *testing.T) { testHighTDStarvationAttack(t, 64, LightSync) }
+func TestHighTDStarvationAttack101(t *testing.T)      { testHighTDStarvationAttack(t, 101, FullSync) }
+func TestHighTDStarvationAttack102Full(t *testing.T)  { testHighTDStarvationAttack(t, 102, FullSync) }
+func TestHighTDStarvationAttack102Fast(t *testing.T)  { testHighTDStarvationAttack(t, 102, FastSync) }
+func TestHighTDStarvationAttack103Full(t *testing.T)  { testHighTDStarvationAttack(t, 103, FullSync) }
+func TestHighTDStarvationAttack103Fast(t *testing.T)  { testHighTDStarvationAttack(t, 103, FastSync) }
+func TestHighTDStarvationAttack103Light(t *testing.T) { testHighTDStarvationAttack(t, 103, LightSync) }
 func testHighTDStarvationAttack(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
 	defer tester.terminate()
 	hashes, headers, blocks, receipts := tester.makeChain(0, 0, tester.genesis, nil, false)
 	tester.newPeer("attack", protocol, []common.Hash{hashes[0]}, headers, blocks, receipts)
-	if err := tester.sync("attack", big.NewInt(1000000), mode); err != errStallingPeer {
+	if err := tester.sync("attack", new(big.Int).Add(tester.genesis.Difficulty(), big.NewInt(1000000)), mode); err != errStallingPeer {
 		t.Fatalf("synchronisation error mismatch: have %v, want %v", err, errStallingPeer)
 	}
 }
-func TestBlockHeaderAttackerDropping62(t *testing.T) { testBlockHeaderAttackerDropping(t, 62) }
-func TestBlockHeaderAttackerDropping63(t *testing.T) { testBlockHeaderAttackerDropping(t, 63) }
-func TestBlockHeaderAttackerDropping64(t *testing.T) { testBlockHeaderAttackerDropping(t, 64) }
+func TestBlockHeaderAttackerDropping101(t *testing.T) { testBlockHeaderAttackerDropping(t, 101) }
+func TestBlockHeaderAttackerDropping102(t *testing.T) { testBlockHeaderAttackerDropping(t, 102) }
+func TestBlockHeaderAttackerDropping103(t *testing.T) { testBlockHeaderAttackerDropping(t, 103) }
 func testBlockHeaderAttackerDropping(t *testing.T, protocol int) {
 	t.Parallel()
 	tests := []struct {
@@ -956,12 +1034,12 @@ func testBlockHeaderAttackerDropping(t *testing.T, protocol int) {
 		}
 	}
 }
-func TestSyncProgress62(t *testing.T)      { testSyncProgress(t, 62, FullSync) }
-func TestSyncProgress63Full(t *testing.T)  { testSyncProgress(t, 63, FullSync) }
-func TestSyncProgress63Fast(t *testing.T)  { testSyncProgress(t, 63, FastSync) }
-func TestSyncProgress64Full(t *testing.T)  { testSyncProgress(t, 64, FullSync) }
-func TestSyncProgress64Fast(t *testing.T)  { testSyncProgress(t, 64, FastSync) }
-func TestSyncProgress64Light(t *testing.T) { testSyncProgress(t, 64, LightSync) }
+func TestSyncProgress101(t *testing.T)      { testSyncProgress(t, 101, FullSync) }
+func TestSyncProgress102Full(t *testing.T)  { testSyncProgress(t, 102, FullSync) }
+func TestSyncProgress102Fast(t *testing.T)  { testSyncProgress(t, 102, FastSync) }
+func TestSyncProgress103Full(t *testing.T)  { testSyncProgress(t, 103, FullSync) }
+func TestSyncProgress103Fast(t *testing.T)  { testSyncProgress(t, 103, FastSync) }
+func TestSyncProgress103Light(t *testing.T) { testSyncProgress(t, 103, LightSync) }
 func testSyncProgress(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
@@ -1010,12 +1088,12 @@ func testSyncProgress(t *testing.T, protocol int, mode SyncMode) {
 		t.Fatalf("Final progress mismatch: have %v/%v/%v, want %v/%v/%v", progress.StartingBlock, progress.CurrentBlock, progress.HighestBlock, targetBlocks/2+1, targetBlocks, targetBlocks)
 	}
 }
-func TestForkedSyncProgress62(t *testing.T)      { testForkedSyncProgress(t, 62, FullSync) }
-func TestForkedSyncProgress63Full(t *testing.T)  { testForkedSyncProgress(t, 63, FullSync) }
-func TestForkedSyncProgress63Fast(t *testing.T)  { testForkedSyncProgress(t, 63, FastSync) }
-func TestForkedSyncProgress64Full(t *testing.T)  { testForkedSyncProgress(t, 64, FullSync) }
-func TestForkedSyncProgress64Fast(t *testing.T)  { testForkedSyncProgress(t, 64, FastSync) }
-func TestForkedSyncProgress64Light(t *testing.T) { testForkedSyncProgress(t, 64, LightSync) }
+func TestForkedSyncProgress101(t *testing.T)      { testForkedSyncProgress(t, 101, FullSync) }
+func TestForkedSyncProgress102Full(t *testing.T)  { testForkedSyncProgress(t, 102, FullSync) }
+func TestForkedSyncProgress102Fast(t *testing.T)  { testForkedSyncProgress(t, 102, FastSync) }
+func TestForkedSyncProgress103Full(t *testing.T)  { testForkedSyncProgress(t, 103, FullSync) }
+func TestForkedSyncProgress103Fast(t *testing.T)  { testForkedSyncProgress(t, 103, FastSync) }
+func TestForkedSyncProgress103Light(t *testing.T) { testForkedSyncProgress(t, 103, LightSync) }
 func testForkedSyncProgress(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
@@ -1065,12 +1143,12 @@ func testForkedSyncProgress(t *testing.T, protocol int, mode SyncMode) {
 		t.Fatalf("Final progress mismatch: have %v/%v/%v, want %v/%v/%v", progress.StartingBlock, progress.CurrentBlock, progress.HighestBlock, common, len(hashesB)-1, len(hashesB)-1)
 	}
 }
-func TestFailedSyncProgress62(t *testing.T)      { testFailedSyncProgress(t, 62, FullSync) }
-func TestFailedSyncProgress63Full(t *testing.T)  { testFailedSyncProgress(t, 63, FullSync) }
-func TestFailedSyncProgress63Fast(t *testing.T)  { testFailedSyncProgress(t, 63, FastSync) }
-func TestFailedSyncProgress64Full(t *testing.T)  { testFailedSyncProgress(t, 64, FullSync) }
-func TestFailedSyncProgress64Fast(t *testing.T)  { testFailedSyncProgress(t, 64, FastSync) }
-func TestFailedSyncProgress64Light(t *testing.T) { testFailedSyncProgress(t, 64, LightSync) }
+func TestFailedSyncProgress101(t *testing.T)      { testFailedSyncProgress(t, 101, FullSync) }
+func TestFailedSyncProgress102Full(t *testing.T)  { testFailedSyncProgress(t, 102, FullSync) }
+func TestFailedSyncProgress102Fast(t *testing.T)  { testFailedSyncProgress(t, 102, FastSync) }
+func TestFailedSyncProgress103Full(t *testing.T)  { testFailedSyncProgress(t, 103, FullSync) }
+func TestFailedSyncProgress103Fast(t *testing.T)  { testFailedSyncProgress(t, 103, FastSync) }
+func TestFailedSyncProgress103Light(t *testing.T) { testFailedSyncProgress(t, 103, LightSync) }
 func testFailedSyncProgress(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
@@ -1123,12 +1201,12 @@ func testFailedSyncProgress(t *testing.T, protocol int, mode SyncMode) {
 		t.Fatalf("Final progress mismatch: have %v/%v/%v, want 0-%v/%v/%v", progress.StartingBlock, progress.CurrentBlock, progress.HighestBlock, targetBlocks/2, targetBlocks, targetBlocks)
 	}
 }
-func TestFakedSyncProgress62(t *testing.T)      { testFakedSyncProgress(t, 62, FullSync) }
-func TestFakedSyncProgress63Full(t *testing.T)  { testFakedSyncProgress(t, 63, FullSync) }
-func TestFakedSyncProgress63Fast(t *testing.T)  { testFakedSyncProgress(t, 63, FastSync) }
-func TestFakedSyncProgress64Full(t *testing.T)  { testFakedSyncProgress(t, 64, FullSync) }
-func TestFakedSyncProgress64Fast(t *testing.T)  { testFakedSyncProgress(t, 64, FastSync) }
-func TestFakedSyncProgress64Light(t *testing.T) { testFakedSyncProgress(t, 64, LightSync) }
+func TestFakedSyncProgress101(t *testing.T)      { testFakedSyncProgress(t, 101, FullSync) }
+func TestFakedSyncProgress102Full(t *testing.T)  { testFakedSyncProgress(t, 102, FullSync) }
+func TestFakedSyncProgress102Fast(t *testing.T)  { testFakedSyncProgress(t, 102, FastSync) }
+func TestFakedSyncProgress103Full(t *testing.T)  { testFakedSyncProgress(t, 103, FullSync) }
+func TestFakedSyncProgress103Fast(t *testing.T)  { testFakedSyncProgress(t, 103, FastSync) }
+func TestFakedSyncProgress103Light(t *testing.T) { testFakedSyncProgress(t, 103, LightSync) }
 func testFakedSyncProgress(t *testing.T, protocol int, mode SyncMode) {
 	t.Parallel()
 	tester := newTester()
@@ -1187,12 +1265,12 @@ func TestDeliverHeadersHang(t *testing.T) {
 		protocol int
 		syncMode SyncMode
 	}{
-		{62, FullSync},
-		{63, FullSync},
-		{63, FastSync},
-		{64, FullSync},
-		{64, FastSync},
-		{64, LightSync},
+		{101, FullSync},
+		{102, FullSync},
+		{102, FastSync},
+		{103, FullSync},
+		{103, FastSync},
+		{103, LightSync},
 	}
 	for _, tc := range testCases {
 		t.Run(fmt.Sprintf("protocol %d mode %v", tc.protocol, tc.syncMode), func(t *testing.T) {
@@ -16,7 +16,7 @@ const (
 	maxLackingHashes  = 4096 
 	measurementImpact = 0.1  
 	minProtocol = 101
-	maxProtocol = 102
+	maxProtocol = 105
 )
 var (
 	errAlreadyFetching   = errors.New("already fetching blocks from peer")
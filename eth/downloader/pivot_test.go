@@ -0,0 +1,167 @@
+package downloader
+import (
+	"math/big"
+	"testing"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/params"
+)
+func TestFastSyncPivot(t *testing.T) {
+	round := uint64(common.LEADER_NUMBER)
+	min := uint64(fsMinFullBlocks)
+	tests := []struct {
+		height uint64
+		pivot  uint64
+	}{
+		{0, 0},
+		{min, 0},
+		{min + 1, 0},
+		{round, 0},
+		{round + min - 1, 0},
+		{round + min, round},
+		{round + min + 1, round},
+		{2*round + min - 1, round},
+		{2*round + min, 2 * round},
+		{5*round + 100, 5 * round},
+	}
+	for i, tt := range tests {
+		pivot := fastSyncPivot(tt.height, round)
+		if pivot != tt.pivot {
+			t.Errorf("test %d: pivot mismatch for height %d: have %d, want %d", i, tt.height, pivot, tt.pivot)
+		}
+		if pivot != 0 && pivot+min > tt.height {
+			t.Errorf("test %d: pivot %d too close to height %d", i, pivot, tt.height)
+		}
+		if pivot != 0 && common.GetEndBlockNumberByRoundNumber(common.GetRoundNumberByBlockNumber(pivot, round), round) != pivot {
+			t.Errorf("test %d: pivot %d not at a round end", i, pivot)
+		}
+	}
+}
+func makeHeaderChain(n uint64) ([]*types.Header, func(common.Hash) *types.Header) {
+	headers := []*types.Header{{Number: big.NewInt(0), Time: big.NewInt(0)}}
+	index := map[common.Hash]*types.Header{headers[0].Hash(): headers[0]}
+	for i := uint64(1); i <= n; i++ {
+		header := &types.Header{ParentHash: headers[i-1].Hash(), Number: new(big.Int).SetUint64(i), Time: new(big.Int).SetUint64(i)}
+		headers = append(headers, header)
+		index[header.Hash()] = header
+	}
+	return headers, func(hash common.Hash) *types.Header { return index[hash] }
+}
+func TestRoundBase(t *testing.T) {
+	round := uint64(common.LEADER_NUMBER)
+	headers, getHeader := makeHeaderChain(3 * round)
+	tests := []struct {
+		pivot uint64
+		base  uint64
+	}{
+		{round, 0},
+		{2 * round, round},
+		{3 * round, 2 * round},
+	}
+	for i, tt := range tests {
+		base := roundBase(getHeader, headers[tt.pivot], round)
+		if base == nil {
+			t.Errorf("test %d: no base for pivot %d", i, tt.pivot)
+			continue
+		}
+		if base.Number.Uint64() != tt.base {
+			t.Errorf("test %d: base mismatch for pivot %d: have %d, want %d", i, tt.pivot, base.Number, tt.base)
+		}
+	}
+	if base := roundBase(func(common.Hash) *types.Header { return nil }, headers[2*round], round); base != nil {
+		t.Errorf("base found on a broken chain: %d", base.Number)
+	}
+}
+func TestVerifySchedule(t *testing.T) {
+	a, b := common.Address{0xa}, common.Address{0xb}
+	genesisProducers := types.Producers{{Addr: a, Vote: big.NewInt(0)}, {Addr: b, Vote: big.NewInt(0)}}
+	genesis := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0), Coinbase: a}).WithBody(nil, nil, genesisProducers, nil)
+	nextProducers := make(types.Producers, common.LEADER_LIMIT)
+	for i := range nextProducers {
+		nextProducers[i] = types.Producer{Addr: []common.Address{b, a}[i%2], Vote: big.NewInt(1)}
+	}
+	slotTime := func(slot int64) *big.Int {
+		return big.NewInt(common.GetSlotTime(slot).Unix())
+	}
+	round := int64(common.LEADER_NUMBER)
+	header := func(number int64, slot int64, coinbase common.Address, producers types.Producers) *types.Header {
+		return &types.Header{Number: big.NewInt(number), Time: slotTime(slot), Coinbase: coinbase, ProducerHash: types.CalcProducerHash(producers)}
+	}
+	var (
+		slot  = int64(1000)
+		owner = genesisProducers[slot%2].Addr
+		other = genesisProducers[(slot+1)%2].Addr
+	)
+	tests := []struct {
+		parent    *types.Header
+		header    *types.Header
+		producers types.Producers
+		err       error
+	}{
+		{header(1, slot-1, other, genesisProducers), header(2, slot, owner, genesisProducers), genesisProducers, nil},
+		{header(1, slot-1, other, genesisProducers), header(2, slot, other, genesisProducers), genesisProducers, errInvalidSchedule},
+		{header(1, slot-1, other, genesisProducers), header(2, slot, owner, nextProducers), nextProducers, errInvalidSchedule},
+		{header(1, slot-common.MINER_TIMEOUT, other, genesisProducers), header(2, slot, genesis.Coinbase(), genesisProducers), genesisProducers, nil},
+		{header(round, slot-1, other, genesisProducers), header(round+1, slot, nextProducers[slot%int64(len(nextProducers))].Addr, nextProducers), nextProducers, nil},
+		{header(round, slot-1, other, genesisProducers), header(round+1, slot, nextProducers[(slot+1)%int64(len(nextProducers))].Addr, nextProducers), nextProducers, errInvalidSchedule},
+		{header(round+1, slot-1, other, nextProducers), header(round+2, slot, nextProducers[slot%int64(len(nextProducers))].Addr, nextProducers), nextProducers, nil},
+		{header(round+1, slot-1, other, genesisProducers), header(round+2, slot, nextProducers[slot%int64(len(nextProducers))].Addr, nextProducers), nextProducers, errInvalidSchedule},
+		{header(round, slot-1, other, genesisProducers), header(round+1, slot, owner, nil), nil, errInvalidSchedule},
+		{header(round, slot-1, other, genesisProducers), header(round+1, slot, owner, genesisProducers), genesisProducers, errInvalidSchedule},
+		{header(round, slot-1, other, genesisProducers), header(round+1, slot, nextProducers[slot%int64(len(nextProducers))].Addr, genesisProducers), nextProducers, errInvalidSchedule},
+	}
+	for i, tt := range tests {
+		if err := verifySchedule(params.MainnetChainConfig, genesis, tt.parent, tt.header, tt.producers); err != tt.err {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+	parent, fallback := header(1, slot+1-common.MINER_TIMEOUT, other, genesisProducers), header(2, slot+1, genesis.Coinbase(), genesisProducers)
+	if err := verifySchedule(params.MainnetChainConfig, genesis, parent, fallback, genesisProducers); err != nil {
+		t.Errorf("genesis coinbase fallback before producer cycle fork: have %v, want nil", err)
+	}
+	if err := verifySchedule(params.TestChainConfig, genesis, parent, fallback, genesisProducers); err != errInvalidSchedule {
+		t.Errorf("genesis coinbase fallback after producer cycle fork: have %v, want %v", err, errInvalidSchedule)
+	}
+}
+func TestVerifyCheckpoint(t *testing.T) {
+	headers, _ := makeHeaderChain(10)
+	tests := []struct {
+		checkpoint *types.Checkpoint
+		err        error
+	}{
+		{nil, nil},
+		{&types.Checkpoint{Number: 5, BlockHash: headers[5].Hash()}, nil},
+		{&types.Checkpoint{Number: 5, BlockHash: headers[4].Hash()}, errCheckpointMismatch},
+		{&types.Checkpoint{Number: 20, BlockHash: headers[4].Hash()}, nil},
+	}
+	for i, tt := range tests {
+		if err := verifyCheckpoint(tt.checkpoint, headers); err != tt.err {
+			t.Errorf("test %d: error mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
+func TestCheckpointAnchor(t *testing.T) {
+	round := uint64(common.LEADER_NUMBER)
+	min := uint64(fsMinFullBlocks)
+	checkpoint := &types.Checkpoint{Number: 2 * round, Td: big.NewInt(100)}
+	tests := []struct {
+		mode       SyncMode
+		checkpoint *types.Checkpoint
+		height     uint64
+		anchor     bool
+	}{
+		{LightSync, nil, 10 * round, false},
+		{LightSync, &types.Checkpoint{Number: 2 * round}, 10 * round, false},
+		{LightSync, checkpoint, 2 * round, false},
+		{LightSync, checkpoint, 2*round + 1, true},
+		{FullSync, checkpoint, 10 * round, false},
+		{FastSync, checkpoint, 4*round + min - 1, false},
+		{FastSync, checkpoint, 4*round + min, true},
+		{FastSync, checkpoint, 10 * round, true},
+	}
+	for i, tt := range tests {
+		if anchor := checkpointAnchor(tt.mode, tt.checkpoint, tt.height, round); anchor != tt.anchor {
+			t.Errorf("test %d: anchor mismatch for height %d: have %v, want %v", i, tt.height, anchor, tt.anchor)
+		}
+	}
+}
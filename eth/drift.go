@@ -0,0 +1,135 @@
+package eth
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/common/mclock"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/log"
+	"github.com/DEL-ORG/del/metrics"
+)
+const (
+	driftSamples     = 32
+	driftMinSamples  = 3
+	driftThreshold   = time.Second
+	driftMaxSample   = 30 * time.Second
+	driftWarnTimeout = time.Minute
+	driftPending     = 64
+)
+var clockDriftGauge = metrics.NewGauge("eth/clock/drift")
+type durationSlice []time.Duration
+func (s durationSlice) Len() int           { return len(s) }
+func (s durationSlice) Less(i, j int) bool { return s[i] < s[j] }
+func (s durationSlice) Swap(i, j int)      { s[i], s[j] = s[j], s[i] }
+type driftArrival struct {
+	number   uint64
+	arrivals map[string]time.Time
+}
+type driftTracker struct {
+	clock    mclock.Clock
+	pending  map[common.Hash]*driftArrival
+	samples  map[string]time.Duration
+	lastWarn time.Time
+	lock     sync.RWMutex
+}
+func newDriftTracker(clock mclock.Clock) *driftTracker {
+	return &driftTracker{
+		clock:   clock,
+		pending: make(map[common.Hash]*driftArrival),
+		samples: make(map[string]time.Duration),
+	}
+}
+func (d *driftTracker) arrive(peer string, header *types.Header, arrival time.Time) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	hash := header.Hash()
+	entry, ok := d.pending[hash]
+	if !ok {
+		if len(d.pending) >= driftPending {
+			var (
+				oldest common.Hash
+				number = ^uint64(0)
+			)
+			for h, e := range d.pending {
+				if e.number < number {
+					oldest, number = h, e.number
+				}
+			}
+			delete(d.pending, oldest)
+		}
+		entry = &driftArrival{number: header.Number.Uint64(), arrivals: make(map[string]time.Time)}
+		d.pending[hash] = entry
+	}
+	if _, ok := entry.arrivals[peer]; !ok {
+		entry.arrivals[peer] = arrival
+	}
+}
+func (d *driftTracker) imported(header *types.Header) {
+	d.lock.Lock()
+	hash := header.Hash()
+	entry, ok := d.pending[hash]
+	delete(d.pending, hash)
+	d.lock.Unlock()
+	if !ok {
+		return
+	}
+	slot := time.Unix(header.Time.Int64(), 0)
+	for peer, arrival := range entry.arrivals {
+		d.add(peer, arrival.Sub(slot))
+	}
+}
+func (d *driftTracker) removePeer(peer string) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	delete(d.samples, peer)
+	for _, entry := range d.pending {
+		delete(entry.arrivals, peer)
+	}
+}
+func (d *driftTracker) add(peer string, sample time.Duration) {
+	if sample < -driftMaxSample || sample > driftMaxSample {
+		return
+	}
+	d.lock.Lock()
+	if _, ok := d.samples[peer]; !ok && len(d.samples) >= driftSamples {
+		d.lock.Unlock()
+		return
+	}
+	d.samples[peer] = sample
+	drift := d.drift()
+	now := d.clock.Now()
+	warn := (drift < -driftThreshold || drift > driftThreshold) && now.Sub(d.lastWarn) > driftWarnTimeout
+	if warn {
+		d.lastWarn = now
+	}
+	d.lock.Unlock()
+	clockDriftGauge.Update(int64(drift / time.Millisecond))
+	if warn {
+		warning := fmt.Sprintf("System clock seems off by %v from the network, which can make block production miss its slots", drift)
+		howtofix := fmt.Sprintf("Please enable network time synchronisation in system settings")
+		separator := strings.Repeat("-", len(warning))
+		log.Warn(separator)
+		log.Warn(warning)
+		log.Warn(howtofix)
+		log.Warn(separator)
+	}
+}
+func (d *driftTracker) Drift() time.Duration {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	return d.drift()
+}
+func (d *driftTracker) drift() time.Duration {
+	if len(d.samples) < driftMinSamples {
+		return 0
+	}
+	sorted := make([]time.Duration, 0, len(d.samples))
+	for _, sample := range d.samples {
+		sorted = append(sorted, sample)
+	}
+	sort.Sort(durationSlice(sorted))
+	return sorted[len(sorted)/2]
+}
@@ -0,0 +1,93 @@
+package eth
+import (
+	"math/big"
+	"testing"
+	"time"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/common/mclock"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/p2p"
+	"github.com/DEL-ORG/del/p2p/discover"
+)
+func TestDriftTracker(t *testing.T) {
+	tracker := newDriftTracker(mclock.NewSimulated(time.Unix(common.GENESIS_TIME, 0)))
+	tracker.add("a", 3*time.Second)
+	tracker.add("b", 4*time.Second)
+	if drift := tracker.Drift(); drift != 0 {
+		t.Fatalf("drift reported before enough samples: %v", drift)
+	}
+	tracker.add("c", time.Hour)
+	tracker.add("c", 2*time.Second)
+	if drift := tracker.Drift(); drift != 3*time.Second {
+		t.Fatalf("drift mismatch: have %v, want %v", drift, 3*time.Second)
+	}
+	for i := 0; i < driftSamples; i++ {
+		tracker.add("d", -time.Second)
+	}
+	if drift := tracker.Drift(); drift != 3*time.Second {
+		t.Fatalf("single peer outweighed others: have %v, want %v", drift, 3*time.Second)
+	}
+	tracker.removePeer("a")
+	if drift := tracker.Drift(); drift != 2*time.Second {
+		t.Fatalf("drift mismatch after peer removal: have %v, want %v", drift, 2*time.Second)
+	}
+}
+func TestDriftBlockArrival(t *testing.T) {
+	tracker := newDriftTracker(mclock.NewSimulated(time.Unix(common.GENESIS_TIME, 0)))
+	slot := time.Unix(common.GENESIS_TIME, 0)
+	var headers []*types.Header
+	for i := 1; i <= driftMinSamples; i++ {
+		header := &types.Header{Number: big.NewInt(int64(i)), Time: big.NewInt(slot.Unix())}
+		peer := string('a' + byte(i))
+		tracker.arrive(peer, header, slot.Add(3*time.Second))
+		tracker.arrive(peer, header, slot.Add(time.Minute))
+		headers = append(headers, header)
+	}
+	if drift := tracker.Drift(); drift != 0 {
+		t.Fatalf("drift sampled from unverified blocks: %v", drift)
+	}
+	for _, header := range headers {
+		tracker.imported(header)
+	}
+	if drift := tracker.Drift(); drift != 3*time.Second {
+		t.Fatalf("drift mismatch: have %v, want %v", drift, 3*time.Second)
+	}
+	if len(tracker.pending) != 0 {
+		t.Fatalf("pending arrivals retained after import: %d", len(tracker.pending))
+	}
+	for i := 0; i < 2*driftPending; i++ {
+		tracker.arrive("x", &types.Header{Number: big.NewInt(int64(i)), Time: big.NewInt(slot.Unix())}, slot)
+	}
+	if len(tracker.pending) != driftPending {
+		t.Fatalf("pending arrivals unbounded: have %d, want %d", len(tracker.pending), driftPending)
+	}
+}
+func TestHandshakeClockOffset(t *testing.T) {
+	var (
+		start   = time.Unix(common.GENESIS_TIME, 0)
+		app, net = p2p.MsgPipe()
+		local   = newPeer(eth103, p2p.NewPeer(discover.NodeID{1}, "local", nil), app)
+		remote  = newPeer(eth103, p2p.NewPeer(discover.NodeID{2}, "remote", nil), net)
+		genesis = common.Hash{0x01}
+		errc    = make(chan error, 2)
+	)
+	defer app.Close()
+	local.clock = mclock.NewSimulated(start)
+	remote.clock = mclock.NewSimulated(start.Add(3 * time.Second))
+	for _, p := range []*peer{local, remote} {
+		go func(p *peer) {
+			errc <- p.Handshake(DefaultConfig.NetworkId, big.NewInt(1), common.Hash{}, genesis)
+		}(p)
+	}
+	for i := 0; i < 2; i++ {
+		if err := <-errc; err != nil {
+			t.Fatalf("handshake failed: %v", err)
+		}
+	}
+	if offset, ok := local.ClockOffset(); !ok || offset != 3*time.Second {
+		t.Errorf("local offset mismatch: have (%v, %v), want (%v, true)", offset, ok, 3*time.Second)
+	}
+	if offset, ok := remote.ClockOffset(); !ok || offset != -3*time.Second {
+		t.Errorf("remote offset mismatch: have (%v, %v), want (%v, true)", offset, ok, -3*time.Second)
+	}
+}
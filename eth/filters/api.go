@@ -10,6 +10,7 @@ import (
 	ethereum "github.com/DEL-ORG/del"
 	"github.com/DEL-ORG/del/common"
 	"github.com/DEL-ORG/del/common/hexutil"
+	"github.com/DEL-ORG/del/core"
 	"github.com/DEL-ORG/del/core/types"
 	"github.com/DEL-ORG/del/ethdb"
 	"github.com/DEL-ORG/del/event"
@@ -196,6 +197,104 @@ func (api *PublicFilterAPI) Logs(ctx context.Context, crit FilterCriteria) (*rpc
 	}()
 	return rpcSub, nil
 }
+type RoundNotification struct {
+	Round     uint64          `json:"round"`
+	Number    uint64          `json:"number"`
+	Hash      common.Hash     `json:"hash"`
+	Producers types.Producers `json:"producers"`
+}
+func (api *PublicFilterAPI) NewRound(ctx context.Context) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	rpcSub := notifier.CreateSubscription()
+	go func() {
+		rounds := make(chan core.NewRoundEvent)
+		roundsSub := api.events.SubscribeNewRound(rounds)
+		for {
+			select {
+			case r := <-rounds:
+				notifier.Notify(rpcSub.ID, &RoundNotification{
+					Round:     r.Round,
+					Number:    r.Block.NumberU64(),
+					Hash:      r.Block.Hash(),
+					Producers: r.Producers,
+				})
+			case <-rpcSub.Err():
+				roundsSub.Unsubscribe()
+				return
+			case <-notifier.Closed():
+				roundsSub.Unsubscribe()
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+type VoteCriteria struct {
+	Voters    []common.Address `json:"voters"`
+	Producers []common.Address `json:"producers"`
+}
+func (api *PublicFilterAPI) Votes(ctx context.Context, crit VoteCriteria) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	var (
+		rpcSub       = notifier.CreateSubscription()
+		matchedVotes = make(chan []types.OutputVote)
+	)
+	votesSub := api.events.SubscribeVotes(crit, matchedVotes)
+	go func() {
+		for {
+			select {
+			case votes := <-matchedVotes:
+				for _, vote := range votes {
+					notifier.Notify(rpcSub.ID, vote)
+				}
+			case <-rpcSub.Err():
+				votesSub.Unsubscribe()
+				return
+			case <-notifier.Closed():
+				votesSub.Unsubscribe()
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
+type TextCriteria struct {
+	Recipients []common.Address `json:"recipients"`
+}
+func (api *PublicFilterAPI) Texts(ctx context.Context, crit TextCriteria) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return &rpc.Subscription{}, rpc.ErrNotificationsUnsupported
+	}
+	var (
+		rpcSub       = notifier.CreateSubscription()
+		matchedTexts = make(chan []types.OutputText)
+	)
+	textsSub := api.events.SubscribeTexts(crit, matchedTexts)
+	go func() {
+		for {
+			select {
+			case texts := <-matchedTexts:
+				for _, text := range texts {
+					notifier.Notify(rpcSub.ID, text)
+				}
+			case <-rpcSub.Err():
+				textsSub.Unsubscribe()
+				return
+			case <-notifier.Closed():
+				textsSub.Unsubscribe()
+				return
+			}
+		}
+	}()
+	return rpcSub, nil
+}
 type FilterCriteria struct {
 	FromBlock *big.Int
 	ToBlock   *big.Int
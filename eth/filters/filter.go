@@ -19,6 +19,9 @@ type Backend interface {
 	SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription
 	SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription
 	SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription
+	SubscribeNewRoundEvent(ch chan<- core.NewRoundEvent) event.Subscription
+	SubscribeVotesEvent(ch chan<- core.VotesEvent) event.Subscription
+	SubscribeTextsEvent(ch chan<- core.TextsEvent) event.Subscription
 	BloomStatus() (uint64, uint64)
 	ServiceFilter(ctx context.Context, session *bloombits.MatcherSession)
 }
@@ -222,3 +225,26 @@ func bloomFilter(bloom types.Bloom, addresses []common.Address, topics [][]commo
 	}
 	return true
 }
+func filterVotes(votes []types.OutputVote, crit VoteCriteria) []types.OutputVote {
+	var ret []types.OutputVote
+	for _, vote := range votes {
+		if len(crit.Voters) > 0 && !includes(crit.Voters, vote.From) {
+			continue
+		}
+		if len(crit.Producers) > 0 && !includes(crit.Producers, vote.To) {
+			continue
+		}
+		ret = append(ret, vote)
+	}
+	return ret
+}
+func filterTexts(texts []types.OutputText, crit TextCriteria) []types.OutputText {
+	var ret []types.OutputText
+	for _, text := range texts {
+		if len(crit.Recipients) > 0 && !includes(crit.Recipients, text.To) {
+			continue
+		}
+		ret = append(ret, text)
+	}
+	return ret
+}
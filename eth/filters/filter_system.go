@@ -20,6 +20,9 @@ const (
 	MinedAndPendingLogsSubscription
 	PendingTransactionsSubscription
 	BlocksSubscription
+	NewRoundSubscription
+	VotesSubscription
+	TextsSubscription
 	LastIndexSubscription
 )
 const (
@@ -27,6 +30,9 @@ const (
 	rmLogsChanSize = 10
 	logsChanSize = 10
 	chainEvChanSize = 10
+	roundChanSize = 10
+	votesChanSize = 10
+	textsChanSize = 10
 )
 var (
 	ErrInvalidSubscriptionID = errors.New("invalid id")
@@ -39,6 +45,11 @@ type subscription struct {
 	logs      chan []*types.Log
 	hashes    chan common.Hash
 	headers   chan *types.Header
+	voteCrit  VoteCriteria
+	textCrit  TextCriteria
+	rounds    chan core.NewRoundEvent
+	votes     chan []types.OutputVote
+	texts     chan []types.OutputText
 	installed chan struct{} 
 	err       chan error    
 }
@@ -80,6 +91,9 @@ func (sub *Subscription) Unsubscribe() {
 			case <-sub.f.logs:
 			case <-sub.f.hashes:
 			case <-sub.f.headers:
+			case <-sub.f.rounds:
+			case <-sub.f.votes:
+			case <-sub.f.texts:
 			}
 		}
 		<-sub.Err()
@@ -187,6 +201,56 @@ func (es *EventSystem) SubscribePendingTxEvents(hashes chan common.Hash) *Subscr
 	}
 	return es.subscribe(sub)
 }
+func (es *EventSystem) SubscribeNewRound(rounds chan core.NewRoundEvent) *Subscription {
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       NewRoundSubscription,
+		created:   time.Now(),
+		logs:      make(chan []*types.Log),
+		hashes:    make(chan common.Hash),
+		headers:   make(chan *types.Header),
+		rounds:    rounds,
+		votes:     make(chan []types.OutputVote),
+		texts:     make(chan []types.OutputText),
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+	return es.subscribe(sub)
+}
+func (es *EventSystem) SubscribeVotes(crit VoteCriteria, votes chan []types.OutputVote) *Subscription {
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       VotesSubscription,
+		created:   time.Now(),
+		voteCrit:  crit,
+		logs:      make(chan []*types.Log),
+		hashes:    make(chan common.Hash),
+		headers:   make(chan *types.Header),
+		rounds:    make(chan core.NewRoundEvent),
+		votes:     votes,
+		texts:     make(chan []types.OutputText),
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+	return es.subscribe(sub)
+}
+func (es *EventSystem) SubscribeTexts(crit TextCriteria, texts chan []types.OutputText) *Subscription {
+	sub := &subscription{
+		id:        rpc.NewID(),
+		typ:       TextsSubscription,
+		created:   time.Now(),
+		textCrit:  crit,
+		logs:      make(chan []*types.Log),
+		hashes:    make(chan common.Hash),
+		headers:   make(chan *types.Header),
+		rounds:    make(chan core.NewRoundEvent),
+		votes:     make(chan []types.OutputVote),
+		texts:     texts,
+		installed: make(chan struct{}),
+		err:       make(chan error),
+	}
+	return es.subscribe(sub)
+}
 type filterIndex map[Type]map[rpc.ID]*subscription
 func (es *EventSystem) broadcast(filters filterIndex, ev interface{}) {
 	if ev == nil {
@@ -222,6 +286,22 @@ func (es *EventSystem) broadcast(filters filterIndex, ev interface{}) {
 		for _, f := range filters[PendingTransactionsSubscription] {
 			f.hashes <- e.Tx.Hash()
 		}
+	case core.NewRoundEvent:
+		for _, f := range filters[NewRoundSubscription] {
+			f.rounds <- e
+		}
+	case core.VotesEvent:
+		for _, f := range filters[VotesSubscription] {
+			if matchedVotes := filterVotes(e.Votes, f.voteCrit); len(matchedVotes) > 0 {
+				f.votes <- matchedVotes
+			}
+		}
+	case core.TextsEvent:
+		for _, f := range filters[TextsSubscription] {
+			if matchedTexts := filterTexts(e.Texts, f.textCrit); len(matchedTexts) > 0 {
+				f.texts <- matchedTexts
+			}
+		}
 	case core.ChainEvent:
 		for _, f := range filters[BlocksSubscription] {
 			f.headers <- e.Block.Header()
@@ -298,12 +378,21 @@ func (es *EventSystem) eventLoop() {
 		logsSub = es.backend.SubscribeLogsEvent(logsCh)
 		chainEvCh  = make(chan core.ChainEvent, chainEvChanSize)
 		chainEvSub = es.backend.SubscribeChainEvent(chainEvCh)
+		roundCh  = make(chan core.NewRoundEvent, roundChanSize)
+		roundSub = es.backend.SubscribeNewRoundEvent(roundCh)
+		votesCh  = make(chan core.VotesEvent, votesChanSize)
+		votesSub = es.backend.SubscribeVotesEvent(votesCh)
+		textsCh  = make(chan core.TextsEvent, textsChanSize)
+		textsSub = es.backend.SubscribeTextsEvent(textsCh)
 	)
 	defer sub.Unsubscribe()
 	defer txSub.Unsubscribe()
 	defer rmLogsSub.Unsubscribe()
 	defer logsSub.Unsubscribe()
 	defer chainEvSub.Unsubscribe()
+	defer roundSub.Unsubscribe()
+	defer votesSub.Unsubscribe()
+	defer textsSub.Unsubscribe()
 	for i := UnknownSubscription; i < LastIndexSubscription; i++ {
 		index[i] = make(map[rpc.ID]*subscription)
 	}
@@ -322,6 +411,12 @@ func (es *EventSystem) eventLoop() {
 			es.broadcast(index, ev)
 		case ev := <-chainEvCh:
 			es.broadcast(index, ev)
+		case ev := <-roundCh:
+			es.broadcast(index, ev)
+		case ev := <-votesCh:
+			es.broadcast(index, ev)
+		case ev := <-textsCh:
+			es.broadcast(index, ev)
 		case f := <-es.install:
 			if f.typ == MinedAndPendingLogsSubscription {
 				index[LogsSubscription][f.id] = f
@@ -346,6 +441,12 @@ func (es *EventSystem) eventLoop() {
 			return
 		case <-chainEvSub.Err():
 			return
+		case <-roundSub.Err():
+			return
+		case <-votesSub.Err():
+			return
+		case <-textsSub.Err():
+			return
 		}
 	}
 }
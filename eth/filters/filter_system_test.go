@@ -26,6 +26,9 @@ type testBackend struct {
 	rmLogsFeed *event.Feed
 	logsFeed   *event.Feed
 	chainFeed  *event.Feed
+	roundFeed  *event.Feed
+	votesFeed  *event.Feed
+	textsFeed  *event.Feed
 }
 func (b *testBackend) ChainDb() ethdb.Database {
 	return b.db
@@ -61,6 +64,15 @@ func (b *testBackend) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscript
 func (b *testBackend) SubscribeChainEvent(ch chan<- core.ChainEvent) event.Subscription {
 	return b.chainFeed.Subscribe(ch)
 }
+func (b *testBackend) SubscribeNewRoundEvent(ch chan<- core.NewRoundEvent) event.Subscription {
+	return b.roundFeed.Subscribe(ch)
+}
+func (b *testBackend) SubscribeVotesEvent(ch chan<- core.VotesEvent) event.Subscription {
+	return b.votesFeed.Subscribe(ch)
+}
+func (b *testBackend) SubscribeTextsEvent(ch chan<- core.TextsEvent) event.Subscription {
+	return b.textsFeed.Subscribe(ch)
+}
 func (b *testBackend) BloomStatus() (uint64, uint64) {
 	return params.BloomBitsBlocks, b.sections
 }
@@ -95,7 +107,7 @@ func TestBlockSubscription(t *testing.T) {
 		rmLogsFeed  = new(event.Feed)
 		logsFeed    = new(event.Feed)
 		chainFeed   = new(event.Feed)
-		backend     = &testBackend{mux, db, 0, txFeed, rmLogsFeed, logsFeed, chainFeed}
+		backend     = &testBackend{mux, db, 0, txFeed, rmLogsFeed, logsFeed, chainFeed, new(event.Feed), new(event.Feed), new(event.Feed)}
 		api         = NewPublicFilterAPI(backend, false)
 		genesis     = new(core.Genesis).MustCommit(db)
 		chain, _    = core.GenerateChain(params.TestChainConfig, genesis, ethash.NewFaker(), db, 10, func(i int, gen *core.BlockGen) {})
@@ -134,6 +146,127 @@ func TestBlockSubscription(t *testing.T) {
 	<-sub0.Err()
 	<-sub1.Err()
 }
+func TestVotesSubscription(t *testing.T) {
+	t.Parallel()
+	var (
+		mux        = new(event.TypeMux)
+		db, _      = ethdb.NewMemDatabase()
+		votesFeed  = new(event.Feed)
+		backend    = &testBackend{mux, db, 0, new(event.Feed), new(event.Feed), new(event.Feed), new(event.Feed), new(event.Feed), votesFeed, new(event.Feed)}
+		api        = NewPublicFilterAPI(backend, false)
+		voter      = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		producer1  = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		producer2  = common.HexToAddress("0x3333333333333333333333333333333333333333")
+		votes      = []types.OutputVote{
+			{From: voter, To: producer1, Amount: big.NewInt(1)},
+			{From: voter, To: producer2, Amount: big.NewInt(2)},
+			{From: producer1, To: producer2, Amount: big.NewInt(3)},
+		}
+	)
+	allCh := make(chan []types.OutputVote)
+	allSub := api.events.SubscribeVotes(VoteCriteria{}, allCh)
+	prodCh := make(chan []types.OutputVote)
+	prodSub := api.events.SubscribeVotes(VoteCriteria{Voters: []common.Address{voter}, Producers: []common.Address{producer2}}, prodCh)
+	time.Sleep(1 * time.Second)
+	votesFeed.Send(core.VotesEvent{Votes: votes})
+	timeout := time.After(1 * time.Second)
+	for i := 0; i < 2; i++ {
+		select {
+		case got := <-allCh:
+			if !reflect.DeepEqual(got, votes) {
+				t.Errorf("unfiltered subscription received %v, want %v", got, votes)
+			}
+		case got := <-prodCh:
+			if len(got) != 1 || !reflect.DeepEqual(got[0], votes[1]) {
+				t.Errorf("filtered subscription received %v, want %v", got, votes[1:2])
+			}
+		case <-timeout:
+			t.Fatal("timeout waiting for vote notifications")
+		}
+	}
+	allSub.Unsubscribe()
+	prodSub.Unsubscribe()
+}
+func TestNewRoundSubscription(t *testing.T) {
+	t.Parallel()
+	var (
+		mux       = new(event.TypeMux)
+		db, _     = ethdb.NewMemDatabase()
+		roundFeed = new(event.Feed)
+		backend   = &testBackend{mux, db, 0, new(event.Feed), new(event.Feed), new(event.Feed), new(event.Feed), roundFeed, new(event.Feed), new(event.Feed)}
+		api       = NewPublicFilterAPI(backend, false)
+		producer  = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		rounds    = []core.NewRoundEvent{
+			{Round: 1, Producers: types.Producers{{Addr: producer, Vote: big.NewInt(1)}}},
+			{Round: 2, Producers: types.Producers{{Addr: producer, Vote: big.NewInt(2)}}},
+		}
+	)
+	ch0 := make(chan core.NewRoundEvent)
+	sub0 := api.events.SubscribeNewRound(ch0)
+	ch1 := make(chan core.NewRoundEvent)
+	sub1 := api.events.SubscribeNewRound(ch1)
+	time.Sleep(1 * time.Second)
+	go func() {
+		for _, round := range rounds {
+			roundFeed.Send(round)
+		}
+	}()
+	timeout := time.After(1 * time.Second)
+	for i := 0; i < 2*len(rounds); i++ {
+		var got core.NewRoundEvent
+		select {
+		case got = <-ch0:
+		case got = <-ch1:
+		case <-timeout:
+			t.Fatalf("test %d: timeout waiting for round notification", i)
+		}
+		if want := rounds[i/2]; got.Round != want.Round || !reflect.DeepEqual(got.Producers, want.Producers) {
+			t.Errorf("test %d: received round %v, want %v", i, got, want)
+		}
+	}
+	sub0.Unsubscribe()
+	sub1.Unsubscribe()
+}
+func TestTextsSubscription(t *testing.T) {
+	t.Parallel()
+	var (
+		mux       = new(event.TypeMux)
+		db, _     = ethdb.NewMemDatabase()
+		textsFeed = new(event.Feed)
+		backend   = &testBackend{mux, db, 0, new(event.Feed), new(event.Feed), new(event.Feed), new(event.Feed), new(event.Feed), new(event.Feed), textsFeed}
+		api       = NewPublicFilterAPI(backend, false)
+		sender    = common.HexToAddress("0x1111111111111111111111111111111111111111")
+		alice     = common.HexToAddress("0x2222222222222222222222222222222222222222")
+		bob       = common.HexToAddress("0x3333333333333333333333333333333333333333")
+		texts     = []types.OutputText{
+			{From: sender, To: alice, Text: "hello alice", Price: big.NewInt(1)},
+			{From: sender, To: bob, Text: "hello bob", Price: big.NewInt(2)},
+		}
+	)
+	allCh := make(chan []types.OutputText)
+	allSub := api.events.SubscribeTexts(TextCriteria{}, allCh)
+	bobCh := make(chan []types.OutputText)
+	bobSub := api.events.SubscribeTexts(TextCriteria{Recipients: []common.Address{bob}}, bobCh)
+	time.Sleep(1 * time.Second)
+	textsFeed.Send(core.TextsEvent{Texts: texts})
+	timeout := time.After(1 * time.Second)
+	for i := 0; i < 2; i++ {
+		select {
+		case got := <-allCh:
+			if !reflect.DeepEqual(got, texts) {
+				t.Errorf("unfiltered subscription received %v, want %v", got, texts)
+			}
+		case got := <-bobCh:
+			if len(got) != 1 || !reflect.DeepEqual(got[0], texts[1]) {
+				t.Errorf("filtered subscription received %v, want %v", got, texts[1:2])
+			}
+		case <-timeout:
+			t.Fatal("timeout waiting for text notifications")
+		}
+	}
+	allSub.Unsubscribe()
+	bobSub.Unsubscribe()
+}
 func TestPendingTxFilter(t *testing.T) {
 	t.Parallel()
 	var (
@@ -143,7 +276,7 @@ func TestPendingTxFilter(t *testing.T) {
 		rmLogsFeed = new(event.Feed)
 		logsFeed   = new(event.Feed)
 		chainFeed  = new(event.Feed)
-		backend    = &testBackend{mux, db, 0, txFeed, rmLogsFeed, logsFeed, chainFeed}
+		backend    = &testBackend{mux, db, 0, txFeed, rmLogsFeed, logsFeed, chainFeed, new(event.Feed), new(event.Feed), new(event.Feed)}
 		api        = NewPublicFilterAPI(backend, false)
 		transactions = []*types.Transaction{
 			types.NewTransaction(0, common.HexToAddress("0xb794f5ea0ba39494ce83a213fffba74279579268"), new(big.Int), 0, new(big.Int), nil),
@@ -194,7 +327,7 @@ func TestLogFilterCreation(t *testing.T) {
 		rmLogsFeed = new(event.Feed)
 		logsFeed   = new(event.Feed)
 		chainFeed  = new(event.Feed)
-		backend    = &testBackend{mux, db, 0, txFeed, rmLogsFeed, logsFeed, chainFeed}
+		backend    = &testBackend{mux, db, 0, txFeed, rmLogsFeed, logsFeed, chainFeed, new(event.Feed), new(event.Feed), new(event.Feed)}
 		api        = NewPublicFilterAPI(backend, false)
 		testCases = []struct {
 			crit    FilterCriteria
@@ -229,7 +362,7 @@ func TestInvalidLogFilterCreation(t *testing.T) {
 		rmLogsFeed = new(event.Feed)
 		logsFeed   = new(event.Feed)
 		chainFeed  = new(event.Feed)
-		backend    = &testBackend{mux, db, 0, txFeed, rmLogsFeed, logsFeed, chainFeed}
+		backend    = &testBackend{mux, db, 0, txFeed, rmLogsFeed, logsFeed, chainFeed, new(event.Feed), new(event.Feed), new(event.Feed)}
 		api        = NewPublicFilterAPI(backend, false)
 	)
 	testCases := []FilterCriteria{
@@ -252,7 +385,7 @@ func TestLogFilter(t *testing.T) {
 		rmLogsFeed = new(event.Feed)
 		logsFeed   = new(event.Feed)
 		chainFeed  = new(event.Feed)
-		backend    = &testBackend{mux, db, 0, txFeed, rmLogsFeed, logsFeed, chainFeed}
+		backend    = &testBackend{mux, db, 0, txFeed, rmLogsFeed, logsFeed, chainFeed, new(event.Feed), new(event.Feed), new(event.Feed)}
 		api        = NewPublicFilterAPI(backend, false)
 		firstAddr      = common.HexToAddress("0x1111111111111111111111111111111111111111")
 		secondAddr     = common.HexToAddress("0x2222222222222222222222222222222222222222")
@@ -340,7 +473,7 @@ func TestPendingLogsSubscription(t *testing.T) {
 		rmLogsFeed = new(event.Feed)
 		logsFeed   = new(event.Feed)
 		chainFeed  = new(event.Feed)
-		backend    = &testBackend{mux, db, 0, txFeed, rmLogsFeed, logsFeed, chainFeed}
+		backend    = &testBackend{mux, db, 0, txFeed, rmLogsFeed, logsFeed, chainFeed, new(event.Feed), new(event.Feed), new(event.Feed)}
 		api        = NewPublicFilterAPI(backend, false)
 		firstAddr      = common.HexToAddress("0x1111111111111111111111111111111111111111")
 		secondAddr     = common.HexToAddress("0x2222222222222222222222222222222222222222")
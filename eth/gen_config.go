@@ -5,6 +5,7 @@ import (
 	"github.com/DEL-ORG/del/common/hexutil"
 	"github.com/DEL-ORG/del/consensus/ethash"
 	"github.com/DEL-ORG/del/core"
+	"github.com/DEL-ORG/del/core/types"
 	"github.com/DEL-ORG/del/eth/downloader"
 	"github.com/DEL-ORG/del/eth/gasprice"
 )
@@ -14,6 +15,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 		Genesis                 *core.Genesis `toml:",omitempty"`
 		NetworkId               uint64
 		SyncMode                downloader.SyncMode
+		Checkpoint              *types.Checkpoint `toml:",omitempty"`
 		LightServ               int  `toml:",omitempty"`
 		LightPeers              int  `toml:",omitempty"`
 		SkipBcVersionCheck      bool `toml:"-"`
@@ -33,6 +35,7 @@ func (c Config) MarshalTOML() (interface{}, error) {
 	enc.Genesis = c.Genesis
 	enc.NetworkId = c.NetworkId
 	enc.SyncMode = c.SyncMode
+	enc.Checkpoint = c.Checkpoint
 	enc.LightServ = c.LightServ
 	enc.LightPeers = c.LightPeers
 	enc.SkipBcVersionCheck = c.SkipBcVersionCheck
@@ -54,6 +57,7 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 		Genesis                 *core.Genesis `toml:",omitempty"`
 		NetworkId               *uint64
 		SyncMode                *downloader.SyncMode
+		Checkpoint              *types.Checkpoint `toml:",omitempty"`
 		LightServ               *int  `toml:",omitempty"`
 		LightPeers              *int  `toml:",omitempty"`
 		SkipBcVersionCheck      *bool `toml:"-"`
@@ -82,6 +86,9 @@ func (c *Config) UnmarshalTOML(unmarshal func(interface{}) error) error {
 	if dec.SyncMode != nil {
 		c.SyncMode = *dec.SyncMode
 	}
+	if dec.Checkpoint != nil {
+		c.Checkpoint = dec.Checkpoint
+	}
 	if dec.LightServ != nil {
 		c.LightServ = *dec.LightServ
 	}
@@ -9,6 +9,7 @@ import (
 	"sync/atomic"
 	"time"
 	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/common/mclock"
 	"github.com/DEL-ORG/del/consensus"
 	"github.com/DEL-ORG/del/core"
 	"github.com/DEL-ORG/del/core/types"
@@ -41,10 +42,15 @@ type ProtocolManager struct {
 	txpool      txPool
 	blockchain  *core.BlockChain
 	chainconfig *params.ChainConfig
+	chaindb     ethdb.Database
 	maxPeers    int
 	downloader *downloader.Downloader
 	fetcher    *fetcher.Fetcher
 	peers      *peerSet
+	checkpoint     *types.Checkpoint
+	checkpointLock sync.Mutex
+	producerProof  *producerProof
+	producerLock   sync.RWMutex
 	SubProtocols []p2p.Protocol
 	eventMux      *event.TypeMux
 	txCh          chan core.TxPreEvent
@@ -54,20 +60,25 @@ type ProtocolManager struct {
 	txsyncCh    chan *txsync
 	quitSync    chan struct{}
 	noMorePeers chan struct{}
+	clock mclock.Clock
+	drift *driftTracker
 	wg sync.WaitGroup
 }
-func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, networkId uint64, mux *event.TypeMux, txpool txPool, engine consensus.Engine, blockchain *core.BlockChain, chaindb ethdb.Database) (*ProtocolManager, error) {
+func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, networkId uint64, mux *event.TypeMux, txpool txPool, engine consensus.Engine, blockchain *core.BlockChain, chaindb ethdb.Database, clock mclock.Clock) (*ProtocolManager, error) {
 	manager := &ProtocolManager{
 		networkId:   networkId,
 		eventMux:    mux,
 		txpool:      txpool,
 		blockchain:  blockchain,
 		chainconfig: config,
+		chaindb:     chaindb,
 		peers:       newPeerSet(),
 		newPeerCh:   make(chan *peer),
 		noMorePeers: make(chan struct{}),
 		txsyncCh:    make(chan *txsync),
 		quitSync:    make(chan struct{}),
+		clock:       clock,
+		drift:       newDriftTracker(clock),
 	}
 	if mode == downloader.FastSync && blockchain.CurrentBlock().NumberU64() > 0 {
 		log.Warn("Blockchain not empty, fast sync disabled")
@@ -124,7 +135,15 @@ func NewProtocolManager(config *params.ChainConfig, mode downloader.SyncMode, ne
 			return 0, nil
 		}
 		atomic.StoreUint32(&manager.acceptTxs, 1) 
-		return manager.blockchain.InsertChain(blocks)
+		n, err := manager.blockchain.InsertChain(blocks)
+		imported := blocks
+		if err != nil {
+			imported = blocks[:n]
+		}
+		for _, block := range imported {
+			manager.drift.imported(block.Header())
+		}
+		return n, err
 	}
 	manager.fetcher = fetcher.New(blockchain.GetBlockByHash, validator, manager.BroadcastBlock, heighter, inserter, manager.removePeer)
 	return manager, nil
@@ -135,6 +154,7 @@ func (pm *ProtocolManager) removePeer(id string) {
 		return
 	}
 	log.Debug("Removing Ethereum peer", "peer", id)
+	pm.drift.removePeer(id)
 	pm.downloader.UnregisterPeer(id)
 	if err := pm.peers.Unregister(id); err != nil {
 		log.Error("Peer removal failed", "peer", id, "err", err)
@@ -164,7 +184,9 @@ func (pm *ProtocolManager) Stop() {
 	log.Info("Ethereum protocol stopped")
 }
 func (pm *ProtocolManager) newPeer(pv int, p *p2p.Peer, rw p2p.MsgReadWriter) *peer {
-	return newPeer(pv, p, newMeteredMsgWriter(rw))
+	peer := newPeer(pv, p, newMeteredMsgWriter(rw))
+	peer.clock = pm.clock
+	return peer
 }
 func (pm *ProtocolManager) handle(p *peer) error {
 	if pm.peers.Len() >= pm.maxPeers {
@@ -209,6 +231,26 @@ func (pm *ProtocolManager) handle(p *peer) error {
 			}
 		}()
 	}
+	if checkpoint := pm.checkpoint; checkpoint != nil && p.version >= eth104 {
+		if err := p.RequestHeadersByNumber(checkpoint.Number, 1, 0, false); err != nil {
+			return err
+		}
+		p.checkpointDrop = time.AfterFunc(checkpointChallengeTimeout, func() {
+			p.Log().Debug("Timed out checkpoint challenge, dropping")
+			pm.removePeer(p.id)
+		})
+		defer func() {
+			if p.checkpointDrop != nil {
+				p.checkpointDrop.Stop()
+				p.checkpointDrop = nil
+			}
+		}()
+	}
+	if proof := pm.ProducerProof(); proof != nil && p.version >= eth105 {
+		if err := p.SendProducerProof(proof); err != nil {
+			return err
+		}
+	}
 	for {
 		if err := pm.handleMsg(p); err != nil {
 			p.Log().Debug("Ethereum message handling failed", "err", err)
@@ -313,8 +355,23 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 				return nil
 			}
 		}
+		if len(headers) == 0 && p.checkpointDrop != nil {
+			p.Log().Debug("Peer has not reached the trusted checkpoint")
+			p.checkpointDrop.Stop()
+			p.checkpointDrop = nil
+			return nil
+		}
 		filter := len(headers) == 1
 		if filter {
+			if p.checkpointDrop != nil && headers[0].Number.Uint64() == pm.checkpoint.Number {
+				p.checkpointDrop.Stop()
+				p.checkpointDrop = nil
+				if headers[0].Hash() != pm.checkpoint.BlockHash {
+					return errResp(ErrCheckpointMismatch, "%x != %x", headers[0].Hash(), pm.checkpoint.BlockHash)
+				}
+				p.Log().Debug("Verified trusted checkpoint")
+				return nil
+			}
 			if p.forkDrop != nil && pm.chainconfig.DAOForkBlock.Cmp(headers[0].Number) == 0 {
 				p.forkDrop.Stop()
 				p.forkDrop = nil
@@ -469,6 +526,9 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 		}
 		request.Block.ReceivedAt = msg.ReceivedAt
 		request.Block.ReceivedFrom = p
+		if request.Block.NumberU64() > pm.blockchain.CurrentBlock().NumberU64() {
+			pm.drift.arrive(p.id, request.Block.Header(), pm.clock.Now())
+		}
 		p.MarkBlock(request.Block.Hash())
 		pm.fetcher.Enqueue(p.id, request.Block)
 		var (
@@ -497,6 +557,31 @@ func (pm *ProtocolManager) handleMsg(p *peer) error {
 			p.MarkTransaction(tx.Hash())
 		}
 		pm.txpool.AddRemotes(txs)
+	case p.version >= eth104 && msg.Code == CheckpointMsg:
+		var checkpoint types.Checkpoint
+		if err := msg.Decode(&checkpoint); err != nil {
+			return errResp(ErrDecode, "msg %v: %v", msg, err)
+		}
+		p.MarkCheckpoint(checkpoint.Hash())
+		stored, changed, err := pm.AddCheckpoint(&checkpoint)
+		if err != nil {
+			p.Log().Debug("Discarded checkpoint", "number", checkpoint.Number, "err", err)
+			break
+		}
+		if changed {
+			p.MarkCheckpoint(stored.Hash())
+			pm.BroadcastCheckpoint(stored)
+		}
+	case p.version >= eth105 && msg.Code == ProducerMsg:
+		var proof producerProof
+		if err := msg.Decode(&proof); err != nil {
+			return errResp(ErrDecode, "msg %v: %v", msg, err)
+		}
+		if err := proof.verify(pm.blockchain.Genesis().Hash(), p.ID()); err != nil {
+			p.Log().Debug("Discarded producer proof", "producer", proof.Producer, "err", err)
+			break
+		}
+		p.SetProducer(proof.Producer)
 	default:
 		return errResp(ErrInvalidMsgCode, "%v", msg.Code)
 	}
@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/common/mclock"
 	"github.com/DEL-ORG/del/consensus/ethash"
 	"github.com/DEL-ORG/del/core"
 	"github.com/DEL-ORG/del/core/state"
@@ -24,8 +25,8 @@ func TestProtocolCompatibility(t *testing.T) {
 		mode       downloader.SyncMode
 		compatible bool
 	}{
-		{61, downloader.FullSync, true}, {62, downloader.FullSync, true}, {63, downloader.FullSync, true},
-		{61, downloader.FastSync, false}, {62, downloader.FastSync, false}, {63, downloader.FastSync, true},
+		{eth101, downloader.FullSync, true}, {eth102, downloader.FullSync, true}, {eth104, downloader.FullSync, true},
+		{eth101, downloader.FastSync, false}, {eth102, downloader.FastSync, true}, {eth104, downloader.FastSync, true},
 	}
 	backup := ProtocolVersions
 	defer func() { ProtocolVersions = backup }()
@@ -40,8 +41,8 @@ func TestProtocolCompatibility(t *testing.T) {
 		}
 	}
 }
-func TestGetBlockHeaders62(t *testing.T) { testGetBlockHeaders(t, 62) }
-func TestGetBlockHeaders63(t *testing.T) { testGetBlockHeaders(t, 63) }
+func TestGetBlockHeaders101(t *testing.T) { testGetBlockHeaders(t, eth101) }
+func TestGetBlockHeaders102(t *testing.T) { testGetBlockHeaders(t, eth102) }
 func testGetBlockHeaders(t *testing.T, protocol int) {
 	pm, _ := newTestProtocolManagerMust(t, downloader.FullSync, downloader.MaxHashFetch+15, nil, nil)
 	peer, _ := newTestPeer("peer", protocol, pm, true)
@@ -177,8 +178,8 @@ func testGetBlockHeaders(t *testing.T, protocol int) {
 		}
 	}
 }
-func TestGetBlockBodies62(t *testing.T) { testGetBlockBodies(t, 62) }
-func TestGetBlockBodies63(t *testing.T) { testGetBlockBodies(t, 63) }
+func TestGetBlockBodies101(t *testing.T) { testGetBlockBodies(t, eth101) }
+func TestGetBlockBodies102(t *testing.T) { testGetBlockBodies(t, eth102) }
 func testGetBlockBodies(t *testing.T, protocol int) {
 	pm, _ := newTestProtocolManagerMust(t, downloader.FullSync, downloader.MaxBlockFetch+15, nil, nil)
 	peer, _ := newTestPeer("peer", protocol, pm, true)
@@ -218,7 +219,7 @@ func testGetBlockBodies(t *testing.T, protocol int) {
 					block := pm.blockchain.GetBlockByNumber(uint64(num))
 					hashes = append(hashes, block.Hash())
 					if len(bodies) < tt.expected {
-						bodies = append(bodies, &blockBody{Transactions: block.Transactions(), Uncles: block.Uncles()})
+						bodies = append(bodies, &blockBody{Transactions: block.Transactions(), Uncles: block.Uncles(), Producers: block.Producers(), Voters: block.Voters})
 					}
 					break
 				}
@@ -228,7 +229,7 @@ func testGetBlockBodies(t *testing.T, protocol int) {
 			hashes = append(hashes, hash)
 			if tt.available[j] && len(bodies) < tt.expected {
 				block := pm.blockchain.GetBlockByHash(hash)
-				bodies = append(bodies, &blockBody{Transactions: block.Transactions(), Uncles: block.Uncles()})
+				bodies = append(bodies, &blockBody{Transactions: block.Transactions(), Uncles: block.Uncles(), Producers: block.Producers(), Voters: block.Voters})
 			}
 		}
 		p2p.Send(peer.app, 0x05, hashes)
@@ -237,7 +238,7 @@ func testGetBlockBodies(t *testing.T, protocol int) {
 		}
 	}
 }
-func TestGetNodeData63(t *testing.T) { testGetNodeData(t, 63) }
+func TestGetNodeData102(t *testing.T) { testGetNodeData(t, eth102) }
 func testGetNodeData(t *testing.T, protocol int) {
 	acc1Key, _ := crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
 	acc2Key, _ := crypto.HexToECDSA("49a7b37aa6f6645917e7b807e9d1c00d4fa71f18343b0d4122a4d2df64dd6fee")
@@ -255,7 +256,6 @@ func testGetNodeData(t *testing.T, protocol int) {
 			block.AddTx(tx1)
 			block.AddTx(tx2)
 		case 2:
-			block.SetCoinbase(acc2Addr)
 			block.SetExtra([]byte("yeehaw"))
 		case 3:
 			b2 := block.PrevBlock(1).Header()
@@ -312,7 +312,7 @@ func testGetNodeData(t *testing.T, protocol int) {
 		}
 	}
 }
-func TestGetReceipt63(t *testing.T) { testGetReceipt(t, 63) }
+func TestGetReceipt102(t *testing.T) { testGetReceipt(t, eth102) }
 func testGetReceipt(t *testing.T, protocol int) {
 	acc1Key, _ := crypto.HexToECDSA("8a1f9a8f95be41cd7ccb6168179afb4504aefe388d1e14474d32c45c72ce7b7a")
 	acc2Key, _ := crypto.HexToECDSA("49a7b37aa6f6645917e7b807e9d1c00d4fa71f18343b0d4122a4d2df64dd6fee")
@@ -330,7 +330,6 @@ func testGetReceipt(t *testing.T, protocol int) {
 			block.AddTx(tx1)
 			block.AddTx(tx2)
 		case 2:
-			block.SetCoinbase(acc2Addr)
 			block.SetExtra([]byte("yeehaw"))
 		case 3:
 			b2 := block.PrevBlock(1).Header()
@@ -375,13 +374,13 @@ func testDAOChallenge(t *testing.T, localForked, remoteForked bool, timeout bool
 		genesis       = gspec.MustCommit(db)
 		blockchain, _ = core.NewBlockChain(db, nil, config, pow, vm.Config{})
 	)
-	pm, err := NewProtocolManager(config, downloader.FullSync, DefaultConfig.NetworkId, evmux, new(testTxPool), pow, blockchain, db)
+	pm, err := NewProtocolManager(config, downloader.FullSync, DefaultConfig.NetworkId, evmux, new(testTxPool), pow, blockchain, db, mclock.NewSimulated(time.Unix(common.GENESIS_TIME, 0)))
 	if err != nil {
 		t.Fatalf("failed to start test protocol manager: %v", err)
 	}
 	pm.Start(1000)
 	defer pm.Stop()
-	peer, _ := newTestPeer("peer", eth63, pm, true)
+	peer, _ := newTestPeer("peer", eth104, pm, true)
 	defer peer.close()
 	challenge := &getBlockHeadersData{
 		Origin:  hashOrNumber{Number: config.DAOForkBlock.Uint64()},
@@ -405,7 +404,7 @@ func testDAOChallenge(t *testing.T, localForked, remoteForked bool, timeout bool
 	} else {
 		time.Sleep(daoChallengeTimeout + 500*time.Millisecond)
 	}
-	if localForked == remoteForked && !timeout {
+	if !timeout {
 		if peers := pm.peers.Len(); peers != 1 {
 			t.Fatalf("peer count mismatch: have %d, want %d", peers, 1)
 		}
@@ -6,7 +6,9 @@ import (
 	"sort"
 	"sync"
 	"testing"
+	"time"
 	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/common/mclock"
 	"github.com/DEL-ORG/del/consensus/ethash"
 	"github.com/DEL-ORG/del/core"
 	"github.com/DEL-ORG/del/core/types"
@@ -30,7 +32,7 @@ func newTestProtocolManager(mode downloader.SyncMode, blocks int, generator func
 		db, _  = ethdb.NewMemDatabase()
 		gspec  = &core.Genesis{
 			Config: params.TestChainConfig,
-			Alloc:  core.GenesisAlloc{testBank: {Balance: big.NewInt(1000000)}},
+			Alloc:  core.GenesisAlloc{{Addr: testBank, Balance: big.NewInt(1000000), Producer: true}},
 		}
 		genesis       = gspec.MustCommit(db)
 		blockchain, _ = core.NewBlockChain(db, nil, gspec.Config, engine, vm.Config{})
@@ -39,7 +41,7 @@ func newTestProtocolManager(mode downloader.SyncMode, blocks int, generator func
 	if _, err := blockchain.InsertChain(chain); err != nil {
 		panic(err)
 	}
-	pm, err := NewProtocolManager(gspec.Config, mode, DefaultConfig.NetworkId, evmux, &testTxPool{added: newtx}, engine, blockchain, db)
+	pm, err := NewProtocolManager(gspec.Config, mode, DefaultConfig.NetworkId, evmux, &testTxPool{added: newtx}, engine, blockchain, db, mclock.NewSimulated(time.Unix(common.GENESIS_TIME, 0)))
 	if err != nil {
 		return nil, nil, err
 	}
@@ -127,6 +129,9 @@ func (p *testPeer) handshake(t *testing.T, td *big.Int, head common.Hash, genesi
 		CurrentBlock:    head,
 		GenesisBlock:    genesis,
 	}
+	if p.version >= eth103 {
+		msg.Time = []uint64{uint64(p.clock.Now().UnixNano())}
+	}
 	if err := p2p.ExpectMsg(p.app, StatusMsg, msg); err != nil {
 		t.Fatalf("status recv: %v", err)
 	}
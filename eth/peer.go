@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/common/mclock"
 	"github.com/DEL-ORG/del/core/types"
 	"github.com/DEL-ORG/del/p2p"
 	"github.com/DEL-ORG/del/rlp"
@@ -17,9 +18,10 @@ var (
 	errNotRegistered     = errors.New("peer is not registered")
 )
 const (
-	maxKnownTxs      = 32768 
-	maxKnownBlocks   = 1024  
-	handshakeTimeout = 5 * time.Second
+	maxKnownTxs         = 32768 
+	maxKnownBlocks      = 1024  
+	maxKnownCheckpoints = 64
+	handshakeTimeout    = 5 * time.Second
 )
 type PeerInfo struct {
 	Version    int      `json:"version"`    
@@ -30,23 +32,31 @@ type peer struct {
 	id string
 	*p2p.Peer
 	rw p2p.MsgReadWriter
-	version  int         
-	forkDrop *time.Timer 
+	version        int         
+	forkDrop       *time.Timer 
+	checkpointDrop *time.Timer
+	clock          mclock.Clock
+	clockOffset  time.Duration
+	clockSampled bool
+	producer     common.Address
 	head common.Hash
 	td   *big.Int
 	lock sync.RWMutex
-	knownTxs    *set.Set 
-	knownBlocks *set.Set 
+	knownTxs         *set.Set 
+	knownBlocks      *set.Set 
+	knownCheckpoints *set.Set
 }
 func newPeer(version int, p *p2p.Peer, rw p2p.MsgReadWriter) *peer {
 	id := p.ID()
 	return &peer{
-		Peer:        p,
-		rw:          rw,
-		version:     version,
-		id:          fmt.Sprintf("%x", id[:8]),
-		knownTxs:    set.New(),
-		knownBlocks: set.New(),
+		Peer:             p,
+		rw:               rw,
+		version:          version,
+		clock:            mclock.System{},
+		id:               fmt.Sprintf("%x", id[:8]),
+		knownTxs:         set.New(),
+		knownBlocks:      set.New(),
+		knownCheckpoints: set.New(),
 	}
 }
 func (p *peer) Info() *PeerInfo {
@@ -81,6 +91,29 @@ func (p *peer) MarkTransaction(hash common.Hash) {
 	}
 	p.knownTxs.Add(hash)
 }
+func (p *peer) MarkCheckpoint(hash common.Hash) {
+	for p.knownCheckpoints.Size() >= maxKnownCheckpoints {
+		p.knownCheckpoints.Pop()
+	}
+	p.knownCheckpoints.Add(hash)
+}
+func (p *peer) SendCheckpoint(checkpoint *types.Checkpoint) error {
+	p.MarkCheckpoint(checkpoint.Hash())
+	return p2p.Send(p.rw, CheckpointMsg, checkpoint)
+}
+func (p *peer) SendProducerProof(proof *producerProof) error {
+	return p2p.Send(p.rw, ProducerMsg, proof)
+}
+func (p *peer) Producer() common.Address {
+	p.lock.RLock()
+	defer p.lock.RUnlock()
+	return p.producer
+}
+func (p *peer) SetProducer(producer common.Address) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	p.producer = producer
+}
 func (p *peer) SendTransactions(txs types.Transactions) error {
 	for _, tx := range txs {
 		p.knownTxs.Add(tx.Hash())
@@ -143,18 +176,28 @@ func (p *peer) RequestReceipts(hashes []common.Hash) error {
 }
 func (p *peer) Handshake(network uint64, td *big.Int, head common.Hash, genesis common.Hash) error {
 	errc := make(chan error, 2)
-	var status statusData 
+	var (
+		status   statusData 
+		sent     = p.clock.Now()
+		received time.Time
+	)
 	go func() {
-		errc <- p2p.Send(p.rw, StatusMsg, &statusData{
+		data := &statusData{
 			ProtocolVersion: uint32(p.version),
 			NetworkId:       network,
 			TD:              td,
 			CurrentBlock:    head,
 			GenesisBlock:    genesis,
-		})
+		}
+		if p.version >= eth103 {
+			data.Time = []uint64{uint64(sent.UnixNano())}
+		}
+		errc <- p2p.Send(p.rw, StatusMsg, data)
 	}()
 	go func() {
-		errc <- p.readStatus(network, &status, genesis)
+		err := p.readStatus(network, &status, genesis)
+		received = p.clock.Now()
+		errc <- err
 	}()
 	timeout := time.NewTimer(handshakeTimeout)
 	defer timeout.Stop()
@@ -169,8 +212,15 @@ func (p *peer) Handshake(network uint64, td *big.Int, head common.Hash, genesis
 		}
 	}
 	p.td, p.head = status.TD, status.CurrentBlock
+	if len(status.Time) > 0 {
+		p.clockOffset = time.Unix(0, int64(status.Time[0])).Sub(sent.Add(received.Sub(sent) / 2))
+		p.clockSampled = true
+	}
 	return nil
 }
+func (p *peer) ClockOffset() (time.Duration, bool) {
+	return p.clockOffset, p.clockSampled
+}
 func (p *peer) readStatus(network uint64, status *statusData, genesis common.Hash) (err error) {
 	msg, err := p.rw.ReadMsg()
 	if err != nil {
@@ -242,6 +292,15 @@ func (ps *peerSet) Len() int {
 	defer ps.lock.RUnlock()
 	return len(ps.peers)
 }
+func (ps *peerSet) Peers() []*peer {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+	list := make([]*peer, 0, len(ps.peers))
+	for _, p := range ps.peers {
+		list = append(list, p)
+	}
+	return list
+}
 func (ps *peerSet) PeersWithoutBlock(hash common.Hash) []*peer {
 	ps.lock.RLock()
 	defer ps.lock.RUnlock()
@@ -264,6 +323,17 @@ func (ps *peerSet) PeersWithoutTx(hash common.Hash) []*peer {
 	}
 	return list
 }
+func (ps *peerSet) PeersWithoutCheckpoint(hash common.Hash) []*peer {
+	ps.lock.RLock()
+	defer ps.lock.RUnlock()
+	list := make([]*peer, 0, len(ps.peers))
+	for _, p := range ps.peers {
+		if p.version >= eth104 && !p.knownCheckpoints.Has(hash) {
+			list = append(list, p)
+		}
+	}
+	return list
+}
 func (ps *peerSet) BestPeer() *peer {
 	ps.lock.RLock()
 	defer ps.lock.RUnlock()
@@ -0,0 +1,309 @@
+package eth
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+	"github.com/DEL-ORG/del/accounts"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/common/mclock"
+	"github.com/DEL-ORG/del/core"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/crypto"
+	"github.com/DEL-ORG/del/log"
+	"github.com/DEL-ORG/del/p2p"
+	"github.com/DEL-ORG/del/p2p/discover"
+	"github.com/DEL-ORG/del/p2p/discv5"
+)
+const (
+	producerSearchFast   = 100 * time.Millisecond
+	producerSearchPeriod = time.Minute
+	producerFoundBuffer  = 16
+	producerMaxNodes     = 3
+	producerMaxRejected  = 256
+	producerProofTimeout = 30 * time.Second
+	producerProofCheck   = 5 * time.Second
+)
+var errProducerProof = errors.New("producer proof signer mismatch")
+func producerTopic(genesisHash common.Hash, producer common.Address) discv5.Topic {
+	return discv5.Topic("DPOS-" + common.Bytes2Hex(producer.Bytes()) + "@" + common.Bytes2Hex(genesisHash.Bytes()[0:8]))
+}
+func scheduledProducers(schedules ...types.Producers) map[common.Address]struct{} {
+	set := make(map[common.Address]struct{})
+	for _, producers := range schedules {
+		for _, producer := range producers {
+			if !producer.Empty() {
+				set[producer.Addr] = struct{}{}
+			}
+		}
+	}
+	return set
+}
+type producerProof struct {
+	Producer  common.Address
+	Signature []byte
+}
+func producerProofHash(genesisHash common.Hash, node discover.NodeID) common.Hash {
+	return crypto.Keccak256Hash([]byte("DPOS"), genesisHash.Bytes(), node[:])
+}
+func (proof *producerProof) verify(genesisHash common.Hash, node discover.NodeID) error {
+	hash := producerProofHash(genesisHash, node)
+	pub, err := crypto.SigToPub(hash[:], proof.Signature)
+	if err != nil {
+		return err
+	}
+	if crypto.PubkeyToAddress(*pub) != proof.Producer {
+		return errProducerProof
+	}
+	return nil
+}
+func (pm *ProtocolManager) ProducerProof() *producerProof {
+	pm.producerLock.RLock()
+	defer pm.producerLock.RUnlock()
+	return pm.producerProof
+}
+func (pm *ProtocolManager) SetProducerProof(proof *producerProof) {
+	pm.producerLock.Lock()
+	pm.producerProof = proof
+	pm.producerLock.Unlock()
+	if proof == nil {
+		return
+	}
+	for _, peer := range pm.peers.Peers() {
+		if peer.version >= eth105 {
+			peer.SendProducerProof(proof)
+		}
+	}
+}
+type producerNode struct {
+	producer common.Address
+	node     *discv5.Node
+}
+type producerDial struct {
+	node   *discover.Node
+	added  time.Time
+	proven bool
+}
+type producerSearch struct {
+	period   chan time.Duration
+	found    chan *discv5.Node
+	quit     chan struct{}
+	nodes    map[discover.NodeID]*producerDial
+	rejected map[discover.NodeID]struct{}
+}
+type producerPeers struct {
+	eth        *Ethereum
+	server     *p2p.Server
+	clock      mclock.Clock
+	genesis    common.Hash
+	searches   map[common.Address]*producerSearch
+	advertised common.Address
+	advertise  chan struct{}
+	found      chan producerNode
+	quit       chan struct{}
+	wg         sync.WaitGroup
+}
+func newProducerPeers(eth *Ethereum, server *p2p.Server) *producerPeers {
+	return &producerPeers{
+		eth:      eth,
+		server:   server,
+		clock:    eth.clock,
+		genesis:  eth.BlockChain().Genesis().Hash(),
+		searches: make(map[common.Address]*producerSearch),
+		found:    make(chan producerNode, producerFoundBuffer),
+		quit:     make(chan struct{}),
+	}
+}
+func (pp *producerPeers) start() {
+	if pp.server.DiscV5 == nil {
+		log.Debug("Producer peering disabled, discovery v5 not running")
+		return
+	}
+	pp.wg.Add(1)
+	go pp.loop()
+}
+func (pp *producerPeers) stop() {
+	close(pp.quit)
+	pp.wg.Wait()
+}
+func (pp *producerPeers) loop() {
+	defer pp.wg.Done()
+	heads := make(chan core.ChainHeadEvent, 10)
+	sub := pp.eth.BlockChain().SubscribeChainHeadEvent(heads)
+	defer sub.Unsubscribe()
+	check := time.NewTicker(producerProofCheck)
+	defer check.Stop()
+	pp.update(pp.eth.BlockChain().CurrentBlock())
+	for {
+		select {
+		case ev := <-heads:
+			pp.update(ev.Block)
+		case found := <-pp.found:
+			pp.connect(found.producer, found.node)
+		case <-check.C:
+			pp.verify(pp.clock.Now())
+		case <-sub.Err():
+			pp.reset()
+			return
+		case <-pp.quit:
+			pp.reset()
+			return
+		}
+	}
+}
+func (pp *producerPeers) schedule(block *types.Block) map[common.Address]struct{} {
+	next, err := pp.eth.Engine().CalProducersWithoutParent(pp.eth.BlockChain(), block.Header())
+	if err != nil {
+		log.Debug("Failed to predict next round producers", "number", block.Number(), "err", err)
+	}
+	return scheduledProducers(block.Producers(), next)
+}
+func (pp *producerPeers) update(block *types.Block) {
+	scheduled := pp.schedule(block)
+	self := common.Address{}
+	if pp.eth.IsMining() {
+		self, _ = pp.eth.Etherbase()
+	}
+	if _, ok := scheduled[self]; !ok {
+		self = common.Address{}
+	}
+	if self != pp.advertised {
+		pp.stopAdvertise()
+		if self != (common.Address{}) {
+			pp.startAdvertise(self)
+		}
+	}
+	for producer := range pp.searches {
+		if _, ok := scheduled[producer]; !ok || producer == self {
+			pp.stopSearch(producer)
+		}
+	}
+	for producer := range scheduled {
+		if _, ok := pp.searches[producer]; !ok && producer != self {
+			pp.startSearch(producer)
+		}
+	}
+}
+func (pp *producerPeers) startAdvertise(producer common.Address) {
+	pp.advertised = producer
+	account := accounts.Account{Address: producer}
+	wallet, err := pp.eth.AccountManager().Find(account)
+	if err != nil {
+		log.Warn("Cannot prove producer node, not advertising", "producer", producer, "err", err)
+		return
+	}
+	hash := producerProofHash(pp.genesis, pp.server.Self().ID)
+	sig, err := wallet.SignHash(account, hash[:])
+	if err != nil {
+		log.Warn("Cannot prove producer node, not advertising", "producer", producer, "err", err)
+		return
+	}
+	pp.eth.protocolManager.SetProducerProof(&producerProof{Producer: producer, Signature: sig})
+	topic := producerTopic(pp.genesis, producer)
+	pp.advertise = make(chan struct{})
+	go pp.server.DiscV5.RegisterTopic(topic, pp.advertise)
+	log.Info("Advertising scheduled producer", "producer", producer, "topic", topic)
+}
+func (pp *producerPeers) stopAdvertise() {
+	if pp.advertise != nil {
+		close(pp.advertise)
+		log.Info("Stopped advertising producer", "producer", pp.advertised)
+	}
+	if pp.advertised != (common.Address{}) {
+		pp.eth.protocolManager.SetProducerProof(nil)
+	}
+	pp.advertised, pp.advertise = common.Address{}, nil
+}
+func (pp *producerPeers) startSearch(producer common.Address) {
+	search := &producerSearch{
+		period: make(chan time.Duration, 1),
+		found:  make(chan *discv5.Node, producerFoundBuffer),
+		quit:   make(chan struct{}),
+		nodes:    make(map[discover.NodeID]*producerDial),
+		rejected: make(map[discover.NodeID]struct{}),
+	}
+	pp.searches[producer] = search
+	search.period <- producerSearchFast
+	go pp.server.DiscV5.SearchTopic(producerTopic(pp.genesis, producer), search.period, search.found, nil)
+	go func() {
+		for {
+			select {
+			case node := <-search.found:
+				select {
+				case pp.found <- producerNode{producer, node}:
+				case <-search.quit:
+					return
+				}
+			case <-search.quit:
+				return
+			}
+		}
+	}()
+	log.Debug("Searching for scheduled producer", "producer", producer)
+}
+func (pp *producerPeers) stopSearch(producer common.Address) {
+	search := pp.searches[producer]
+	close(search.period)
+	close(search.quit)
+	for _, dial := range search.nodes {
+		pp.server.RemovePeer(dial.node)
+	}
+	delete(pp.searches, producer)
+	log.Debug("Dropped unscheduled producer", "producer", producer, "peers", len(search.nodes))
+}
+func (pp *producerPeers) connect(producer common.Address, node *discv5.Node) {
+	search, ok := pp.searches[producer]
+	if !ok {
+		return
+	}
+	id := discover.NodeID(node.ID)
+	if id == pp.server.Self().ID {
+		return
+	}
+	if _, ok := search.nodes[id]; ok {
+		return
+	}
+	if _, ok := search.rejected[id]; ok || len(search.nodes) >= producerMaxNodes {
+		return
+	}
+	if len(search.nodes) == 0 {
+		select {
+		case search.period <- producerSearchPeriod:
+		default:
+		}
+	}
+	peer := discover.NewNode(id, node.IP, node.UDP, node.TCP)
+	search.nodes[id] = &producerDial{node: peer, added: pp.clock.Now()}
+	pp.server.AddPeer(peer)
+	log.Debug("Connecting to scheduled producer", "producer", producer, "node", id)
+}
+func (pp *producerPeers) verify(now time.Time) {
+	for producer, search := range pp.searches {
+		for id, dial := range search.nodes {
+			if dial.proven {
+				continue
+			}
+			if peer := pp.eth.protocolManager.peers.Peer(fmt.Sprintf("%x", id[:8])); peer != nil && peer.Producer() == producer {
+				dial.proven = true
+				log.Debug("Verified scheduled producer node", "producer", producer, "node", id)
+				continue
+			}
+			if now.Sub(dial.added) < producerProofTimeout {
+				continue
+			}
+			delete(search.nodes, id)
+			pp.server.RemovePeer(dial.node)
+			if len(search.rejected) >= producerMaxRejected {
+				search.rejected = make(map[discover.NodeID]struct{})
+			}
+			search.rejected[id] = struct{}{}
+			log.Debug("Dropped unproven producer node", "producer", producer, "node", id)
+		}
+	}
+}
+func (pp *producerPeers) reset() {
+	pp.stopAdvertise()
+	for producer := range pp.searches {
+		pp.stopSearch(producer)
+	}
+}
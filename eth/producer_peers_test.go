@@ -0,0 +1,95 @@
+package eth
+import (
+	"math/big"
+	"testing"
+	"time"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/crypto"
+	"github.com/DEL-ORG/del/eth/downloader"
+	"github.com/DEL-ORG/del/p2p"
+	"github.com/DEL-ORG/del/p2p/discover"
+)
+func TestScheduledProducers(t *testing.T) {
+	a, b, c := common.Address{1}, common.Address{2}, common.Address{3}
+	current := types.Producers{{Addr: a, Vote: big.NewInt(0)}, types.EmptyProducer, {Addr: b, Vote: big.NewInt(1)}, {Addr: a, Vote: big.NewInt(0)}}
+	next := types.Producers{{Addr: b, Vote: big.NewInt(2)}, {Addr: c, Vote: big.NewInt(3)}, types.EmptyProducer}
+	set := scheduledProducers(current, next)
+	if len(set) != 3 {
+		t.Fatalf("scheduled producer count mismatch: have %d, want 3", len(set))
+	}
+	for _, addr := range []common.Address{a, b, c} {
+		if _, ok := set[addr]; !ok {
+			t.Errorf("producer %x missing from schedule", addr)
+		}
+	}
+	if _, ok := set[common.Address{}]; ok {
+		t.Errorf("empty producer scheduled")
+	}
+	if set := scheduledProducers(nil, types.Producers{types.EmptyProducer}); len(set) != 0 {
+		t.Errorf("empty schedule mismatch: have %d producers, want 0", len(set))
+	}
+}
+func TestProducerTopic(t *testing.T) {
+	genesis := common.Hash{0x91, 0x7f, 0xcd}
+	a, b := common.Address{1}, common.Address{2}
+	if producerTopic(genesis, a) == producerTopic(genesis, b) {
+		t.Errorf("producers share a topic")
+	}
+	if producerTopic(genesis, a) == producerTopic(common.Hash{0x01}, a) {
+		t.Errorf("networks share a producer topic")
+	}
+	if producerTopic(genesis, a) != producerTopic(genesis, a) {
+		t.Errorf("producer topic not deterministic")
+	}
+}
+func newTestProducerProof(t *testing.T, genesis common.Hash, node discover.NodeID) (*producerProof, common.Address) {
+	key, _ := crypto.GenerateKey()
+	hash := producerProofHash(genesis, node)
+	sig, err := crypto.Sign(hash[:], key)
+	if err != nil {
+		t.Fatalf("failed to sign producer proof: %v", err)
+	}
+	return &producerProof{Producer: crypto.PubkeyToAddress(key.PublicKey), Signature: sig}, crypto.PubkeyToAddress(key.PublicKey)
+}
+func TestProducerProof(t *testing.T) {
+	genesis, node := common.Hash{0x91}, discover.NodeID{1}
+	proof, _ := newTestProducerProof(t, genesis, node)
+	if err := proof.verify(genesis, node); err != nil {
+		t.Fatalf("valid proof rejected: %v", err)
+	}
+	if err := proof.verify(genesis, discover.NodeID{2}); err != errProducerProof {
+		t.Errorf("proof replayed for another node: have %v, want %v", err, errProducerProof)
+	}
+	if err := proof.verify(common.Hash{0x01}, node); err != errProducerProof {
+		t.Errorf("proof replayed on another network: have %v, want %v", err, errProducerProof)
+	}
+	forged := &producerProof{Producer: common.Address{1}, Signature: proof.Signature}
+	if err := forged.verify(genesis, node); err != errProducerProof {
+		t.Errorf("forged producer accepted: have %v, want %v", err, errProducerProof)
+	}
+}
+func TestProducerProofExchange(t *testing.T) {
+	pm, _ := newTestProtocolManagerMust(t, downloader.FullSync, 0, nil, nil)
+	defer pm.Stop()
+	genesis := pm.blockchain.Genesis().Hash()
+	own, _ := newTestProducerProof(t, genesis, discover.NodeID{0xff})
+	pm.SetProducerProof(own)
+	peer, _ := newTestPeer("peer", eth105, pm, true)
+	defer peer.close()
+	if err := p2p.ExpectMsg(peer.app, ProducerMsg, own); err != nil {
+		t.Fatalf("producer proof mismatch: %v", err)
+	}
+	proof, producer := newTestProducerProof(t, genesis, peer.ID())
+	if err := p2p.Send(peer.app, ProducerMsg, proof); err != nil {
+		t.Fatalf("failed to send producer proof: %v", err)
+	}
+	stolen, _ := newTestProducerProof(t, genesis, discover.NodeID{0xff})
+	if err := p2p.Send(peer.app, ProducerMsg, stolen); err != nil {
+		t.Fatalf("failed to send producer proof: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	if have := pm.peers.Peer(peer.id).Producer(); have != producer {
+		t.Errorf("proven producer mismatch: have %x, want %x", have, producer)
+	}
+}
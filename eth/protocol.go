@@ -12,10 +12,13 @@ import (
 const (
 	eth101 = 101
 	eth102 = 102
+	eth103 = 103
+	eth104 = 104
+	eth105 = 105
 )
 var ProtocolName = "deld"
-var ProtocolVersions = []uint{eth102, eth101}
-var ProtocolLengths = []uint64{17, 8}
+var ProtocolVersions = []uint{eth105, eth104, eth103, eth102, eth101}
+var ProtocolLengths = []uint64{19, 18, 17, 17, 8}
 const ProtocolMaxMsgSize = 10 * 1024 * 1024 
 const (
 	StatusMsg          = 0x00
@@ -30,6 +33,8 @@ const (
 	NodeDataMsg    = 0x0e
 	GetReceiptsMsg = 0x0f
 	ReceiptsMsg    = 0x10
+	CheckpointMsg  = 0x11
+	ProducerMsg    = 0x12
 )
 type errCode int
 const (
@@ -42,6 +47,7 @@ const (
 	ErrNoStatusMsg
 	ErrExtraStatusMsg
 	ErrSuspendedPeer
+	ErrCheckpointMismatch
 )
 func (e errCode) String() string {
 	return errorToString[int(e)]
@@ -56,6 +62,7 @@ var errorToString = map[int]string{
 	ErrNoStatusMsg:             "No status message",
 	ErrExtraStatusMsg:          "Extra status message",
 	ErrSuspendedPeer:           "Suspended peer",
+	ErrCheckpointMismatch:      "Trusted checkpoint mismatch",
 }
 type txPool interface {
 	AddRemotes([]*types.Transaction) []error
@@ -68,6 +75,7 @@ type statusData struct {
 	TD              *big.Int
 	CurrentBlock    common.Hash
 	GenesisBlock    common.Hash
+	Time            []uint64 `rlp:"tail"`
 }
 type newBlockHashesData []struct {
 	Hash   common.Hash 
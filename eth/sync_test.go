@@ -17,8 +17,8 @@ func TestFastSyncDisabling(t *testing.T) {
 		t.Fatalf("fast sync not disabled on non-empty blockchain")
 	}
 	io1, io2 := p2p.MsgPipe()
-	go pmFull.handle(pmFull.newPeer(63, p2p.NewPeer(discover.NodeID{}, "empty", nil), io2))
-	go pmEmpty.handle(pmEmpty.newPeer(63, p2p.NewPeer(discover.NodeID{}, "full", nil), io1))
+	go pmFull.handle(pmFull.newPeer(eth102, p2p.NewPeer(discover.NodeID{}, "empty", nil), io2))
+	go pmEmpty.handle(pmEmpty.newPeer(eth102, p2p.NewPeer(discover.NodeID{}, "full", nil), io1))
 	time.Sleep(250 * time.Millisecond)
 	pmEmpty.synchronise(pmEmpty.peers.BestPeer())
 	if atomic.LoadUint32(&pmEmpty.fastSync) == 1 {
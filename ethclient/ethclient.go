@@ -348,6 +348,89 @@ func (ec *Client) SendTransaction(ctx context.Context, tx *types.Transaction) er
 	}
 	return ec.c.CallContext(ctx, nil, "eth_sendRawTransaction", common.ToHex(data))
 }
+func (ec *Client) GetProducers(ctx context.Context, blockNumber *big.Int, hidden bool) ([]types.Producer, error) {
+	var result []types.Producer
+	err := ec.c.CallContext(ctx, &result, "eth_getProducers", toBlockNumArg(blockNumber), hidden)
+	return result, err
+}
+func (ec *Client) GetVoters(ctx context.Context, blockNumber *big.Int) (types.Voters, error) {
+	var result types.Voters
+	err := ec.c.CallContext(ctx, &result, "eth_getVoters", toBlockNumArg(blockNumber))
+	return result, err
+}
+func (ec *Client) GetVoterState(ctx context.Context, account common.Address, blockNumber *big.Int) (*types.OVoter, error) {
+	var result *types.OVoter
+	err := ec.c.CallContext(ctx, &result, "eth_getVoterState", account, toBlockNumArg(blockNumber))
+	if err == nil && result == nil {
+		return nil, ethereum.NotFound
+	}
+	return result, err
+}
+func (ec *Client) GetFreeze(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error) {
+	var result hexutil.Big
+	err := ec.c.CallContext(ctx, &result, "eth_getFreeze", account, toBlockNumArg(blockNumber))
+	return (*big.Int)(&result), err
+}
+func (ec *Client) GetDayRewardEx(ctx context.Context, account common.Address) (*types.OutputBlockReward, error) {
+	var result *types.OutputBlockReward
+	err := ec.c.CallContext(ctx, &result, "eth_getDayRewardEx", account)
+	if err == nil && result == nil {
+		return nil, ethereum.NotFound
+	}
+	return result, err
+}
+func (ec *Client) GetLastTxs(ctx context.Context, count int64, accounts []common.Address) ([]types.OutputTx, error) {
+	var result []types.OutputTx
+	err := ec.c.CallContext(ctx, &result, "eth_getLastTxs", count, accounts)
+	return result, err
+}
+func (ec *Client) GetLastTexts(ctx context.Context, count int64, accounts []common.Address) ([]types.OutputText, error) {
+	var result []types.OutputText
+	err := ec.c.CallContext(ctx, &result, "eth_getLastTexts", count, accounts)
+	return result, err
+}
+func (ec *Client) VoteProducer(ctx context.Context, msg ethereum.VoteMsg) (common.Hash, error) {
+	var hash common.Hash
+	err := ec.c.CallContext(ctx, &hash, "eth_voteProducer", toVoteArg(msg))
+	return hash, err
+}
+func (ec *Client) SendText(ctx context.Context, msg ethereum.TextMsg) (common.Hash, error) {
+	var hash common.Hash
+	err := ec.c.CallContext(ctx, &hash, "eth_sendText", toTextArg(msg))
+	return hash, err
+}
+func toVoteArg(msg ethereum.VoteMsg) interface{} {
+	arg := map[string]interface{}{
+		"from":     msg.From,
+		"producer": msg.Producer,
+	}
+	if msg.Amount != nil {
+		arg["amount"] = (*hexutil.Big)(msg.Amount)
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	if msg.Nonce != nil {
+		arg["nonce"] = hexutil.Uint64(*msg.Nonce)
+	}
+	return arg
+}
+func toTextArg(msg ethereum.TextMsg) interface{} {
+	arg := map[string]interface{}{
+		"from": msg.From,
+		"text": msg.Text,
+	}
+	if msg.To != nil {
+		arg["to"] = msg.To
+	}
+	if msg.GasPrice != nil {
+		arg["gasPrice"] = (*hexutil.Big)(msg.GasPrice)
+	}
+	if msg.Nonce != nil {
+		arg["nonce"] = hexutil.Uint64(*msg.Nonce)
+	}
+	return arg
+}
 func toCallArg(msg ethereum.CallMsg) interface{} {
 	arg := map[string]interface{}{
 		"from": msg.From,
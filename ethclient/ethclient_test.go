@@ -1,5 +1,14 @@
 package ethclient
-import "github.com/DEL-ORG/del"
+import (
+	"context"
+	"math/big"
+	"testing"
+	"github.com/DEL-ORG/del"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/common/hexutil"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/rpc"
+)
 var (
 	_ = ethereum.ChainReader(&Client{})
 	_ = ethereum.TransactionReader(&Client{})
@@ -11,4 +20,87 @@ var (
 	_ = ethereum.LogFilterer(&Client{})
 	_ = ethereum.PendingStateReader(&Client{})
 	_ = ethereum.PendingContractCaller(&Client{})
+	_ = ethereum.DposReader(&Client{})
+	_ = ethereum.DposTransactor(&Client{})
 )
+type TestDposArgs struct {
+	From     common.Address  `json:"from"`
+	To       *common.Address `json:"to"`
+	Producer common.Address  `json:"producer"`
+	Amount   *hexutil.Big    `json:"amount"`
+	Text     *string         `json:"text"`
+}
+type TestDposService struct {
+	vote TestDposArgs
+	text TestDposArgs
+}
+func (s *TestDposService) GetProducers(blockNr rpc.BlockNumber, hidden bool) ([]types.Producer, error) {
+	return []types.Producer{{Addr: common.HexToAddress("0x01"), Vote: big.NewInt(int64(blockNr) + 10)}}, nil
+}
+func (s *TestDposService) GetFreeze(address common.Address, blockNr rpc.BlockNumber) (*big.Int, error) {
+	return big.NewInt(42), nil
+}
+func (s *TestDposService) GetVoterState(address common.Address, blockNr rpc.BlockNumber) (*types.OVoter, error) {
+	return nil, nil
+}
+func (s *TestDposService) GetLastTexts(count int64, address []common.Address) ([]types.OutputText, error) {
+	return []types.OutputText{{From: address[0], Text: "hello", Price: big.NewInt(count)}}, nil
+}
+func (s *TestDposService) VoteProducer(args TestDposArgs) common.Hash {
+	s.vote = args
+	return common.HexToHash("0x02")
+}
+func (s *TestDposService) SendText(args TestDposArgs) common.Hash {
+	s.text = args
+	return common.HexToHash("0x03")
+}
+func TestDposMethods(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		service  = new(TestDposService)
+		server   = rpc.NewServer()
+		producer = common.HexToAddress("0x01")
+		account  = common.HexToAddress("0x04")
+	)
+	if err := server.RegisterName("eth", service); err != nil {
+		t.Fatal(err)
+	}
+	defer server.Stop()
+	rpcClient := rpc.DialInProc(server)
+	defer rpcClient.Close()
+	client := NewClient(rpcClient)
+	producers, err := client.GetProducers(ctx, big.NewInt(5), true)
+	if err != nil {
+		t.Fatalf("GetProducers failed: %v", err)
+	}
+	if len(producers) != 1 || producers[0].Addr != producer || producers[0].Vote.Int64() != 15 {
+		t.Errorf("GetProducers returned %v", producers)
+	}
+	if freeze, err := client.GetFreeze(ctx, account, nil); err != nil || freeze.Int64() != 42 {
+		t.Errorf("GetFreeze returned %v, %v", freeze, err)
+	}
+	if _, err := client.GetVoterState(ctx, account, nil); err != ethereum.NotFound {
+		t.Errorf("GetVoterState error mismatch: have %v, want %v", err, ethereum.NotFound)
+	}
+	texts, err := client.GetLastTexts(ctx, 7, []common.Address{account})
+	if err != nil {
+		t.Fatalf("GetLastTexts failed: %v", err)
+	}
+	if len(texts) != 1 || texts[0].From != account || texts[0].Text != "hello" || texts[0].Price.Int64() != 7 {
+		t.Errorf("GetLastTexts returned %v", texts)
+	}
+	hash, err := client.VoteProducer(ctx, ethereum.VoteMsg{From: account, Producer: producer, Amount: big.NewInt(100)})
+	if err != nil || hash != common.HexToHash("0x02") {
+		t.Fatalf("VoteProducer returned %x, %v", hash, err)
+	}
+	if service.vote.From != account || service.vote.Producer != producer || service.vote.Amount.ToInt().Int64() != 100 {
+		t.Errorf("VoteProducer sent %v", service.vote)
+	}
+	hash, err = client.SendText(ctx, ethereum.TextMsg{From: account, To: &producer, Text: "hi"})
+	if err != nil || hash != common.HexToHash("0x03") {
+		t.Fatalf("SendText returned %x, %v", hash, err)
+	}
+	if service.text.From != account || service.text.To == nil || *service.text.To != producer || service.text.Text == nil || *service.text.Text != "hi" {
+		t.Errorf("SendText sent %v", service.text)
+	}
+}
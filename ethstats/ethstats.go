@@ -363,6 +363,11 @@ type blockStats struct {
 	TxHash     common.Hash    `json:"transactionsRoot"`
 	Root       common.Hash    `json:"stateRoot"`
 	Uncles     uncleStats     `json:"uncles"`
+	Slot        int64          `json:"slot"`
+	Round       uint64         `json:"round"`
+	Scheduled   common.Address `json:"scheduledProducer"`
+	InTurn      bool           `json:"inTurn"`
+	MissedSlots int            `json:"missedSlots"`
 }
 type txStats struct {
 	Hash common.Hash `json:"hash"`
@@ -388,10 +393,12 @@ func (s *Service) reportBlock(conn *websocket.Conn, block *types.Block) error {
 }
 func (s *Service) assembleBlockStats(block *types.Block) *blockStats {
 	var (
-		header *types.Header
-		td     *big.Int
-		txs    []txStats
-		uncles []*types.Header
+		header    *types.Header
+		parent    *types.Header
+		td        *big.Int
+		txs       []txStats
+		uncles    []*types.Header
+		producers types.Producers
 	)
 	if s.eth != nil {
 		if block == nil {
@@ -404,6 +411,8 @@ func (s *Service) assembleBlockStats(block *types.Block) *blockStats {
 			txs[i].Hash = tx.Hash()
 		}
 		uncles = block.Uncles()
+		producers = block.Producers()
+		parent = s.eth.BlockChain().GetHeader(header.ParentHash, header.Number.Uint64()-1)
 	} else {
 		if block != nil {
 			header = block.Header()
@@ -412,8 +421,10 @@ func (s *Service) assembleBlockStats(block *types.Block) *blockStats {
 		}
 		td = s.les.BlockChain().GetTd(header.Hash(), header.Number.Uint64())
 		txs = []txStats{}
+		parent = s.les.BlockChain().GetHeader(header.ParentHash, header.Number.Uint64()-1)
 	}
 	author, _ := s.engine.Author(header)
+	slot, scheduled, missed := slotStats(header, parent, producers)
 	return &blockStats{
 		Number:     header.Number,
 		Hash:       header.Hash(),
@@ -428,8 +439,35 @@ func (s *Service) assembleBlockStats(block *types.Block) *blockStats {
 		TxHash:     header.TxHash,
 		Root:       header.Root,
 		Uncles:     uncles,
+		Slot:        slot,
+		Round:       common.GetRoundNumberByBlockNumber(header.Number.Uint64(), s.roundLength()),
+		Scheduled:   scheduled,
+		InTurn:      scheduled == author,
+		MissedSlots: missed,
 	}
 }
+func slotStats(header, parent *types.Header, producers types.Producers) (slot int64, scheduled common.Address, missed int) {
+	slot = common.GetCurrentSlotByBigInt(header.Time)
+	if len(producers) == 0 {
+		return slot, common.Address{}, 0
+	}
+	if producer := producers[slot%int64(len(producers))]; !producer.Empty() {
+		scheduled = producer.Addr
+	}
+	if parent == nil || header.Number.Sign() == 0 {
+		return slot, scheduled, 0
+	}
+	first := common.GetCurrentSlotByBigInt(parent.Time) + 1
+	if slot-first > int64(len(producers)) {
+		first = slot - int64(len(producers))
+	}
+	for i := first; i < slot; i++ {
+		if !producers[i%int64(len(producers))].Empty() {
+			missed++
+		}
+	}
+	return slot, scheduled, missed
+}
 func (s *Service) reportHistory(conn *websocket.Conn, list []uint64) error {
 	indexes := make([]uint64, 0, historyUpdateRange)
 	if len(list) > 0 {
@@ -503,43 +541,96 @@ func (s *Service) reportPending(conn *websocket.Conn) error {
 	return websocket.JSON.Send(conn, report)
 }
 type nodeStats struct {
-	Active   bool `json:"active"`
-	Syncing  bool `json:"syncing"`
-	Mining   bool `json:"mining"`
-	Hashrate int  `json:"hashrate"`
-	Peers    int  `json:"peers"`
-	GasPrice int  `json:"gasPrice"`
-	Uptime   int  `json:"uptime"`
+	Active    bool           `json:"active"`
+	Syncing   bool           `json:"syncing"`
+	Producing bool           `json:"producing"`
+	Slot      int64          `json:"slot"`
+	Round     uint64         `json:"round"`
+	Peers     int            `json:"peers"`
+	GasPrice  int            `json:"gasPrice"`
+	Uptime    int            `json:"uptime"`
+	Producer  *producerStats `json:"producer,omitempty"`
+}
+type producerStats struct {
+	Coinbase  common.Address `json:"coinbase"`
+	Votes     string         `json:"votes"`
+	Rank      int            `json:"rank"`
+	Scheduled bool           `json:"scheduled"`
+	Super     bool           `json:"super"`
+	Round     uint64         `json:"round"`
+	NextSlot  int64          `json:"nextSlot"`
+}
+func (s *Service) assembleProducerStats() *producerStats {
+	coinbase, err := s.eth.Etherbase()
+	if err != nil {
+		return nil
+	}
+	block := s.eth.BlockChain().CurrentBlock()
+	stats := &producerStats{
+		Coinbase: coinbase,
+		Votes:    "0",
+		Rank:     -1,
+		Round:    common.GetRoundNumberByBlockNumber(block.NumberU64(), s.roundLength()),
+		NextSlot: -1,
+	}
+	if voters := s.eth.BlockChain().GetVotersState(block.Header()); voters != nil {
+		if vote, ok := voters[coinbase]; ok && vote != nil {
+			stats.Votes = vote.String()
+		}
+		for i, producer := range voters.GetProducers() {
+			if producer.Addr == coinbase {
+				stats.Rank = i
+				stats.Super = i < common.SUPER_COINBASE_RANK
+				break
+			}
+		}
+	}
+	if slot, ok := block.Producers().NextSlot(coinbase, common.GetCurrentSlot(time.Now())+1); ok {
+		stats.Scheduled = true
+		stats.NextSlot = slot
+	}
+	return stats
+}
+func (s *Service) roundLength() uint64 {
+	if s.eth != nil {
+		return s.eth.BlockChain().Config().GetRoundLength()
+	}
+	return s.les.BlockChain().Config().GetRoundLength()
 }
 func (s *Service) reportStats(conn *websocket.Conn) error {
 	var (
-		mining   bool
-		hashrate int
-		syncing  bool
-		gasprice int
+		producing bool
+		syncing   bool
+		gasprice  int
+		round     uint64
+		producer  *producerStats
 	)
 	if s.eth != nil {
-		mining = s.eth.Miner().Mining()
-		hashrate = int(s.eth.Miner().HashRate())
+		producing = s.eth.Miner().Mining()
 		sync := s.eth.Downloader().Progress()
 		syncing = s.eth.BlockChain().CurrentHeader().Number.Uint64() >= sync.HighestBlock
+		round = common.GetRoundNumberByBlockNumber(s.eth.BlockChain().CurrentHeader().Number.Uint64(), s.roundLength())
 		price, _ := s.eth.ApiBackend.SuggestPrice(context.Background())
 		gasprice = int(price.Uint64())
+		producer = s.assembleProducerStats()
 	} else {
 		sync := s.les.Downloader().Progress()
 		syncing = s.les.BlockChain().CurrentHeader().Number.Uint64() >= sync.HighestBlock
+		round = common.GetRoundNumberByBlockNumber(s.les.BlockChain().CurrentHeader().Number.Uint64(), s.roundLength())
 	}
 	log.Trace("Sending node details to ethstats")
 	stats := map[string]interface{}{
 		"id": s.node,
 		"stats": &nodeStats{
-			Active:   true,
-			Mining:   mining,
-			Hashrate: hashrate,
-			Peers:    s.server.PeerCount(),
-			GasPrice: gasprice,
-			Syncing:  syncing,
-			Uptime:   100,
+			Active:    true,
+			Producing: producing,
+			Slot:      common.GetCurrentSlot(time.Now()),
+			Round:     round,
+			Peers:     s.server.PeerCount(),
+			GasPrice:  gasprice,
+			Syncing:   syncing,
+			Uptime:    100,
+			Producer:  producer,
 		},
 	}
 	report := map[string][]interface{}{
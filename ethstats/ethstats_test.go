@@ -0,0 +1,39 @@
+package ethstats
+import (
+	"math/big"
+	"testing"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core/types"
+)
+func TestSlotStats(t *testing.T) {
+	var (
+		a         = common.HexToAddress("0x01")
+		b         = common.HexToAddress("0x02")
+		producers = types.Producers{
+			{Addr: a, Vote: big.NewInt(1)},
+			types.EmptyProducer,
+			{Addr: b, Vote: big.NewInt(1)},
+			types.EmptyProducer,
+		}
+	)
+	tests := []struct {
+		parent, time int64
+		slot         int64
+		scheduled    common.Address
+		missed       int
+	}{
+		{0, 1 * common.SLOT_BASE, 1, common.Address{}, 0},
+		{1 * common.SLOT_BASE, 2 * common.SLOT_BASE, 2, b, 0},
+		{0, 4 * common.SLOT_BASE, 4, a, 1},
+		{0, 8 * common.SLOT_BASE, 8, a, 2},
+		{0, 100 * common.SLOT_BASE, 100, a, 2},
+	}
+	for i, tt := range tests {
+		parent := &types.Header{Number: big.NewInt(1), Time: big.NewInt(tt.parent)}
+		header := &types.Header{Number: big.NewInt(2), Time: big.NewInt(tt.time)}
+		slot, scheduled, missed := slotStats(header, parent, producers)
+		if slot != tt.slot || scheduled != tt.scheduled || missed != tt.missed {
+			t.Errorf("test %d: have (%d, %x, %d), want (%d, %x, %d)", i, slot, scheduled, missed, tt.slot, tt.scheduled, tt.missed)
+		}
+	}
+}
@@ -83,3 +83,30 @@ type GasEstimator interface {
 type PendingStateEventer interface {
 	SubscribePendingTransactions(ctx context.Context, ch chan<- *types.Transaction) (Subscription, error)
 }
+type VoteMsg struct {
+	From     common.Address
+	Producer common.Address
+	Amount   *big.Int
+	GasPrice *big.Int
+	Nonce    *uint64
+}
+type TextMsg struct {
+	From     common.Address
+	To       *common.Address
+	Text     string
+	GasPrice *big.Int
+	Nonce    *uint64
+}
+type DposReader interface {
+	GetProducers(ctx context.Context, blockNumber *big.Int, hidden bool) ([]types.Producer, error)
+	GetVoters(ctx context.Context, blockNumber *big.Int) (types.Voters, error)
+	GetVoterState(ctx context.Context, account common.Address, blockNumber *big.Int) (*types.OVoter, error)
+	GetFreeze(ctx context.Context, account common.Address, blockNumber *big.Int) (*big.Int, error)
+	GetDayRewardEx(ctx context.Context, account common.Address) (*types.OutputBlockReward, error)
+	GetLastTxs(ctx context.Context, count int64, accounts []common.Address) ([]types.OutputTx, error)
+	GetLastTexts(ctx context.Context, count int64, accounts []common.Address) ([]types.OutputText, error)
+}
+type DposTransactor interface {
+	VoteProducer(ctx context.Context, msg VoteMsg) (common.Hash, error)
+	SendText(ctx context.Context, msg TextMsg) (common.Hash, error)
+}
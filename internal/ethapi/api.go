@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 	"encoding/hex"
+	"encoding/json"
 	"github.com/DEL-ORG/del/accounts"
 	"github.com/DEL-ORG/del/accounts/keystore"
 	"github.com/DEL-ORG/del/common"
@@ -336,10 +337,10 @@ func (s *PublicBlockChainAPI) BlockNumber() *big.Int {
 	return header.Number
 }
 func (s *PublicBlockChainAPI) GetBeginBlockNumberByRoundNumber(ctx context.Context, roundNumber uint64) uint64 {
-	return common.GetBeginBlockNumberByRoundNumber(roundNumber)
+	return common.GetBeginBlockNumberByRoundNumber(roundNumber, s.b.ChainConfig().GetRoundLength())
 }
 func (s *PublicBlockChainAPI) GetEndBlockNumberByRoundNumber(ctx context.Context, roundNumber uint64) uint64 {
-	return common.GetEndBlockNumberByRoundNumber(roundNumber)
+	return common.GetEndBlockNumberByRoundNumber(roundNumber, s.b.ChainConfig().GetRoundLength())
 }
 func (s *PublicBlockChainAPI) GetRoundNumberByBlockNumber(ctx context.Context, blockNr rpc.BlockNumber) uint64 {
 	var number uint64 = 0
@@ -348,17 +349,115 @@ func (s *PublicBlockChainAPI) GetRoundNumberByBlockNumber(ctx context.Context, b
 	} else {
 		number = uint64(blockNr)
 	}
-	return common.GetRoundNumberByBlockNumber(number)
+	return common.GetRoundNumberByBlockNumber(number, s.b.ChainConfig().GetRoundLength())
 }
 type ADataProtocolVote struct {
 	Addr   string       `json:"addr" gencodec:"required"`
 	Amount *hexutil.Big `json:"amount,omitempty" gencodec:"required"`
 }
 type ADataProtocolTickets []ADataProtocolVote
+func (self ADataProtocolTickets) toTickets() common.DataProtocolTickets {
+	tickets := common.DataProtocolTickets{}
+	for _, ticket := range self {
+		tickets = append(tickets, common.DataProtocolVote{Addr: ticket.Addr, Amount: (*big.Int)(ticket.Amount)})
+	}
+	return tickets
+}
 type ADataProtocol struct {
 	MessageID uint16  `json:"message_id" gencodec:"required"`
 	Text      *string `json:"text,omitempty" gencodec:"required"`
 	Tickets ADataProtocolTickets `json:"tickets,omitempty" gencodec:"required"`
+	Vesting []AVestingSchedule `json:"vesting,omitempty"`
+}
+type AVestingTranche struct {
+	Block  hexutil.Uint64 `json:"block"`
+	Amount *hexutil.Big   `json:"amount"`
+}
+type AVestingSchedule struct {
+	Start    hexutil.Uint64    `json:"start"`
+	Cliff    hexutil.Uint64    `json:"cliff"`
+	Period   hexutil.Uint64    `json:"period"`
+	Releases hexutil.Uint64    `json:"releases"`
+	Amount   *hexutil.Big      `json:"amount,omitempty"`
+	Tranches []AVestingTranche `json:"tranches,omitempty"`
+}
+func newAVestingSchedule(schedule common.VestingSchedule) AVestingSchedule {
+	a := AVestingSchedule{
+		Start:    hexutil.Uint64(schedule.Start),
+		Cliff:    hexutil.Uint64(schedule.Cliff),
+		Period:   hexutil.Uint64(schedule.Period),
+		Releases: hexutil.Uint64(schedule.Releases),
+		Amount:   (*hexutil.Big)(schedule.Total()),
+	}
+	for _, tranche := range schedule.Tranches {
+		a.Tranches = append(a.Tranches, AVestingTranche{Block: hexutil.Uint64(tranche.Block), Amount: (*hexutil.Big)(tranche.Amount)})
+	}
+	return a
+}
+func (a AVestingSchedule) toSchedule() common.VestingSchedule {
+	schedule := common.VestingSchedule{
+		Start:    uint64(a.Start),
+		Cliff:    uint64(a.Cliff),
+		Period:   uint64(a.Period),
+		Releases: uint64(a.Releases),
+		Amount:   (*big.Int)(a.Amount),
+	}
+	for _, tranche := range a.Tranches {
+		schedule.Tranches = append(schedule.Tranches, common.VestingTranche{Block: uint64(tranche.Block), Amount: (*big.Int)(tranche.Amount)})
+	}
+	return schedule
+}
+func toVestingSchedules(schedules []AVestingSchedule) []common.VestingSchedule {
+	var result []common.VestingSchedule
+	for _, schedule := range schedules {
+		result = append(result, schedule.toSchedule())
+	}
+	return result
+}
+type RPCVestingSchedule struct {
+	AVestingSchedule
+	Released *hexutil.Big `json:"released"`
+	Locked   *hexutil.Big `json:"locked"`
+	Next     *hexutil.Uint64 `json:"next"`
+}
+type RPCAlias struct {
+	Name   string         `json:"name"`
+	Owner  common.Address `json:"owner"`
+	Expiry hexutil.Uint64 `json:"expiry"`
+}
+type RPCDelegation struct {
+	Total   *hexutil.Big         `json:"total"`
+	Tickets ADataProtocolTickets `json:"tickets"`
+}
+type RPCProposal struct {
+	Id         hexutil.Uint64       `json:"id"`
+	Proposer   common.Address       `json:"proposer"`
+	Round      hexutil.Uint64       `json:"round"`
+	Status     string               `json:"status"`
+	Activation hexutil.Uint64       `json:"activation"`
+	Changes    ADataProtocolTickets `json:"changes"`
+	Approvals  []common.Address     `json:"approvals"`
+}
+func newRPCProposal(proposal *core.Proposal) *RPCProposal {
+	status := map[uint64]string{core.ProposalPending: "pending", core.ProposalApproved: "approved", core.ProposalActive: "active", core.ProposalExpired: "expired"}[proposal.Status]
+	result := &RPCProposal{
+		Id:         hexutil.Uint64(proposal.Id),
+		Proposer:   proposal.Proposer,
+		Round:      hexutil.Uint64(proposal.Round),
+		Status:     status,
+		Activation: hexutil.Uint64(proposal.Activation),
+		Changes:    ADataProtocolTickets{},
+		Approvals:  proposal.Approvals,
+	}
+	for _, change := range proposal.Changes {
+		result.Changes = append(result.Changes, ADataProtocolVote{Addr: change.Addr, Amount: (*hexutil.Big)(change.Amount)})
+	}
+	return result
+}
+type RPCVesting struct {
+	Vested    *hexutil.Big          `json:"vested"`
+	Unvested  *hexutil.Big          `json:"unvested"`
+	Schedules []RPCVestingSchedule  `json:"schedules"`
 }
 func (s *PublicBlockChainAPI) GetDayRewardEx(ctx context.Context, addr common.Address) (reward *types.OutputBlockReward, err error) {
 	return s.b.Get24HRewardEx(addr)
@@ -380,6 +479,9 @@ func (s *PublicBlockChainAPI) DecodeMessage(ctx context.Context, buff []byte) (m
 			Amount: (*hexutil.Big)(ticket.Amount),
 		})
 	}
+	for _, schedule := range m.Vesting {
+		message.Vesting = append(message.Vesting, newAVestingSchedule(schedule))
+	}
 	return message, nil
 }
 func (s *PublicBlockChainAPI) MakeVoteMessage(ctx context.Context, tickets ADataProtocolTickets) ([]byte, error) {
@@ -391,6 +493,21 @@ func (s *PublicBlockChainAPI) MakeVoteMessage(ctx context.Context, tickets AData
 	}
 	return msg.Encode()
 }
+func (s *PublicBlockChainAPI) MakeLockMessage(ctx context.Context, schedules []AVestingSchedule) ([]byte, error) {
+	msg := common.DataProtocol{}
+	msg.MessageID = common.DataProtocolMessageID_LOCK
+	msg.Vesting = toVestingSchedules(schedules)
+	return msg.Encode()
+}
+func (s *PublicBlockChainAPI) MakeDelegateMessage(ctx context.Context, tickets ADataProtocolTickets) ([]byte, error) {
+	msg := common.DataProtocol{}
+	msg.MessageID = common.DataProtocolMessageID_DELEGATE
+	msg.Tickets = tickets.toTickets()
+	if err := msg.Tickets.ValidateDelegation(); err != nil {
+		return nil, err
+	}
+	return msg.Encode()
+}
 func (s *PublicBlockChainAPI) MakeTextMessage(ctx context.Context, text string) ([]byte, error) {
 	msg := common.DataProtocol{}
 	msg.MessageID = common.DataProtocolMessageID_TEXT
@@ -408,6 +525,107 @@ func (s *PublicBlockChainAPI) GetBalance(ctx context.Context, address common.Add
 	b := state.GetBalance(address)
 	return b, state.Error()
 }
+func (s *PublicBlockChainAPI) GetVesting(ctx context.Context, address common.Address, blockNr rpc.BlockNumber) (*RPCVesting, error) {
+	state, header, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	vesting := &RPCVesting{Schedules: []RPCVestingSchedule{}}
+	vested, unvested := new(big.Int), new(big.Int)
+	for _, entry := range core.GetVestings(state, address) {
+		schedule := RPCVestingSchedule{
+			AVestingSchedule: newAVestingSchedule(entry.Schedule),
+			Released:         (*hexutil.Big)(entry.Released),
+			Locked:           (*hexutil.Big)(entry.Locked()),
+		}
+		if next, ok := entry.Schedule.NextRelease(header.Number.Uint64()); ok {
+			schedule.Next = (*hexutil.Uint64)(&next)
+		}
+		vested.Add(vested, entry.Released)
+		unvested.Add(unvested, entry.Locked())
+		vesting.Schedules = append(vesting.Schedules, schedule)
+	}
+	vesting.Vested, vesting.Unvested = (*hexutil.Big)(vested), (*hexutil.Big)(unvested)
+	return vesting, state.Error()
+}
+func (s *PublicBlockChainAPI) GetDelegation(ctx context.Context, address common.Address, blockNr rpc.BlockNumber) (*RPCDelegation, error) {
+	state, _, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	delegations := core.GetDelegations(state, address)
+	delegation := &RPCDelegation{Total: (*hexutil.Big)(delegations.Total()), Tickets: ADataProtocolTickets{}}
+	for _, entry := range delegations {
+		delegation.Tickets = append(delegation.Tickets, ADataProtocolVote{Addr: entry.Producer.Hex(), Amount: (*hexutil.Big)(entry.Amount)})
+	}
+	return delegation, state.Error()
+}
+func (s *PublicBlockChainAPI) GetDelegators(ctx context.Context, producer common.Address, blockNr rpc.BlockNumber) (map[common.Address]*hexutil.Big, error) {
+	state, _, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	delegators := make(map[common.Address]*hexutil.Big)
+	for voter, amount := range core.GetDelegators(state, producer) {
+		delegators[voter] = (*hexutil.Big)(amount)
+	}
+	return delegators, state.Error()
+}
+func (s *PublicBlockChainAPI) GetGovernanceParams(ctx context.Context, blockNr rpc.BlockNumber) (map[string]*hexutil.Big, error) {
+	state, _, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	active := make(map[string]*hexutil.Big)
+	for name, value := range core.GetGovernanceParams(s.b.ChainConfig(), state) {
+		active[name] = (*hexutil.Big)(value)
+	}
+	return active, state.Error()
+}
+func (s *PublicBlockChainAPI) GetProposal(ctx context.Context, id hexutil.Uint64, blockNr rpc.BlockNumber) (*RPCProposal, error) {
+	state, _, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	proposal := core.GetProposal(state, uint64(id))
+	if proposal == nil {
+		return nil, state.Error()
+	}
+	return newRPCProposal(proposal), state.Error()
+}
+func (s *PublicBlockChainAPI) GetProposals(ctx context.Context, blockNr rpc.BlockNumber) ([]*RPCProposal, error) {
+	state, _, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	proposals := []*RPCProposal{}
+	for _, proposal := range core.GetOpenProposals(state) {
+		proposals = append(proposals, newRPCProposal(proposal))
+	}
+	return proposals, state.Error()
+}
+func (s *PublicBlockChainAPI) GetAlias(ctx context.Context, name string, blockNr rpc.BlockNumber) (*RPCAlias, error) {
+	state, header, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	alias := core.GetAlias(state, name, header.Number.Uint64())
+	if alias == nil {
+		return nil, state.Error()
+	}
+	return &RPCAlias{Name: alias.Name, Owner: alias.Owner, Expiry: hexutil.Uint64(alias.Expiry)}, state.Error()
+}
+func (s *PublicBlockChainAPI) LookupAlias(ctx context.Context, address common.Address, blockNr rpc.BlockNumber) (*RPCAlias, error) {
+	state, header, err := s.b.StateAndHeaderByNumber(ctx, blockNr)
+	if state == nil || err != nil {
+		return nil, err
+	}
+	alias := core.LookupAlias(state, address, header.Number.Uint64())
+	if alias == nil {
+		return nil, state.Error()
+	}
+	return &RPCAlias{Name: alias.Name, Owner: alias.Owner, Expiry: hexutil.Uint64(alias.Expiry)}, state.Error()
+}
 func (s *PublicBlockChainAPI) GetVoterFreeze(ctx context.Context, address common.Address, blockNr rpc.BlockNumber) (freeze *big.Int, err error) {
 	return s.b.GetVoteFreeze(ctx, address, blockNr)
 }
@@ -469,12 +687,13 @@ func (s *PublicBlockChainAPI) GetVoterReward(ctx context.Context, count int64, a
 	return rewards, nil
 }
 func (s *PublicBlockChainAPI) GetBlockRewardByNumber(ctx context.Context, address common.Address, number uint64) (reward types.OutputBlockReward, err error) {
-	round := common.GetRoundNumberByBlockNumber(number)
-	beginBlockNumber := common.GetBeginBlockNumberByRoundNumber(round)
+	round := common.GetRoundNumberByBlockNumber(number, s.b.ChainConfig().GetRoundLength())
+	beginBlockNumber := common.GetBeginBlockNumberByRoundNumber(round, s.b.ChainConfig().GetRoundLength())
 	reward.CoinbaseReward = big.NewInt(0)
 	reward.SuperCoinbaseReward = big.NewInt(0)
 	reward.BlockReward = big.NewInt(0)
 	reward.VoteReward = big.NewInt(0)
+	reward.FeeReward = big.NewInt(0)
 	reward.Number = beginBlockNumber
 	for ; number >= beginBlockNumber; number-- {
 		header, err := s.b.HeaderByNumber(ctx, rpc.BlockNumber(number))
@@ -505,6 +724,7 @@ func (s *PublicBlockChainAPI) GetBlockRewardByNumber(ctx context.Context, addres
 		reward.CoinbaseReward.Add(reward.CoinbaseReward, coinbaseReward)
 		reward.VoteReward.Add(reward.VoteReward, voteReward)
 		reward.BlockReward.Add(reward.BlockReward, blockReward)
+		reward.FeeReward.Add(reward.FeeReward, s.b.GetFeeReward(number, address))
 	}
 	return reward, nil
 }
@@ -546,9 +766,10 @@ func (s *PublicBlockChainAPI) GetBlockReward(ctx context.Context, count int64, a
 			CoinbaseReward:      coinbaseReward,
 			VoteReward:          voteReward,
 			BlockReward:         blockReward,
+			FeeReward:           s.b.GetFeeReward(number, address),
 		}
 		if reward.SuperCoinbaseReward.Cmp(common.Big0) > 0 || reward.CoinbaseReward.Cmp(common.Big0) > 0 ||
-			reward.VoteReward.Cmp(common.Big0) > 0 || reward.BlockReward.Cmp(common.Big0) > 0 {
+			reward.VoteReward.Cmp(common.Big0) > 0 || reward.BlockReward.Cmp(common.Big0) > 0 || reward.FeeReward.Cmp(common.Big0) > 0 {
 			rewards = append(rewards, reward)
 			if count > 0 && int64(len(rewards)) >= count {
 				break
@@ -741,6 +962,20 @@ search:
 					otx.Type = common.TXTYPE_TEXT
 				} else if message.MessageID == common.DataProtocolMessageID_VOTE {
 					otx.Type = common.TXTYPE_VOTE
+				} else if message.MessageID == common.DataProtocolMessageID_DELEGATE {
+					otx.Type = common.TXTYPE_DELEGATE
+				} else if message.MessageID == common.DataProtocolMessageID_PROPOSE {
+					otx.Type = common.TXTYPE_PROPOSE
+				} else if message.MessageID == common.DataProtocolMessageID_APPROVE {
+					otx.Type = common.TXTYPE_APPROVE
+				} else if message.MessageID == common.DataProtocolMessageID_ALIAS {
+					otx.Type = common.TXTYPE_ALIAS
+				} else if message.MessageID == common.DataProtocolMessageID_LOCK {
+					otx.Type = common.TXTYPE_LOCK
+					otx.Value = common.VestingTotal(message.Vesting)
+					if out {
+						otx.Value.Neg(otx.Value)
+					}
 				} else {
 					otx = nil
 				}
@@ -1265,9 +1500,44 @@ func (s *PublicTransactionPoolAPI) sign(addr common.Address, tx *types.Transacti
 	}
 	return wallet.SignTx(account, tx, chainID)
 }
+type AddressOrAlias struct {
+	Address common.Address
+	Alias   string
+}
+func (self *AddressOrAlias) UnmarshalJSON(input []byte) error {
+	var value string
+	if err := json.Unmarshal(input, &value); err != nil {
+		return err
+	}
+	if common.IsHexAddress(value) {
+		self.Address = common.HexToAddress(value)
+		return nil
+	}
+	if err := common.ValidateAlias(value); err != nil {
+		return err
+	}
+	self.Alias = value
+	return nil
+}
+func (self *AddressOrAlias) resolve(ctx context.Context, b Backend) error {
+	if self == nil || self.Alias == "" {
+		return nil
+	}
+	state, header, err := b.StateAndHeaderByNumber(ctx, rpc.LatestBlockNumber)
+	if err != nil {
+		return err
+	}
+	if state != nil {
+		if owner, ok := core.ResolveAlias(state, self.Alias, header.Number.Uint64()); ok {
+			self.Address = owner
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown alias %q", self.Alias)
+}
 type SendTxArgs struct {
 	From     common.Address  `json:"from"`
-	To       *common.Address `json:"to"`
+	To       *AddressOrAlias `json:"to"`
 	Gas      *hexutil.Uint64 `json:"gas"`
 	GasPrice *hexutil.Big    `json:"gasPrice"`
 	Value    *hexutil.Big    `json:"value"`
@@ -1276,6 +1546,9 @@ type SendTxArgs struct {
 	Input *hexutil.Bytes `json:"input"`
 }
 func (args *SendTxArgs) setDefaults(ctx context.Context, b Backend) error {
+	if err := args.To.resolve(ctx, b); err != nil {
+		return err
+	}
 	if args.Gas == nil {
 		args.Gas = new(hexutil.Uint64)
 		*(*uint64)(args.Gas) = 90000
@@ -1312,7 +1585,7 @@ func (args *SendTxArgs) toTransaction() *types.Transaction {
 	if args.To == nil {
 		return types.NewContractCreation(uint64(*args.Nonce), (*big.Int)(args.Value), uint64(*args.Gas), (*big.Int)(args.GasPrice), input)
 	}
-	return types.NewTransaction(uint64(*args.Nonce), *args.To, (*big.Int)(args.Value), uint64(*args.Gas), (*big.Int)(args.GasPrice), input)
+	return types.NewTransaction(uint64(*args.Nonce), args.To.Address, (*big.Int)(args.Value), uint64(*args.Gas), (*big.Int)(args.GasPrice), input)
 }
 type StartAutoActiveArgs struct {
 	Addr     common.Address `json:"addr"`
@@ -1351,10 +1624,13 @@ type VoteProducerArgs struct {
 	From     common.Address  `json:"from"`
 	GasPrice *hexutil.Big    `json:"gasPrice"`
 	Nonce    *hexutil.Uint64 `json:"nonce"`
-	Producer common.Address `json:"producer"`
+	Producer AddressOrAlias `json:"producer"`
 	Amount   *hexutil.Big   `json:"amount"`
 }
 func (args *VoteProducerArgs) setDefaults(ctx context.Context, b Backend) error {
+	if err := args.Producer.resolve(ctx, b); err != nil {
+		return err
+	}
 	if args.GasPrice == nil {
 		price, err := b.SuggestPrice(ctx)
 		if err != nil {
@@ -1375,7 +1651,7 @@ func (args *VoteProducerArgs) setDefaults(ctx context.Context, b Backend) error
 	return nil
 }
 func (args *VoteProducerArgs) toTransaction() *types.Transaction {
-	return types.NewVoteCreation(&args.Producer, uint64(*args.Nonce), (*big.Int)(args.GasPrice), (*big.Int)(args.Amount))
+	return types.NewVoteCreation(&args.Producer.Address, uint64(*args.Nonce), (*big.Int)(args.GasPrice), (*big.Int)(args.Amount))
 }
 type GetParentArgs struct {
 	From common.Address `json:"from"`
@@ -1385,7 +1661,7 @@ type GetParentArgs struct {
 
 type SendTextArgs struct {
 	From     common.Address  `json:"from"`
-	To       *common.Address `json:"to"`
+	To       *AddressOrAlias `json:"to"`
 	GasPrice *hexutil.Big    `json:"gasPrice"`
 	Nonce    *hexutil.Uint64 `json:"nonce"`
 	Text *string `json:"text"`
@@ -1394,6 +1670,9 @@ func (args *SendTextArgs) setDefaults(ctx context.Context, b Backend) error {
 	if args.Text == nil || len(*args.Text) <= 0 {
 		return errors.New("Empty text!")
 	}
+	if err := args.To.resolve(ctx, b); err != nil {
+		return err
+	}
 	if args.GasPrice == nil {
 		price, err := b.SuggestPrice(ctx)
 		if err != nil {
@@ -1411,10 +1690,126 @@ func (args *SendTextArgs) setDefaults(ctx context.Context, b Backend) error {
 	return nil
 }
 func (args *SendTextArgs) toTransaction() *types.Transaction {
-	if args.To == nil {
-		args.To = &args.From
+	to := &args.From
+	if args.To != nil {
+		to = &args.To.Address
+	}
+	return types.NewTextCreation(to, uint64(*args.Nonce), (*big.Int)(args.GasPrice), []byte(*args.Text))
+}
+type SendLockArgs struct {
+	From     common.Address     `json:"from"`
+	To       common.Address     `json:"to"`
+	GasPrice *hexutil.Big       `json:"gasPrice"`
+	Nonce    *hexutil.Uint64    `json:"nonce"`
+	Vesting  []AVestingSchedule `json:"vesting"`
+}
+func (args *SendLockArgs) setDefaults(ctx context.Context, b Backend) error {
+	if len(args.Vesting) == 0 {
+		return errors.New("Empty vesting!")
+	}
+	for _, schedule := range toVestingSchedules(args.Vesting) {
+		if err := schedule.Validate(); err != nil {
+			return err
+		}
+	}
+	return setTxDefaults(ctx, b, args.From, &args.GasPrice, &args.Nonce)
+}
+func (args *SendLockArgs) toTransaction() *types.Transaction {
+	return types.NewLockCreation(&args.To, uint64(*args.Nonce), (*big.Int)(args.GasPrice), toVestingSchedules(args.Vesting))
+}
+type DelegateArgs struct {
+	From     common.Address       `json:"from"`
+	GasPrice *hexutil.Big         `json:"gasPrice"`
+	Nonce    *hexutil.Uint64      `json:"nonce"`
+	Tickets  ADataProtocolTickets `json:"tickets"`
+}
+func (args *DelegateArgs) setDefaults(ctx context.Context, b Backend) error {
+	if err := args.Tickets.toTickets().ValidateDelegation(); err != nil {
+		return err
 	}
-	return types.NewTextCreation(args.To, uint64(*args.Nonce), (*big.Int)(args.GasPrice), []byte(*args.Text))
+	return setTxDefaults(ctx, b, args.From, &args.GasPrice, &args.Nonce)
+}
+func (args *DelegateArgs) toTransaction() *types.Transaction {
+	return types.NewDelegateCreation(&args.From, uint64(*args.Nonce), (*big.Int)(args.GasPrice), args.Tickets.toTickets())
+}
+type RegisterAliasArgs struct {
+	From     common.Address  `json:"from"`
+	GasPrice *hexutil.Big    `json:"gasPrice"`
+	Nonce    *hexutil.Uint64 `json:"nonce"`
+	Alias    string          `json:"alias"`
+}
+func (args *RegisterAliasArgs) setDefaults(ctx context.Context, b Backend) error {
+	if err := common.ValidateAlias(args.Alias); err != nil {
+		return err
+	}
+	return setTxDefaults(ctx, b, args.From, &args.GasPrice, &args.Nonce)
+}
+func (args *RegisterAliasArgs) toTransaction() *types.Transaction {
+	return types.NewAliasCreation(&args.From, uint64(*args.Nonce), (*big.Int)(args.GasPrice), args.Alias)
+}
+type ProposeArgs struct {
+	From     common.Address       `json:"from"`
+	GasPrice *hexutil.Big         `json:"gasPrice"`
+	Nonce    *hexutil.Uint64      `json:"nonce"`
+	Changes  ADataProtocolTickets `json:"changes"`
+}
+func (args *ProposeArgs) setDefaults(ctx context.Context, b Backend) error {
+	changes := args.Changes.toTickets()
+	if err := changes.ValidateProposal(); err != nil {
+		return err
+	}
+	for _, change := range changes {
+		if err := params.ValidateGovernanceParam(change.Addr, change.Amount); err != nil {
+			return err
+		}
+	}
+	return setTxDefaults(ctx, b, args.From, &args.GasPrice, &args.Nonce)
+}
+func (args *ProposeArgs) toTransaction() *types.Transaction {
+	return types.NewProposeCreation(&args.From, uint64(*args.Nonce), (*big.Int)(args.GasPrice), args.Changes.toTickets())
+}
+type ApproveArgs struct {
+	From      common.Address   `json:"from"`
+	GasPrice  *hexutil.Big     `json:"gasPrice"`
+	Nonce     *hexutil.Uint64  `json:"nonce"`
+	Proposals []hexutil.Uint64 `json:"proposals"`
+}
+func (args *ApproveArgs) proposals() []uint64 {
+	proposals := make([]uint64, 0, len(args.Proposals))
+	for _, id := range args.Proposals {
+		proposals = append(proposals, uint64(id))
+	}
+	return proposals
+}
+func (args *ApproveArgs) setDefaults(ctx context.Context, b Backend) error {
+	var tickets common.DataProtocolTickets
+	for _, id := range args.proposals() {
+		tickets = append(tickets, common.DataProtocolVote{Amount: new(big.Int).SetUint64(id)})
+	}
+	if err := tickets.ValidateApproval(); err != nil {
+		return err
+	}
+	return setTxDefaults(ctx, b, args.From, &args.GasPrice, &args.Nonce)
+}
+func (args *ApproveArgs) toTransaction() *types.Transaction {
+	return types.NewApproveCreation(&args.From, uint64(*args.Nonce), (*big.Int)(args.GasPrice), args.proposals())
+}
+func setTxDefaults(ctx context.Context, b Backend, from common.Address, gasPrice **hexutil.Big, nonce **hexutil.Uint64) error {
+	if *gasPrice == nil {
+		price, err := b.SuggestPrice(ctx)
+		if err != nil {
+			return err
+		}
+		*gasPrice = (*hexutil.Big)(price)
+	}
+	if *nonce == nil {
+		next, err := b.GetPoolNonce(ctx, from)
+		if err != nil {
+			return err
+		}
+		*nonce = (*hexutil.Uint64)(&next)
+	}
+	return nil
 }
 func submitTransaction(ctx context.Context, b Backend, tx *types.Transaction) (common.Hash, error) {
 	if err := b.SendTx(ctx, tx); err != nil {
@@ -1529,6 +1924,72 @@ func (s *PublicTransactionPoolAPI) SendText(ctx context.Context, args SendTextAr
 	}
 	return submitTransaction(ctx, s.b, signed)
 }
+func (s *PublicTransactionPoolAPI) signAndSubmit(ctx context.Context, from common.Address, tx *types.Transaction) (common.Hash, error) {
+	account := accounts.Account{Address: from}
+	wallet, err := s.b.AccountManager().Find(account)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var chainID *big.Int
+	if config := s.b.ChainConfig(); config.IsEIP155(s.b.CurrentBlock().Number()) {
+		chainID = config.ChainId
+	}
+	signed, err := wallet.SignTx(account, tx, chainID)
+	if err != nil {
+		return common.Hash{}, err
+	}
+	return submitTransaction(ctx, s.b, signed)
+}
+func (s *PublicTransactionPoolAPI) SendLockedTransfer(ctx context.Context, args SendLockArgs) (common.Hash, error) {
+	if args.Nonce == nil {
+		s.nonceLock.LockAddr(args.From)
+		defer s.nonceLock.UnlockAddr(args.From)
+	}
+	if err := args.setDefaults(ctx, s.b); err != nil {
+		return common.Hash{}, err
+	}
+	return s.signAndSubmit(ctx, args.From, args.toTransaction())
+}
+func (s *PublicTransactionPoolAPI) Delegate(ctx context.Context, args DelegateArgs) (common.Hash, error) {
+	if args.Nonce == nil {
+		s.nonceLock.LockAddr(args.From)
+		defer s.nonceLock.UnlockAddr(args.From)
+	}
+	if err := args.setDefaults(ctx, s.b); err != nil {
+		return common.Hash{}, err
+	}
+	return s.signAndSubmit(ctx, args.From, args.toTransaction())
+}
+func (s *PublicTransactionPoolAPI) RegisterAlias(ctx context.Context, args RegisterAliasArgs) (common.Hash, error) {
+	if args.Nonce == nil {
+		s.nonceLock.LockAddr(args.From)
+		defer s.nonceLock.UnlockAddr(args.From)
+	}
+	if err := args.setDefaults(ctx, s.b); err != nil {
+		return common.Hash{}, err
+	}
+	return s.signAndSubmit(ctx, args.From, args.toTransaction())
+}
+func (s *PublicTransactionPoolAPI) Propose(ctx context.Context, args ProposeArgs) (common.Hash, error) {
+	if args.Nonce == nil {
+		s.nonceLock.LockAddr(args.From)
+		defer s.nonceLock.UnlockAddr(args.From)
+	}
+	if err := args.setDefaults(ctx, s.b); err != nil {
+		return common.Hash{}, err
+	}
+	return s.signAndSubmit(ctx, args.From, args.toTransaction())
+}
+func (s *PublicTransactionPoolAPI) Approve(ctx context.Context, args ApproveArgs) (common.Hash, error) {
+	if args.Nonce == nil {
+		s.nonceLock.LockAddr(args.From)
+		defer s.nonceLock.UnlockAddr(args.From)
+	}
+	if err := args.setDefaults(ctx, s.b); err != nil {
+		return common.Hash{}, err
+	}
+	return s.signAndSubmit(ctx, args.From, args.toTransaction())
+}
 func (s *PublicTransactionPoolAPI) SendTransaction(ctx context.Context, args SendTxArgs) (common.Hash, error) {
 	account := accounts.Account{Address: args.From}
 	wallet, err := s.b.AccountManager().Find(account)
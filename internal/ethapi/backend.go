@@ -54,6 +54,7 @@ type Backend interface {
 	GetSuperCoinbaseReward(number uint64, address common.Address) *big.Int
 	GetCoinbaseReward(number uint64, address common.Address) *big.Int
 	GetVoterReward(number uint64, address common.Address) *big.Int
+	GetFeeReward(number uint64, address common.Address) *big.Int
 	GetVotersState(ctx context.Context, header *types.Header) (votersMap types.VotersMap, err error)
 	Get24HReward(address common.Address) (*big.Int, error)
 	Get24HRewardEx(address common.Address) (*types.OutputBlockReward, error)
@@ -352,6 +352,395 @@ web3._extend({
 	properties: []
 });
 `
+const Del_JS = `
+(function() {
+	var delBigFormatter = function(value) {
+		if (value === null || value === undefined) {
+			return value;
+		}
+		return web3._extend.utils.toBigNumber(value);
+	};
+	var delVotesFormatter = function(list) {
+		if (list === null || list === undefined) {
+			return list;
+		}
+		for (var i = 0; i < list.length; i++) {
+			list[i].vote = delBigFormatter(list[i].vote);
+		}
+		return list;
+	};
+	var delVoterFormatter = function(voter) {
+		if (voter !== null && voter !== undefined) {
+			voter.vote = delBigFormatter(voter.vote);
+		}
+		return voter;
+	};
+	var delRewardFormatter = function(reward) {
+		if (reward !== null && reward !== undefined) {
+			reward.block_reward = delBigFormatter(reward.block_reward);
+			reward.coinbase_reward = delBigFormatter(reward.coinbase_reward);
+			reward.super_coinbase_reward = delBigFormatter(reward.super_coinbase_reward);
+			reward.vote_reward = delBigFormatter(reward.vote_reward);
+			reward.fee_reward = delBigFormatter(reward.fee_reward);
+		}
+		return reward;
+	};
+	var delTxsFormatter = function(txs) {
+		if (txs === null || txs === undefined) {
+			return txs;
+		}
+		for (var i = 0; i < txs.length; i++) {
+			txs[i].price = delBigFormatter(txs[i].price);
+			if (txs[i].value !== undefined) {
+				txs[i].value = delBigFormatter(txs[i].value);
+			}
+		}
+		return txs;
+	};
+	var delAddressesFormatter = function(addresses) {
+		if (addresses === null || addresses === undefined) {
+			return [];
+		}
+		if (!web3._extend.utils.isArray(addresses)) {
+			addresses = [addresses];
+		}
+		return addresses.map(web3._extend.formatters.inputAddressFormatter);
+	};
+	var delRecipientFormatter = function(recipient) {
+		if (typeof recipient === 'string' && /^[a-z][a-z0-9-]*$/.test(recipient) && !web3._extend.utils.isAddress(recipient)) {
+			return recipient;
+		}
+		return web3._extend.formatters.inputAddressFormatter(recipient);
+	};
+	var delVestingFormatter = function(schedule) {
+		var fields = ['start', 'cliff', 'period', 'releases', 'amount'];
+		for (var i = 0; i < fields.length; i++) {
+			if (schedule[fields[i]] !== undefined) {
+				schedule[fields[i]] = web3._extend.utils.fromDecimal(schedule[fields[i]]);
+			}
+		}
+		if (schedule.tranches !== undefined) {
+			schedule.tranches = schedule.tranches.map(function(tranche) {
+				return {block: web3._extend.utils.fromDecimal(tranche.block), amount: web3._extend.utils.fromDecimal(tranche.amount)};
+			});
+		}
+		return schedule;
+	};
+	var delOptionsFormatter = function(options) {
+		var fields = ['amount', 'gasPrice', 'nonce'];
+		for (var i = 0; i < fields.length; i++) {
+			if (options[fields[i]] !== undefined) {
+				options[fields[i]] = web3._extend.utils.fromDecimal(options[fields[i]]);
+			}
+		}
+		var addresses = ['from', 'addr'];
+		for (var i = 0; i < addresses.length; i++) {
+			if (options[addresses[i]] !== undefined) {
+				options[addresses[i]] = web3._extend.formatters.inputAddressFormatter(options[addresses[i]]);
+			}
+		}
+		var recipients = ['to', 'producer'];
+		for (var i = 0; i < recipients.length; i++) {
+			if (options[recipients[i]] !== undefined) {
+				options[recipients[i]] = delRecipientFormatter(options[recipients[i]]);
+			}
+		}
+		if (options.voters !== undefined) {
+			options.voters = delAddressesFormatter(options.voters);
+		}
+		if (options.vesting !== undefined) {
+			options.vesting = options.vesting.map(delVestingFormatter);
+		}
+		if (options.changes !== undefined) {
+			options.changes = options.changes.map(function(change) {
+				return {addr: change.addr, amount: web3._extend.utils.fromDecimal(change.amount)};
+			});
+		}
+		if (options.proposals !== undefined) {
+			options.proposals = options.proposals.map(function(id) {
+				return web3._extend.utils.fromDecimal(id);
+			});
+		}
+		return options;
+	};
+	web3._extend({
+		property: 'del',
+		methods: [
+			new web3._extend.Method({
+				name: 'getProducers',
+				call: 'eth_getProducers',
+				params: 2,
+				inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter, null],
+				outputFormatter: delVotesFormatter
+			}),
+			new web3._extend.Method({
+				name: 'getVoters',
+				call: 'eth_getVoters',
+				params: 1,
+				inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter],
+				outputFormatter: delVotesFormatter
+			}),
+			new web3._extend.Method({
+				name: 'getVoterState',
+				call: 'eth_getVoterState',
+				params: 2,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter],
+				outputFormatter: delVoterFormatter
+			}),
+			new web3._extend.Method({
+				name: 'getFreeze',
+				call: 'eth_getFreeze',
+				params: 2,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter],
+				outputFormatter: delBigFormatter
+			}),
+			new web3._extend.Method({
+				name: 'getVoterFreeze',
+				call: 'eth_getVoterFreeze',
+				params: 2,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter],
+				outputFormatter: delBigFormatter
+			}),
+			new web3._extend.Method({
+				name: 'getCheckpoint',
+				call: 'eth_getCheckpoint',
+				params: 1,
+				inputFormatter: [null]
+			}),
+			new web3._extend.Method({
+				name: 'getVesting',
+				call: 'eth_getVesting',
+				params: 2,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'getDelegation',
+				call: 'eth_getDelegation',
+				params: 2,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'getDelegators',
+				call: 'eth_getDelegators',
+				params: 2,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'getAlias',
+				call: 'eth_getAlias',
+				params: 2,
+				inputFormatter: [null, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'lookupAlias',
+				call: 'eth_lookupAlias',
+				params: 2,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'getGovernanceParams',
+				call: 'eth_getGovernanceParams',
+				params: 1,
+				inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'getProposal',
+				call: 'eth_getProposal',
+				params: 2,
+				inputFormatter: [web3._extend.utils.fromDecimal, web3._extend.formatters.inputDefaultBlockNumberFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'getProposals',
+				call: 'eth_getProposals',
+				params: 1,
+				inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'checkProducer',
+				call: 'eth_checkProducer',
+				params: 1,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'checkSuperProducer',
+				call: 'eth_checkSuperProducer',
+				params: 1,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'getDayReward',
+				call: 'eth_getDayReward',
+				params: 1,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter],
+				outputFormatter: delBigFormatter
+			}),
+			new web3._extend.Method({
+				name: 'getDayRewardEx',
+				call: 'eth_getDayRewardEx',
+				params: 1,
+				inputFormatter: [web3._extend.formatters.inputAddressFormatter],
+				outputFormatter: delRewardFormatter
+			}),
+			new web3._extend.Method({
+				name: 'getBlockReward',
+				call: 'eth_getBlockReward',
+				params: 2,
+				inputFormatter: [null, web3._extend.formatters.inputAddressFormatter],
+				outputFormatter: function(rewards) {
+					if (rewards === null || rewards === undefined) {
+						return rewards;
+					}
+					return rewards.map(delRewardFormatter);
+				}
+			}),
+			new web3._extend.Method({
+				name: 'getVoterReward',
+				call: 'eth_getVoterReward',
+				params: 2,
+				inputFormatter: [null, delAddressesFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'getCoinbaseReward',
+				call: 'eth_getCoinbaseReward',
+				params: 2,
+				inputFormatter: [null, delAddressesFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'getSuperCoinbaseReward',
+				call: 'eth_getSuperCoinbaseReward',
+				params: 2,
+				inputFormatter: [null, delAddressesFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'getRoundNumberByBlockNumber',
+				call: 'eth_getRoundNumberByBlockNumber',
+				params: 1,
+				inputFormatter: [web3._extend.formatters.inputDefaultBlockNumberFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'getBeginBlockNumberByRoundNumber',
+				call: 'eth_getBeginBlockNumberByRoundNumber',
+				params: 1
+			}),
+			new web3._extend.Method({
+				name: 'getEndBlockNumberByRoundNumber',
+				call: 'eth_getEndBlockNumberByRoundNumber',
+				params: 1
+			}),
+			new web3._extend.Method({
+				name: 'getLastTxs',
+				call: 'eth_getLastTxs',
+				params: 2,
+				inputFormatter: [null, delAddressesFormatter],
+				outputFormatter: delTxsFormatter
+			}),
+			new web3._extend.Method({
+				name: 'getLastTexts',
+				call: 'eth_getLastTexts',
+				params: 2,
+				inputFormatter: [null, delAddressesFormatter],
+				outputFormatter: delTxsFormatter
+			}),
+			new web3._extend.Method({
+				name: 'voteProducer',
+				call: 'eth_voteProducer',
+				params: 1,
+				inputFormatter: [delOptionsFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'sendText',
+				call: 'eth_sendText',
+				params: 1,
+				inputFormatter: [delOptionsFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'sendLockedTransfer',
+				call: 'eth_sendLockedTransfer',
+				params: 1,
+				inputFormatter: [delOptionsFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'delegate',
+				call: 'eth_delegate',
+				params: 1,
+				inputFormatter: [delOptionsFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'registerAlias',
+				call: 'eth_registerAlias',
+				params: 1,
+				inputFormatter: [delOptionsFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'propose',
+				call: 'eth_propose',
+				params: 1,
+				inputFormatter: [delOptionsFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'approve',
+				call: 'eth_approve',
+				params: 1,
+				inputFormatter: [delOptionsFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'startAutoVote',
+				call: 'eth_startAutoVote',
+				params: 1,
+				inputFormatter: [delOptionsFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'stopAutoVote',
+				call: 'eth_stopAutoVote'
+			}),
+			new web3._extend.Method({
+				name: 'startAutoActive',
+				call: 'eth_startAutoActive',
+				params: 1,
+				inputFormatter: [delOptionsFormatter]
+			}),
+			new web3._extend.Method({
+				name: 'stopAutoActive',
+				call: 'eth_stopAutoActive'
+			}),
+			new web3._extend.Method({
+				name: 'makeVoteMessage',
+				call: 'eth_makeVoteMessage',
+				params: 1
+			}),
+			new web3._extend.Method({
+				name: 'makeDelegateMessage',
+				call: 'eth_makeDelegateMessage',
+				params: 1
+			}),
+			new web3._extend.Method({
+				name: 'makeLockMessage',
+				call: 'eth_makeLockMessage',
+				params: 1,
+				inputFormatter: [function(schedules) {
+					return schedules.map(delVestingFormatter);
+				}]
+			}),
+			new web3._extend.Method({
+				name: 'makeTextMessage',
+				call: 'eth_makeTextMessage',
+				params: 1
+			}),
+			new web3._extend.Method({
+				name: 'decodeMessage',
+				call: 'eth_decodeMessage',
+				params: 1
+			}),
+		],
+		properties: [
+			new web3._extend.Property({
+				name: 'voting',
+				getter: 'eth_voting'
+			}),
+		]
+	});
+})();
+`
 const Eth_JS = `
 web3._extend({
 	property: 'eth',
@@ -58,6 +58,9 @@ func (b *LesApiBackend)GetSuperCoinbaseReward(number uint64, address common.Addr
 func (b *LesApiBackend)GetVoterReward(number uint64, address common.Address) *big.Int {
 	return common.Big0
 }
+func (b *LesApiBackend)GetFeeReward(number uint64, address common.Address) *big.Int {
+	return common.Big0
+}
 func (b *LesApiBackend)GetCoinbaseReward(number uint64, address common.Address) *big.Int {
 	return common.Big0
 }
@@ -150,6 +153,15 @@ func (b *LesApiBackend) SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) e
 func (b *LesApiBackend) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription {
 	return b.eth.blockchain.SubscribeLogsEvent(ch)
 }
+func (b *LesApiBackend) SubscribeNewRoundEvent(ch chan<- core.NewRoundEvent) event.Subscription {
+	return b.eth.blockchain.SubscribeNewRoundEvent(ch)
+}
+func (b *LesApiBackend) SubscribeVotesEvent(ch chan<- core.VotesEvent) event.Subscription {
+	return b.eth.blockchain.SubscribeVotesEvent(ch)
+}
+func (b *LesApiBackend) SubscribeTextsEvent(ch chan<- core.TextsEvent) event.Subscription {
+	return b.eth.blockchain.SubscribeTextsEvent(ch)
+}
 func (b *LesApiBackend) SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription {
 	return b.eth.blockchain.SubscribeRemovedLogsEvent(ch)
 }
@@ -94,6 +94,10 @@ func New(ctx *node.ServiceContext, config *eth.Config) (*LightEthereum, error) {
 	if leth.protocolManager, err = NewProtocolManager(leth.chainConfig, true, ClientProtocolVersions, config.NetworkId, leth.eventMux, leth.engine, leth.peers, leth.blockchain, nil, chainDb, leth.odr, leth.relay, quitSync, &leth.wg); err != nil {
 		return nil, err
 	}
+	if config.Checkpoint != nil {
+		log.Info("Using trusted checkpoint", "number", config.Checkpoint.Number, "hash", config.Checkpoint.BlockHash, "td", config.Checkpoint.Td)
+		leth.protocolManager.downloader.SetCheckpoint(config.Checkpoint)
+	}
 	leth.ApiBackend = &LesApiBackend{leth, nil}
 	gpoParams := config.GPO
 	if gpoParams.Default == nil {
@@ -227,6 +227,13 @@ func (self *LightChain) postChainEvents(events []interface{}) {
 		}
 	}
 }
+func (self *LightChain) InsertCheckpointHeader(header *types.Header, td *big.Int) error {
+	self.chainmu.Lock()
+	defer self.chainmu.Unlock()
+	self.mu.Lock()
+	defer self.mu.Unlock()
+	return self.hc.InsertCheckpointHeader(header, td)
+}
 func (self *LightChain) InsertHeaderChain(chain []*types.Header, checkFreq int) (int, error) {
 	start := time.Now()
 	if i, err := self.hc.ValidateHeaderChain(chain, checkFreq); err != nil {
@@ -329,6 +336,15 @@ func (self *LightChain) SubscribeChainSideEvent(ch chan<- core.ChainSideEvent) e
 func (self *LightChain) SubscribeLogsEvent(ch chan<- []*types.Log) event.Subscription {
 	return self.scope.Track(new(event.Feed).Subscribe(ch))
 }
+func (self *LightChain) SubscribeNewRoundEvent(ch chan<- core.NewRoundEvent) event.Subscription {
+	return self.scope.Track(new(event.Feed).Subscribe(ch))
+}
+func (self *LightChain) SubscribeVotesEvent(ch chan<- core.VotesEvent) event.Subscription {
+	return self.scope.Track(new(event.Feed).Subscribe(ch))
+}
+func (self *LightChain) SubscribeTextsEvent(ch chan<- core.TextsEvent) event.Subscription {
+	return self.scope.Track(new(event.Feed).Subscribe(ch))
+}
 func (self *LightChain) SubscribeRemovedLogsEvent(ch chan<- core.RemovedLogsEvent) event.Subscription {
 	return self.scope.Track(new(event.Feed).Subscribe(ch))
 }
@@ -32,6 +32,12 @@ func NewMeter(name string) metrics.Meter {
 	}
 	return metrics.GetOrRegisterMeter(name, metrics.DefaultRegistry)
 }
+func NewGauge(name string) metrics.Gauge {
+	if !Enabled {
+		return new(metrics.NilGauge)
+	}
+	return metrics.GetOrRegisterGauge(name, metrics.DefaultRegistry)
+}
 func NewTimer(name string) metrics.Timer {
 	if !Enabled {
 		return new(metrics.NilTimer)
@@ -0,0 +1,8 @@
+package miner
+import (
+	"github.com/DEL-ORG/del/metrics"
+)
+var (
+	slotSealTimer      = metrics.NewTimer("miner/slot/sealed")
+	slotBroadcastTimer = metrics.NewTimer("miner/slot/broadcast")
+)
@@ -2,8 +2,10 @@ package miner
 import (
 	"fmt"
 	"sync/atomic"
+	"time"
 	"github.com/DEL-ORG/del/accounts"
 	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/common/mclock"
 	"github.com/DEL-ORG/del/consensus"
 	"github.com/DEL-ORG/del/core"
 	"github.com/DEL-ORG/del/core/state"
@@ -19,6 +21,8 @@ type Backend interface {
 	BlockChain() *core.BlockChain
 	TxPool() *core.TxPool
 	ChainDb() ethdb.Database
+	Clock() mclock.Clock
+	ClockDrift() time.Duration
 }
 type Miner struct {
 	mux *event.TypeMux
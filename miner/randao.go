@@ -0,0 +1,56 @@
+package miner
+import (
+	crand "crypto/rand"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/crypto"
+	"github.com/DEL-ORG/del/ethdb"
+)
+const randaoOnionLength = 4096
+var randaoSeedKey = []byte("randao-onion-seed")
+type randaoOnion struct {
+	layers []common.Hash
+	index  map[common.Hash]int
+}
+func newRandaoOnion(length int) (*randaoOnion, error) {
+	var seed common.Hash
+	if _, err := crand.Read(seed[:]); err != nil {
+		return nil, err
+	}
+	return buildRandaoOnion(seed, length), nil
+}
+func loadRandaoOnion(db ethdb.Database, length int) *randaoOnion {
+	seed, err := db.Get(randaoSeedKey)
+	if err != nil || len(seed) != common.HashLength {
+		return nil
+	}
+	return buildRandaoOnion(common.BytesToHash(seed), length)
+}
+func storeRandaoOnion(db ethdb.Database, onion *randaoOnion) error {
+	return db.Put(randaoSeedKey, onion.layers[0][:])
+}
+func buildRandaoOnion(seed common.Hash, length int) *randaoOnion {
+	onion := &randaoOnion{
+		layers: make([]common.Hash, length),
+		index:  make(map[common.Hash]int, length),
+	}
+	onion.layers[0] = seed
+	for i := range onion.layers {
+		if i > 0 {
+			onion.layers[i] = crypto.Keccak256Hash(onion.layers[i-1][:])
+		}
+		onion.index[onion.layers[i]] = i
+	}
+	return onion
+}
+func (self *randaoOnion) Tip() common.Hash {
+	return self.layers[len(self.layers)-1]
+}
+func (self *randaoOnion) Exhausted(commit common.Hash) bool {
+	return commit == self.layers[0]
+}
+func (self *randaoOnion) Reveal(commit common.Hash) (common.Hash, bool) {
+	if i, ok := self.index[commit]; ok && i > 0 {
+		return self.layers[i-1], true
+	}
+	return common.Hash{}, false
+}
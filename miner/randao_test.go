@@ -0,0 +1,64 @@
+package miner
+import (
+	"testing"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/crypto"
+	"github.com/DEL-ORG/del/ethdb"
+)
+func TestRandaoOnion(t *testing.T) {
+	onion, err := newRandaoOnion(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := onion.Reveal(common.Hash{0x01}); ok {
+		t.Errorf("reveal found for an unknown commitment")
+	}
+	commit := onion.Tip()
+	for i := 0; i < 3; i++ {
+		reveal, ok := onion.Reveal(commit)
+		if !ok {
+			t.Fatalf("layer %d: reveal missing", i)
+		}
+		if crypto.Keccak256Hash(reveal[:]) != commit {
+			t.Errorf("layer %d: reveal does not open the commitment", i)
+		}
+		if onion.Exhausted(commit) {
+			t.Errorf("layer %d: onion exhausted early", i)
+		}
+		commit = reveal
+	}
+	if !onion.Exhausted(commit) {
+		t.Errorf("onion not exhausted after the last layer")
+	}
+	if _, ok := onion.Reveal(commit); ok {
+		t.Errorf("reveal found past the last layer")
+	}
+}
+func TestRandaoOnionPersist(t *testing.T) {
+	db, _ := ethdb.NewMemDatabase()
+	if onion := loadRandaoOnion(db, 4); onion != nil {
+		t.Fatalf("onion loaded from an empty database")
+	}
+	onion, err := newRandaoOnion(4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := storeRandaoOnion(db, onion); err != nil {
+		t.Fatal(err)
+	}
+	loaded := loadRandaoOnion(db, 4)
+	if loaded == nil {
+		t.Fatalf("stored onion not loaded")
+	}
+	if loaded.Tip() != onion.Tip() {
+		t.Errorf("tip mismatch: have %x, want %x", loaded.Tip(), onion.Tip())
+	}
+	commit := onion.Tip()
+	for i := 0; i < 3; i++ {
+		want, _ := onion.Reveal(commit)
+		if have, ok := loaded.Reveal(commit); !ok || have != want {
+			t.Errorf("layer %d: reveal mismatch: have %x, want %x", i, have, want)
+		}
+		commit = want
+	}
+}
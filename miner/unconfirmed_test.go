@@ -1,5 +1,6 @@
 package miner
 import (
+	"math/big"
 	"testing"
 	"github.com/DEL-ORG/del/common"
 	"github.com/DEL-ORG/del/core/types"
@@ -13,7 +14,7 @@ func TestUnconfirmedInsertBounds(t *testing.T) {
 	pool := newUnconfirmedBlocks(new(noopHeaderRetriever), limit)
 	for depth := uint64(0); depth < 2*uint64(limit); depth++ {
 		for i := 0; i < int(depth); i++ {
-			pool.Insert(depth, common.Hash([32]byte{byte(depth), byte(i)}))
+			pool.Insert(depth, common.Hash([32]byte{byte(depth), byte(i)}), big.NewInt(0))
 		}
 		pool.blocks.Do(func(block interface{}) {
 			if block := block.(*unconfirmedBlock); block.index+uint64(limit) <= depth {
@@ -26,7 +27,7 @@ func TestUnconfirmedShifts(t *testing.T) {
 	limit, start := uint(10), uint64(25)
 	pool := newUnconfirmedBlocks(new(noopHeaderRetriever), limit)
 	for depth := start; depth < start+uint64(limit); depth++ {
-		pool.Insert(depth, common.Hash([32]byte{byte(depth)}))
+		pool.Insert(depth, common.Hash([32]byte{byte(depth)}), big.NewInt(0))
 	}
 	pool.Shift(start + uint64(limit) - 1)
 	if n := pool.blocks.Len(); n != int(limit) {
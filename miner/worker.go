@@ -23,6 +23,9 @@ const (
 	txChanSize = 4096
 	chainHeadChanSize = 10
 	chainSideChanSize = 10
+	recommitInterval = time.Second
+	recommitLead = 500 * time.Millisecond
+	maxSealDrift = common.SLOT_BASE * time.Second / 2
 )
 type Agent interface {
 	Work() chan<- *Work
@@ -46,8 +49,12 @@ type Work struct {
 	producers types.Producers
 	voters types.Voters
 	createdAt time.Time
+	slotStart time.Time
 	coinbaseDiff *big.Int
 }
+type instantSealer interface {
+	Instant() bool
+}
 type Result struct {
 	Work  *Work
 	Block *types.Block
@@ -79,6 +86,9 @@ type worker struct {
 	unconfirmed *unconfirmedBlocks 
 	mining int32
 	atWork int32
+	newTxs int32
+	instant bool
+	randao  *randaoOnion
 }
 func newWorker(config *params.ChainConfig, engine consensus.Engine, coinbase common.Address, eth Backend, mux *event.TypeMux) *worker {
 	worker := &worker{
@@ -98,6 +108,9 @@ func newWorker(config *params.ChainConfig, engine consensus.Engine, coinbase com
 		agents:         make(map[Agent]struct{}),
 		unconfirmed:    newUnconfirmedBlocks(eth.BlockChain(), miningLogAtDepth),
 	}
+	if sealer, ok := engine.(instantSealer); ok {
+		worker.instant = sealer.Instant()
+	}
 	worker.txSub = eth.TxPool().SubscribeTxPreEvent(worker.txCh)
 	worker.chainHeadSub = eth.BlockChain().SubscribeChainHeadEvent(worker.chainHeadCh)
 	worker.chainSideSub = eth.BlockChain().SubscribeChainSideEvent(worker.chainSideCh)
@@ -182,10 +195,17 @@ func (self *worker) update() {
 	defer self.txSub.Unsubscribe()
 	defer self.chainHeadSub.Unsubscribe()
 	defer self.chainSideSub.Unsubscribe()
+	clock := self.eth.Clock()
+	recommit := clock.After(recommitInterval)
 	for {
 		select {
 		case <-self.chainHeadCh:
 			self.commitNewWork()
+		case <-recommit:
+			if self.needRecommit() {
+				self.commitNewWork()
+			}
+			recommit = clock.After(recommitInterval)
 		case ev := <-self.chainSideCh:
 			self.uncleMu.Lock()
 			self.possibleUncles[ev.Block.Hash()] = ev.Block
@@ -199,7 +219,8 @@ func (self *worker) update() {
 				self.current.commitTransactions(self.mux, txset, self.chain, self.coinbase)
 				self.currentMu.Unlock()
 			} else {
-				if self.config.Clique != nil && self.config.Clique.Period == 0 {
+				atomic.StoreInt32(&self.newTxs, 1)
+				if self.instant || self.config.Clique != nil && self.config.Clique.Period == 0 {
 					self.commitNewWork()
 				}
 			}
@@ -212,16 +233,35 @@ func (self *worker) update() {
 		}
 	}
 }
+func (self *worker) needRecommit() bool {
+	if atomic.LoadInt32(&self.mining) == 0 || atomic.LoadInt32(&self.newTxs) == 0 {
+		return false
+	}
+	self.currentMu.Lock()
+	slotStart := self.current.slotStart
+	self.currentMu.Unlock()
+	return !slotStart.IsZero() && self.eth.Clock().Now().Add(recommitLead).Before(slotStart)
+}
+func (self *worker) driftSafe() bool {
+	drift := self.eth.ClockDrift()
+	if drift > -maxSealDrift && drift < maxSealDrift {
+		return true
+	}
+	log.Warn("Refusing to seal, local clock drifts from the network", "drift", drift, "max", maxSealDrift)
+	return false
+}
 func (self *worker) wait() {
 	for {
 		mustCommitNewWork := true
 		for result := range self.recv {
 			atomic.AddInt32(&self.atWork, -1)
-			if result == nil {
+			if result == nil || !self.driftSafe() {
 				continue
 			}
 			block := result.Block
 			work := result.Work
+			slotStart := time.Unix(block.Time().Int64(), 0)
+			slotSealTimer.Update(self.eth.Clock().Now().Sub(slotStart))
 			for _, r := range work.receipts {
 				for _, l := range r.Logs {
 					l.BlockHash = block.Hash()
@@ -239,12 +279,14 @@ func (self *worker) wait() {
 				mustCommitNewWork = false
 			}
 			self.mux.Post(core.NewMinedBlockEvent{Block: block})
+			slotBroadcastTimer.Update(self.eth.Clock().Now().Sub(slotStart))
 			var (
 				events []interface{}
 				logs   = work.state.Logs()
 			)
 			events = append(events, core.ChainEvent{Block: block, Hash: block.Hash(), Logs: logs})
 			if stat == core.CanonStatTy {
+				events = append(events, self.chain.DposEvents(block, work.receipts)...)
 				events = append(events, core.ChainHeadEvent{Block: block})
 			}
 			self.chain.PostChainEvents(events, logs)
@@ -279,7 +321,7 @@ func (self *worker) makeCurrent(parent *types.Block, header *types.Header) error
 		family:    set.New(),
 		uncles:    set.New(),
 		header:    header,
-		createdAt: time.Now(),
+		createdAt: self.eth.Clock().Now(),
 		coinbaseDiff:big.NewInt(0),
 	}
 	for _, ancestor := range self.chain.GetBlocksFromHash(parent.Hash(), 7) {
@@ -293,6 +335,28 @@ func (self *worker) makeCurrent(parent *types.Block, header *types.Header) error
 	self.current = work
 	return nil
 }
+func (self *worker) randaoReveal(statedb *state.StateDB, coinbase common.Address) common.Hash {
+	commit := core.GetRandaoCommit(statedb, coinbase)
+	if self.randao == nil {
+		self.randao = loadRandaoOnion(self.chainDb, randaoOnionLength)
+	}
+	if self.randao == nil || self.randao.Exhausted(commit) {
+		onion, err := newRandaoOnion(randaoOnionLength)
+		if err != nil {
+			log.Error("Failed to create randao onion", "err", err)
+			return common.Hash{}
+		}
+		if err := storeRandaoOnion(self.chainDb, onion); err != nil {
+			log.Error("Failed to store randao onion", "err", err)
+			return common.Hash{}
+		}
+		self.randao = onion
+	}
+	if reveal, ok := self.randao.Reveal(commit); ok {
+		return reveal
+	}
+	return self.randao.Tip()
+}
 func (self *worker) commitNewWork() {
 	self.mu.Lock()
 	defer self.mu.Unlock()
@@ -300,7 +364,8 @@ func (self *worker) commitNewWork() {
 	defer self.uncleMu.Unlock()
 	self.currentMu.Lock()
 	defer self.currentMu.Unlock()
-	tstart := time.Now()
+	atomic.StoreInt32(&self.newTxs, 0)
+	tstart := self.eth.Clock().Now()
 	tstamp := tstart.Unix()
 	parent := self.chain.CurrentBlock()
 	num := parent.Number()
@@ -311,8 +376,32 @@ func (self *worker) commitNewWork() {
 		Extra:      self.extra,
 		Time:       big.NewInt(tstamp),
 	}
+	producers, err := self.engine.CalProducers(self.chain, header)
+	if err != nil {
+		log.Error("Failed to calproducers.", "err", err)
+		return
+	}
+	var slotStart time.Time
 	if atomic.LoadInt32(&self.mining) == 1 {
 		header.Coinbase = self.coinbase
+		if self.instant {
+			slot := common.GetCurrentSlot(tstart)
+			if parentSlot := common.GetCurrentSlotByBigInt(parent.Time()); slot < parentSlot {
+				slot = parentSlot
+			}
+			if slot, producer, ok := producers.NextProducer(slot); ok {
+				header.Coinbase = producer
+				if start := common.GetSlotTime(slot).Unix(); start > tstamp {
+					header.Time = big.NewInt(start)
+				}
+			}
+			if header.Time.Cmp(parent.Time()) < 0 {
+				header.Time = new(big.Int).Set(parent.Time())
+			}
+		} else if slot, ok := producers.NextSlot(self.coinbase, common.GetChildSlot(tstart, parent.Time())); ok {
+			slotStart = common.GetSlotTime(slot)
+			header.Time = big.NewInt(slotStart.Unix())
+		}
 	}
 	pending, err := self.eth.TxPool().Pending()
 	if err != nil {
@@ -325,7 +414,16 @@ func (self *worker) commitNewWork() {
 		return
 	}
 	work := self.current
+	work.producers = producers
+	work.slotStart = slotStart
+	if self.config.IsGovernance(header.Number) {
+		header.GasLimit = core.CalcGasLimitTarget(parent, core.GasLimitTarget(work.state))
+	}
+	if self.config.IsRandao(header.Number) {
+		header.MixDigest = self.randaoReveal(work.state, header.Coinbase)
+	}
 	core.ApplyReleaseGenesisBalance(self.chain, header, work.state)
+	core.ApplyRandaoReveal(self.config, header, work.state)
 	txs := types.NewTransactionsByPriceAndNonce(self.current.signer, pending)
 	work.commitTransactions(self.mux, txs, self.chain, self.coinbase)
 	if err := self.engine.Prepare(self.chain, header, work.txs); err != nil {
@@ -352,22 +450,20 @@ func (self *worker) commitNewWork() {
 	for _, hash := range badUncles {
 		delete(self.possibleUncles, hash)
 	}
-	work.producers, err = self.engine.CalProducers(self.chain, header)
-	if err != nil {
-		log.Error("Failed to calproducers.", "err", err)
-		return
-	}
 	work.voters = self.chain.GetVoters(work.header)
-	core.ApplyReleaseVoterBalance(self.chain, header, work.state, work.txs)
+	core.ApplyReleaseVoterBalance(self.chain, header, work.state, work.txs, work.receipts)
+	core.ApplyGovernance(self.config, header, work.state, work.producers)
 	if work.Block, err = self.engine.Finalize(self.chain, header, work.state, work.txs, uncles, work.receipts, work.producers, work.voters); err != nil {
 		log.Error("Failed to finalize block for sealing", "err", err)
 		return
 	}
 	if atomic.LoadInt32(&self.mining) == 1 {
-		log.Info("Commit new mining work", "number", work.Block.Number(), "txs", work.tcount, "uncles", len(uncles), "elapsed", common.PrettyDuration(time.Since(time.Now())), "time", header.Time)
+		log.Info("Commit new mining work", "number", work.Block.Number(), "txs", work.tcount, "uncles", len(uncles), "elapsed", common.PrettyDuration(self.eth.Clock().Now().Sub(tstart)), "time", header.Time)
 		self.unconfirmed.Shift(work.Block.NumberU64() - 1)
 	}
-	
+	if atomic.LoadInt32(&self.mining) == 1 && !self.driftSafe() {
+		return
+	}
 	self.push(work)
 }
 func (self *worker) commitUncle(work *Work, uncle *types.Header) error {
@@ -424,7 +520,11 @@ func (env *Work) commitTransactions(mux *event.TypeMux, txs *types.TransactionsB
 			txs.Shift()
 		}
 	}
-	env.state.AddBalance(coinbase, env.coinbaseDiff)
+	var voters types.Voters
+	if env.config.IsFeeSplit(env.header.Number) {
+		voters = bc.GetVoters(env.header)
+	}
+	core.ApplyFees(env.config, env.header, env.state, env.coinbaseDiff, voters)
 	if len(coalescedLogs) > 0 || env.tcount > 0 {
 		cpy := make([]*types.Log, len(coalescedLogs))
 		for i, l := range coalescedLogs {
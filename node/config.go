@@ -15,6 +15,7 @@ import (
 	"github.com/DEL-ORG/del/log"
 	"github.com/DEL-ORG/del/p2p"
 	"github.com/DEL-ORG/del/p2p/discover"
+	"github.com/DEL-ORG/del/rpc"
 )
 const (
 	datadirPrivateKey      = "nodekey"            
@@ -43,6 +44,8 @@ type Config struct {
 	WSOrigins []string `toml:",omitempty"`
 	WSModules []string `toml:",omitempty"`
 	WSExposeAll bool `toml:",omitempty"`
+	JWTSecret string `toml:",omitempty"`
+	RPCPolicy rpc.Policy `toml:",omitempty"`
 	Logger log.Logger `toml:",omitempty"`
 }
 func (c *Config) IPCEndpoint() string {
@@ -99,6 +102,12 @@ func DefaultWSEndpoint() string {
 	config := &Config{WSHost: DefaultWSHost, WSPort: DefaultWSPort}
 	return config.WSEndpoint()
 }
+func (c *Config) JWTSecretKey() ([]byte, error) {
+	if c.JWTSecret == "" {
+		return nil, nil
+	}
+	return rpc.LoadJWTSecret(c.JWTSecret)
+}
 func (c *Config) NodeName() string {
 	name := c.Name
 	if c.UserIdent != "" {
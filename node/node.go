@@ -276,6 +276,10 @@ func (n *Node) startHTTP(endpoint string, apis []rpc.API, modules []string, cors
 	if endpoint == "" {
 		return nil
 	}
+	secret, err := n.config.JWTSecretKey()
+	if err != nil {
+		return err
+	}
 	whitelist := make(map[string]bool)
 	for _, module := range modules {
 		whitelist[module] = true
@@ -289,15 +293,14 @@ func (n *Node) startHTTP(endpoint string, apis []rpc.API, modules []string, cors
 			n.log.Debug("HTTP registered", "service", api.Service, "namespace", api.Namespace)
 		}
 	}
-	var (
-		listener net.Listener
-		err      error
-	)
-	if listener, err = net.Listen("tcp", endpoint); err != nil {
+	handler.SetPolicy(n.config.RPCPolicy)
+	n.log.Debug("HTTP policy applied", "policy", n.config.RPCPolicy)
+	listener, err := net.Listen("tcp", endpoint)
+	if err != nil {
 		return err
 	}
-	go rpc.NewHTTPServer(cors, vhosts, handler).Serve(listener)
-	n.log.Info("HTTP endpoint opened", "url", fmt.Sprintf("http://%s", endpoint), "cors", strings.Join(cors, ","), "vhosts", strings.Join(vhosts, ","))
+	go rpc.NewHTTPServer(cors, vhosts, secret, handler).Serve(listener)
+	n.log.Info("HTTP endpoint opened", "url", fmt.Sprintf("http://%s", endpoint), "cors", strings.Join(cors, ","), "vhosts", strings.Join(vhosts, ","), "jwt", secret != nil)
 	n.httpEndpoint = endpoint
 	n.httpListener = listener
 	n.httpHandler = handler
@@ -318,6 +321,10 @@ func (n *Node) startWS(endpoint string, apis []rpc.API, modules []string, wsOrig
 	if endpoint == "" {
 		return nil
 	}
+	secret, err := n.config.JWTSecretKey()
+	if err != nil {
+		return err
+	}
 	whitelist := make(map[string]bool)
 	for _, module := range modules {
 		whitelist[module] = true
@@ -331,15 +338,14 @@ func (n *Node) startWS(endpoint string, apis []rpc.API, modules []string, wsOrig
 			n.log.Debug("WebSocket registered", "service", api.Service, "namespace", api.Namespace)
 		}
 	}
-	var (
-		listener net.Listener
-		err      error
-	)
-	if listener, err = net.Listen("tcp", endpoint); err != nil {
+	handler.SetPolicy(n.config.RPCPolicy)
+	n.log.Debug("WebSocket policy applied", "policy", n.config.RPCPolicy)
+	listener, err := net.Listen("tcp", endpoint)
+	if err != nil {
 		return err
 	}
-	go rpc.NewWSServer(wsOrigins, handler).Serve(listener)
-	n.log.Info("WebSocket endpoint opened", "url", fmt.Sprintf("ws://%s", listener.Addr()))
+	go rpc.NewWSServer(wsOrigins, secret, handler).Serve(listener)
+	n.log.Info("WebSocket endpoint opened", "url", fmt.Sprintf("ws://%s", listener.Addr()), "jwt", secret != nil)
 	n.wsEndpoint = endpoint
 	n.wsListener = listener
 	n.wsHandler = handler
@@ -96,7 +96,7 @@ func (n *ExecNode) Addr() []byte {
 func (n *ExecNode) Client() (*rpc.Client, error) {
 	return n.client, nil
 }
-var wsAddrPattern = regexp.MustCompile(`ws:
+var wsAddrPattern = regexp.MustCompile(`ws://[\d.:]+`)
 func (n *ExecNode) Start(snapshots map[string][]byte) (err error) {
 	if n.Cmd != nil {
 		return errors.New("already started")
@@ -0,0 +1,119 @@
+package dpos
+import (
+	"fmt"
+	"time"
+	"github.com/DEL-ORG/del/eth/downloader"
+)
+type Fault interface {
+	Inject(sim *Simulation) error
+}
+type Offline struct {
+	Producer int
+}
+func (f Offline) Inject(sim *Simulation) error {
+	if f.Producer < 0 || f.Producer >= len(sim.producers) {
+		return errUnknownProducer
+	}
+	return sim.stop(f.Producer)
+}
+type Online struct {
+	Producer int
+}
+func (f Online) Inject(sim *Simulation) error {
+	if f.Producer < 0 || f.Producer >= len(sim.producers) {
+		return errUnknownProducer
+	}
+	return sim.start(f.Producer)
+}
+type Resync struct {
+	Producer int
+	Mode     downloader.SyncMode
+}
+func (f Resync) Inject(sim *Simulation) error {
+	if f.Producer < 0 || f.Producer >= len(sim.producers) {
+		return errUnknownProducer
+	}
+	if sim.producers[f.Producer].online {
+		if err := sim.stop(f.Producer); err != nil {
+			return err
+		}
+	}
+	sim.producers[f.Producer].mode = f.Mode
+	return sim.start(f.Producer)
+}
+type Partition struct {
+	Groups [][]int
+}
+func (f Partition) Inject(sim *Simulation) error {
+	group := make(map[int]int)
+	for g, members := range f.Groups {
+		for _, member := range members {
+			if member < 0 || member >= len(sim.producers) {
+				return errUnknownProducer
+			}
+			group[member] = g
+		}
+	}
+	for i := range sim.producers {
+		for j := i + 1; j < len(sim.producers); j++ {
+			gi, iok := group[i]
+			gj, jok := group[j]
+			if iok && jok && gi == gj {
+				continue
+			}
+			if !sim.producers[i].online || !sim.producers[j].online {
+				continue
+			}
+			if err := sim.disconnect(sim.producers[i], sim.producers[j]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+type Heal struct{}
+func (f Heal) Inject(sim *Simulation) error {
+	online := sim.online()
+	for i := range online {
+		for j := i + 1; j < len(online); j++ {
+			if err := sim.connect(online[i], online[j]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+type VoteStorm struct {
+	Producer int
+	Votes    int
+}
+func (f VoteStorm) Inject(sim *Simulation) error {
+	_, err := sim.SendVotes(f.Producer, f.Votes)
+	return err
+}
+type Skew struct {
+	Producer int
+	Offset   time.Duration
+}
+func (f Skew) Inject(sim *Simulation) error {
+	if f.Producer < 0 || f.Producer >= len(sim.producers) {
+		return errUnknownProducer
+	}
+	sim.producers[f.Producer].clock.setOffset(f.Offset)
+	return nil
+}
+type Step struct {
+	Block uint64
+	Fault Fault
+}
+func (s *Simulation) Run(steps []Step, timeout time.Duration) error {
+	for i, step := range steps {
+		if err := s.WaitBlock(step.Block, timeout); err != nil {
+			return fmt.Errorf("step %d: %v", i, err)
+		}
+		if err := step.Fault.Inject(s); err != nil {
+			return fmt.Errorf("step %d (%T): %v", i, step.Fault, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,524 @@
+package dpos
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"time"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/common/mclock"
+	"github.com/DEL-ORG/del/consensus/ethash"
+	"github.com/DEL-ORG/del/core"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/crypto"
+	"github.com/DEL-ORG/del/eth"
+	"github.com/DEL-ORG/del/eth/downloader"
+	"github.com/DEL-ORG/del/node"
+	"github.com/DEL-ORG/del/p2p/discover"
+	"github.com/DEL-ORG/del/p2p/simulations"
+	"github.com/DEL-ORG/del/p2p/simulations/adapters"
+	"github.com/DEL-ORG/del/params"
+)
+const serviceName = "deld"
+var (
+	errUnknownProducer = errors.New("unknown producer")
+	errProducerOffline = errors.New("producer offline")
+	errNoProducerOnline = errors.New("no producer online")
+)
+type Config struct {
+	Producers    int
+	Voters       int
+	VoterBalance *big.Int
+	VoteAmount   *big.Int
+	GasPrice     *big.Int
+	NetworkId    uint64
+	RoundLength  uint64
+	FeeSplit     *params.FeeSplitConfig
+}
+var DefaultConfig = Config{
+	Producers:    3,
+	Voters:       4,
+	VoterBalance: new(big.Int).Mul(common.ONE_COIN, big.NewInt(1000000)),
+	VoteAmount:   new(big.Int).Set(common.ONE_COIN),
+	GasPrice:     big.NewInt(params.Shannon),
+	NetworkId:    870113,
+	FeeSplit: &params.FeeSplitConfig{
+		Coinbase:        50,
+		Burn:            10,
+		Voters:          30,
+		Treasury:        10,
+		TreasuryAddress: common.HexToAddress("0x7ea5"),
+	},
+}
+type Producer struct {
+	ID      discover.NodeID
+	Key     *ecdsa.PrivateKey
+	Address common.Address
+	eth     *eth.Ethereum
+	clock   *skewClock
+	mode    downloader.SyncMode
+	online  bool
+}
+type skewClock struct {
+	mclock.System
+	offset int64
+}
+func (c *skewClock) Now() time.Time {
+	return time.Now().Add(time.Duration(atomic.LoadInt64(&c.offset)))
+}
+func (c *skewClock) setOffset(offset time.Duration) {
+	atomic.StoreInt64(&c.offset, int64(offset))
+}
+type Voter struct {
+	Key     *ecdsa.PrivateKey
+	Address common.Address
+	nonce   uint64
+}
+type Simulation struct {
+	config    *Config
+	genesis   *core.Genesis
+	adapter   *adapters.SimAdapter
+	network   *simulations.Network
+	producers []*Producer
+	voters    []*Voter
+	lock      sync.RWMutex
+}
+func New(config *Config) (*Simulation, error) {
+	if config.Producers <= 0 || config.Producers > common.LEADER_LIMIT {
+		return nil, fmt.Errorf("invalid producer count %d", config.Producers)
+	}
+	sim := &Simulation{config: config}
+	for i := 0; i < config.Producers; i++ {
+		nodeConfig := adapters.RandomNodeConfig()
+		nodeConfig.Name = fmt.Sprintf("producer%02d", i)
+		nodeConfig.Services = []string{serviceName}
+		sim.producers = append(sim.producers, &Producer{
+			ID:      nodeConfig.ID,
+			Key:     nodeConfig.PrivateKey,
+			Address: crypto.PubkeyToAddress(nodeConfig.PrivateKey.PublicKey),
+			clock:   new(skewClock),
+			mode:    downloader.FullSync,
+		})
+	}
+	for i := 0; i < config.Voters; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, err
+		}
+		sim.voters = append(sim.voters, &Voter{Key: key, Address: crypto.PubkeyToAddress(key.PublicKey)})
+	}
+	sim.genesis = sim.makeGenesis()
+	sim.adapter = adapters.NewSimAdapter(adapters.Services{serviceName: sim.newService})
+	sim.network = simulations.NewNetwork(sim.adapter, &simulations.NetworkConfig{
+		ID:             "dpos",
+		DefaultService: serviceName,
+	})
+	for i, producer := range sim.producers {
+		_, err := sim.network.NewNodeWithConfig(&adapters.NodeConfig{
+			ID:         producer.ID,
+			PrivateKey: producer.Key,
+			Name:       fmt.Sprintf("producer%02d", i),
+			Services:   []string{serviceName},
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return sim, nil
+}
+func (s *Simulation) makeGenesis() *core.Genesis {
+	config := *params.TestChainConfig
+	config.FeeSplit = s.config.FeeSplit
+	config.RoundLength = s.config.RoundLength
+	genesis := &core.Genesis{
+		Config:     &config,
+		Timestamp:  uint64(time.Now().Unix()),
+		GasLimit:   params.MinGasLimit,
+		Difficulty: big.NewInt(0),
+		Coinbase:   s.producers[0].Address,
+	}
+	for i := 0; i < common.LEADER_LIMIT; i++ {
+		producer := s.producers[i%len(s.producers)]
+		genesis.Alloc = append(genesis.Alloc, core.GenesisAccount{
+			Addr:     producer.Address,
+			Balance:  new(big.Int),
+			Freeze:   new(big.Int),
+			Producer: true,
+		})
+	}
+	for _, voter := range s.voters {
+		genesis.Alloc = append(genesis.Alloc, core.GenesisAccount{
+			Addr:    voter.Address,
+			Balance: new(big.Int).Set(s.config.VoterBalance),
+			Freeze:  new(big.Int),
+		})
+	}
+	return genesis
+}
+func (s *Simulation) newService(ctx *adapters.ServiceContext) (node.Service, error) {
+	producer := s.producerByID(ctx.Config.ID)
+	if producer == nil {
+		return nil, errUnknownProducer
+	}
+	config := eth.DefaultConfig
+	config.Genesis = s.genesis
+	config.NetworkId = s.config.NetworkId
+	config.SyncMode = producer.mode
+	config.Etherbase = producer.Address
+	config.GasPrice = s.config.GasPrice
+	config.Ethash.PowMode = ethash.ModeNormal
+	config.TxPool.Journal = ""
+	config.Clock = producer.clock
+	ethServ, err := eth.New(ctx.NodeContext, &config)
+	if err != nil {
+		return nil, err
+	}
+	s.lock.Lock()
+	producer.eth = ethServ
+	s.lock.Unlock()
+	return ethServ, nil
+}
+func (s *Simulation) producerByID(id discover.NodeID) *Producer {
+	for _, producer := range s.producers {
+		if producer.ID == id {
+			return producer
+		}
+	}
+	return nil
+}
+func (s *Simulation) Producers() []*Producer {
+	return s.producers
+}
+func (s *Simulation) Voters() []*Voter {
+	return s.voters
+}
+func (s *Simulation) Genesis() *core.Genesis {
+	return s.genesis
+}
+func (s *Simulation) Network() *simulations.Network {
+	return s.network
+}
+func (s *Simulation) Eth(index int) (*eth.Ethereum, error) {
+	if index < 0 || index >= len(s.producers) {
+		return nil, errUnknownProducer
+	}
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	producer := s.producers[index]
+	if !producer.online {
+		return nil, errProducerOffline
+	}
+	return producer.eth, nil
+}
+func (s *Simulation) online() []*Producer {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	var producers []*Producer
+	for _, producer := range s.producers {
+		if producer.online {
+			producers = append(producers, producer)
+		}
+	}
+	return producers
+}
+func (s *Simulation) Start() error {
+	for i := range s.producers {
+		if err := s.start(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (s *Simulation) start(index int) error {
+	producer := s.producers[index]
+	if err := s.network.Start(producer.ID); err != nil {
+		return err
+	}
+	s.lock.Lock()
+	producer.online = true
+	s.lock.Unlock()
+	for _, peer := range s.online() {
+		if peer == producer {
+			continue
+		}
+		if err := s.connect(producer, peer); err != nil {
+			return err
+		}
+	}
+	return producer.eth.StartMining(true)
+}
+func (s *Simulation) stop(index int) error {
+	producer := s.producers[index]
+	s.lock.Lock()
+	producer.online = false
+	s.lock.Unlock()
+	return s.network.Stop(producer.ID)
+}
+func (s *Simulation) connect(one, other *Producer) error {
+	client, err := s.network.GetNode(one.ID).Client()
+	if err != nil {
+		return err
+	}
+	return client.Call(nil, "admin_addPeer", string(s.network.GetNode(other.ID).Addr()))
+}
+func (s *Simulation) disconnect(one, other *Producer) error {
+	for _, pair := range [][2]*Producer{{one, other}, {other, one}} {
+		client, err := s.network.GetNode(pair[0].ID).Client()
+		if err != nil {
+			return err
+		}
+		if err := client.Call(nil, "admin_removePeer", string(s.network.GetNode(pair[1].ID).Addr())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (s *Simulation) Stop() {
+	for _, producer := range s.online() {
+		producer.eth.StopMining()
+	}
+	s.network.Shutdown()
+}
+func (s *Simulation) WaitBlock(number uint64, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		producers := s.online()
+		if len(producers) == 0 {
+			return errNoProducerOnline
+		}
+		for _, producer := range producers {
+			if producer.eth.BlockChain().CurrentBlock().NumberU64() >= number {
+				return nil
+			}
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timeout waiting for block %d: %s", number, s.heads())
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+func (s *Simulation) WaitConvergence(timeout time.Duration) (*types.Block, error) {
+	deadline := time.Now().Add(timeout)
+	for {
+		producers := s.online()
+		if len(producers) == 0 {
+			return nil, errNoProducerOnline
+		}
+		head := producers[0].eth.BlockChain().CurrentBlock()
+		converged := true
+		for _, producer := range producers[1:] {
+			if producer.eth.BlockChain().CurrentBlock().Hash() != head.Hash() {
+				converged = false
+				break
+			}
+		}
+		if converged {
+			return head, nil
+		}
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("chains did not converge: %s", s.heads())
+		}
+		time.Sleep(100 * time.Millisecond)
+	}
+}
+func (s *Simulation) WaitRound(round uint64, timeout time.Duration) error {
+	return s.WaitBlock(common.GetEndBlockNumberByRoundNumber(round, s.genesis.Config.GetRoundLength()), timeout)
+}
+func (s *Simulation) Scheduled(at time.Time) (int, error) {
+	online := s.online()
+	if len(online) == 0 {
+		return -1, errNoProducerOnline
+	}
+	producers := online[0].eth.BlockChain().CurrentBlock().Producers()
+	if len(producers) == 0 {
+		return -1, errors.New("head block has no producers")
+	}
+	leader := producers[common.GetCurrentSlot(at)%int64(len(producers))]
+	if leader.Empty() {
+		return -1, nil
+	}
+	for i, producer := range s.producers {
+		if producer.Address == leader.Addr {
+			return i, nil
+		}
+	}
+	return -1, errUnknownProducer
+}
+func (s *Simulation) heads() string {
+	heads := ""
+	for i, producer := range s.producers {
+		s.lock.RLock()
+		online, ethServ := producer.online, producer.eth
+		s.lock.RUnlock()
+		if !online {
+			heads += fmt.Sprintf("[%d offline]", i)
+			continue
+		}
+		head := ethServ.BlockChain().CurrentBlock()
+		heads += fmt.Sprintf("[%d #%d %x]", i, head.NumberU64(), head.Hash().Bytes()[:4])
+	}
+	return heads
+}
+func (s *Simulation) SendVotes(producer int, votes int) ([]common.Hash, error) {
+	if producer < 0 || producer >= len(s.producers) {
+		return nil, errUnknownProducer
+	}
+	if len(s.voters) == 0 {
+		return nil, errors.New("no voters configured")
+	}
+	online := s.online()
+	if len(online) == 0 {
+		return nil, errNoProducerOnline
+	}
+	var (
+		target = s.producers[producer].Address
+		pool   = online[0].eth.TxPool()
+		signer = types.NewEIP155Signer(s.genesis.Config.ChainId)
+		hashes []common.Hash
+	)
+	for i := 0; i < votes; i++ {
+		voter := s.voters[i%len(s.voters)]
+		tx := types.NewVoteCreation(&target, voter.nonce, s.config.GasPrice, s.config.VoteAmount)
+		signed, err := types.SignTx(tx, signer, voter.Key)
+		if err != nil {
+			return hashes, err
+		}
+		if err := pool.AddLocal(signed); err != nil {
+			return hashes, err
+		}
+		voter.nonce++
+		hashes = append(hashes, signed.Hash())
+	}
+	return hashes, nil
+}
+func (s *Simulation) CheckRewards() error {
+	online := s.online()
+	if len(online) == 0 {
+		return errNoProducerOnline
+	}
+	var (
+		chain    = online[0].eth.BlockChain()
+		head     = chain.CurrentBlock()
+		length   = chain.Config().GetRoundLength()
+		expected = make(map[common.Address]*big.Int)
+	)
+	add := func(addr common.Address, amount *big.Int) {
+		if _, ok := expected[addr]; !ok {
+			expected[addr] = new(big.Int)
+		}
+		expected[addr].Add(expected[addr], amount)
+	}
+	for _, account := range s.genesis.Alloc {
+		add(account.Addr, account.Balance)
+		add(account.Addr, account.Freeze)
+	}
+	for number := uint64(1); number <= head.NumberU64(); number++ {
+		block := chain.GetBlockByNumber(number)
+		if block == nil {
+			return fmt.Errorf("missing block %d", number)
+		}
+		reward := ethash.GetRewardByNumber(number)
+		add(block.Coinbase(), reward.GetBlockReward())
+		var (
+			receipts = chain.GetReceiptsByHash(block.Hash())
+			signer   = types.MakeSigner(chain.Config(), block.Number())
+		)
+		for i, tx := range block.Transactions() {
+			from, err := types.Sender(signer, tx)
+			if err != nil {
+				return err
+			}
+			add(from, new(big.Int).Neg(new(big.Int).Mul(new(big.Int).SetUint64(receipts[i].GasUsed), tx.GasPrice())))
+		}
+		for addr, amount := range chain.GetFeeShares(block) {
+			add(addr, amount)
+		}
+		round := common.GetRoundNumberByBlockNumber(number, length)
+		if number != common.GetEndBlockNumberByRoundNumber(round, length) {
+			continue
+		}
+		for n := common.GetBeginBlockNumberByRoundNumber(round, length); n <= number; n++ {
+			rblock := chain.GetBlockByNumber(n)
+			for addr, amount := range producerShares(rblock.Producers(), reward) {
+				add(addr, amount)
+			}
+			for addr, amount := range voterShares(rblock.Voters, reward) {
+				add(addr, amount)
+			}
+		}
+	}
+	statedb, err := chain.StateAt(head.Root())
+	if err != nil {
+		return err
+	}
+	check := func(kind string, i int, addr common.Address) error {
+		want, ok := expected[addr]
+		if !ok {
+			want = new(big.Int)
+		}
+		if have := new(big.Int).Add(statedb.GetBalance(addr), statedb.GetFreeze(addr)); have.Cmp(want) != 0 {
+			return fmt.Errorf("%s %d reward mismatch at block %d: have %v, want %v", kind, i, head.NumberU64(), have, want)
+		}
+		return nil
+	}
+	for i, producer := range s.producers {
+		if err := check("producer", i, producer.Address); err != nil {
+			return err
+		}
+	}
+	for i, voter := range s.voters {
+		if err := check("voter", i, voter.Address); err != nil {
+			return err
+		}
+	}
+	if split := s.genesis.Config.FeeSplit; split != nil {
+		if err := check("treasury", 0, split.TreasuryAddress); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func voterShares(voters types.Voters, reward *ethash.Reward) map[common.Address]*big.Int {
+	shares := make(map[common.Address]*big.Int)
+	var total uint64
+	for _, voter := range voters {
+		total += voter.Rank
+	}
+	if total == 0 {
+		return shares
+	}
+	for _, voter := range voters {
+		r := new(big.Int).Mul(reward.GetVoterReward(), new(big.Int).SetUint64(voter.Rank))
+		r.Div(r, new(big.Int).SetUint64(total))
+		if _, ok := shares[voter.Addr]; !ok {
+			shares[voter.Addr] = new(big.Int)
+		}
+		shares[voter.Addr].Add(shares[voter.Addr], r)
+	}
+	return shares
+}
+func producerShares(producers types.Producers, reward *ethash.Reward) map[common.Address]*big.Int {
+	shares := make(map[common.Address]*big.Int)
+	active := producers.GetWithOutEmpty()
+	if len(active) == 0 {
+		return shares
+	}
+	supers := len(active)
+	if supers > common.SUPER_COINBASE_RANK {
+		supers = common.SUPER_COINBASE_RANK
+	}
+	coinbase := new(big.Int).Div(reward.GetCoinbaseReward(), big.NewInt(int64(len(active))))
+	super := new(big.Int).Div(reward.GetSuperCoinbaseReward(), big.NewInt(int64(supers)))
+	for i, producer := range active {
+		if _, ok := shares[producer.Addr]; !ok {
+			shares[producer.Addr] = new(big.Int)
+		}
+		shares[producer.Addr].Add(shares[producer.Addr], coinbase)
+		if i < supers {
+			shares[producer.Addr].Add(shares[producer.Addr], super)
+		}
+	}
+	return shares
+}
@@ -0,0 +1,154 @@
+package dpos
+import (
+	"testing"
+	"time"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/eth/downloader"
+)
+const testRoundLength = 12
+const blockTimeout = 4 * common.MINER_TIMEOUT * time.Second
+func newTestSimulation(t *testing.T, config Config) *Simulation {
+	if testing.Short() {
+		t.Skip("skipping DPoS network simulation in short mode")
+	}
+	sim, err := New(&config)
+	if err != nil {
+		t.Fatalf("failed to create simulation: %v", err)
+	}
+	if err := sim.Start(); err != nil {
+		sim.Stop()
+		t.Fatalf("failed to start simulation: %v", err)
+	}
+	return sim
+}
+func scheduled(t *testing.T, sim *Simulation) int {
+	index, err := sim.Scheduled(time.Now())
+	if err != nil {
+		t.Fatalf("failed to get scheduled producer: %v", err)
+	}
+	return index
+}
+func idle(sim *Simulation, scheduled int) int {
+	return (scheduled + 1) % len(sim.Producers())
+}
+func checkNetwork(t *testing.T, sim *Simulation, number uint64) {
+	if err := sim.WaitBlock(number, blockTimeout); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := sim.WaitConvergence(blockTimeout); err != nil {
+		t.Fatal(err)
+	}
+	if err := sim.CheckRewards(); err != nil {
+		t.Fatal(err)
+	}
+}
+func TestProducerOffline(t *testing.T) {
+	sim := newTestSimulation(t, DefaultConfig)
+	defer sim.Stop()
+	producer := idle(sim, scheduled(t, sim))
+	steps := []Step{
+		{Block: 2, Fault: Offline{Producer: producer}},
+		{Block: 4, Fault: Online{Producer: producer}},
+	}
+	if err := sim.Run(steps, blockTimeout); err != nil {
+		t.Fatal(err)
+	}
+	checkNetwork(t, sim, 6)
+}
+func TestPartition(t *testing.T) {
+	sim := newTestSimulation(t, DefaultConfig)
+	defer sim.Stop()
+	leader := scheduled(t, sim)
+	var others []int
+	for i := range sim.Producers() {
+		if i != leader {
+			others = append(others, i)
+		}
+	}
+	steps := []Step{
+		{Block: 1, Fault: Partition{Groups: [][]int{{leader}, others}}},
+		{Block: 4, Fault: Heal{}},
+	}
+	if err := sim.Run(steps, blockTimeout); err != nil {
+		t.Fatal(err)
+	}
+	checkNetwork(t, sim, 6)
+}
+func TestVoteStorm(t *testing.T) {
+	sim := newTestSimulation(t, DefaultConfig)
+	defer sim.Stop()
+	steps := []Step{
+		{Block: 1, Fault: VoteStorm{Producer: 0, Votes: 64}},
+		{Block: 2, Fault: VoteStorm{Producer: 1, Votes: 64}},
+	}
+	if err := sim.Run(steps, blockTimeout); err != nil {
+		t.Fatal(err)
+	}
+	checkNetwork(t, sim, 5)
+	ethServ, err := sim.Eth(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	statedb, err := ethServ.BlockChain().State()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i, voter := range sim.Voters() {
+		if nonce := statedb.GetNonce(voter.Address); nonce != voter.nonce {
+			t.Errorf("voter %d nonce mismatch: have %d, want %d", i, nonce, voter.nonce)
+		}
+	}
+}
+func TestClockSkew(t *testing.T) {
+	sim := newTestSimulation(t, DefaultConfig)
+	defer sim.Stop()
+	leader := scheduled(t, sim)
+	steps := []Step{
+		{Block: 1, Fault: Skew{Producer: leader, Offset: 2 * time.Second}},
+		{Block: 1, Fault: Skew{Producer: idle(sim, leader), Offset: -3 * time.Second}},
+		{Block: 4, Fault: Skew{Producer: leader, Offset: 0}},
+	}
+	if err := sim.Run(steps, blockTimeout); err != nil {
+		t.Fatal(err)
+	}
+	checkNetwork(t, sim, 6)
+}
+func TestRoundBoundaryPartition(t *testing.T) {
+	config := DefaultConfig
+	config.RoundLength = testRoundLength
+	sim := newTestSimulation(t, config)
+	defer sim.Stop()
+	boundary := common.GetEndBlockNumberByRoundNumber(1, testRoundLength)
+	steps := []Step{
+		{Block: boundary - 8, Fault: VoteStorm{Producer: 1, Votes: 8}},
+		{Block: boundary - 3, Fault: Partition{Groups: [][]int{{0}, {1, 2}}}},
+		{Block: boundary + 3, Fault: Heal{}},
+	}
+	if err := sim.Run(steps, testRoundLength*blockTimeout); err != nil {
+		t.Fatal(err)
+	}
+	checkNetwork(t, sim, boundary+6)
+}
+func TestResync(t *testing.T) {
+	config := DefaultConfig
+	config.RoundLength = testRoundLength
+	sim := newTestSimulation(t, config)
+	defer sim.Stop()
+	boundary := common.GetEndBlockNumberByRoundNumber(1, testRoundLength)
+	producer := idle(sim, scheduled(t, sim))
+	steps := []Step{
+		{Block: 2, Fault: Offline{Producer: producer}},
+		{Block: boundary + 2, Fault: Resync{Producer: producer, Mode: downloader.FastSync}},
+	}
+	if err := sim.Run(steps, testRoundLength*blockTimeout); err != nil {
+		t.Fatal(err)
+	}
+	checkNetwork(t, sim, boundary+5)
+	ethServ, err := sim.Eth(producer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if number := ethServ.BlockChain().CurrentBlock().NumberU64(); number <= boundary {
+		t.Errorf("resynced producer stuck before round boundary: have %d, want > %d", number, boundary)
+	}
+}
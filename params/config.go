@@ -1,12 +1,13 @@
 package params
 import (
+	"errors"
 	"fmt"
 	"math/big"
 	"github.com/DEL-ORG/del/common"
 )
 var (
-	MainnetGenesisHash = common.HexToHash("0xd4e56740f876aef8c010b86a40d5f56745a118d0906a34e69aec8c0db1cb8fa3") 
-	TestnetGenesisHash = common.HexToHash("0x41941023680923e0fe4d74a34bdac8141f2540e3ae90623718e47d66d1ca4a2d") 
+	MainnetGenesisHash = common.HexToHash("0xf2f205f5ef5c32c63381e28392cedc4bfa8bc24771708eed500810b4230a23b5") 
+	TestnetGenesisHash = common.HexToHash("0x70385014908486489f079403fff3ce270d36bb0706ddc68fb8662dd13cc79f66") 
 )
 var (
 	MainnetChainConfig = &ChainConfig{
@@ -33,14 +34,14 @@ var (
 		},
 	}
 	AllEthashProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false,
-	big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0),
-	0, 0, new(EthashConfig), nil}
+	big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0),
+	0, 0, 0, nil, new(EthashConfig), nil}
 	AllCliqueProtocolChanges = &ChainConfig{big.NewInt(1337), big.NewInt(0), nil, false,
-	big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0),
-	0, 0,nil, &CliqueConfig{Period: 0, Epoch: 30000}}
+	big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0),
+	0, 0, 0, nil, nil, &CliqueConfig{Period: 0, Epoch: 30000}}
 	TestChainConfig = &ChainConfig{big.NewInt(1), big.NewInt(0), nil, false,
 	big.NewInt(0), common.Hash{}, big.NewInt(0), big.NewInt(0),
-	big.NewInt(0), 0, 0, new(EthashConfig), nil}
+	big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), big.NewInt(0), 0, 0, 0, nil, new(EthashConfig), nil}
 	TestRules       = TestChainConfig.Rules(new(big.Int))
 )
 type ChainConfig struct {
@@ -53,8 +54,18 @@ type ChainConfig struct {
 	EIP155Block *big.Int `json:"eip155Block,omitempty"` 
 	EIP158Block *big.Int `json:"eip158Block,omitempty"` 
 	ByzantiumBlock *big.Int `json:"byzantiumBlock,omitempty"` 
+	DposBlock *big.Int `json:"dposBlock,omitempty"`
+	VestingBlock *big.Int `json:"vestingBlock,omitempty"`
+	DelegationBlock *big.Int `json:"delegationBlock,omitempty"`
+	RandaoBlock *big.Int `json:"randaoBlock,omitempty"`
+	ProducerCycleBlock *big.Int `json:"producerCycleBlock,omitempty"`
+	FeeSplitBlock *big.Int `json:"feeSplitBlock,omitempty"`
+	GovernanceBlock *big.Int `json:"governanceBlock,omitempty"`
+	AliasBlock *big.Int `json:"aliasBlock,omitempty"`
 	FreezeNumber uint64 `json:"freezeNumber,omitempty"`
 	FreezeTimes uint64 `json:"freezeTimes,omitempty"`
+	RoundLength uint64 `json:"roundLength,omitempty"`
+	FeeSplit *FeeSplitConfig `json:"feeSplit,omitempty"`
 	Ethash *EthashConfig `json:"ethash,omitempty"`
 	Clique *CliqueConfig `json:"clique,omitempty"`
 }
@@ -69,6 +80,50 @@ type CliqueConfig struct {
 func (c *CliqueConfig) String() string {
 	return "clique"
 }
+const MaxFeeSplitWeight = 10000
+var (
+	ErrFeeSplitWeight = errors.New("fee split weight out of range")
+	ErrFeeSplitTotal  = errors.New("fee split weights sum to zero")
+)
+type FeeSplitConfig struct {
+	Coinbase        uint64         `json:"coinbase"`
+	Burn            uint64         `json:"burn"`
+	Voters          uint64         `json:"voters"`
+	Treasury        uint64         `json:"treasury"`
+	TreasuryAddress common.Address `json:"treasuryAddress,omitempty"`
+}
+func (c *FeeSplitConfig) String() string {
+	return fmt.Sprintf("{Coinbase: %d Burn: %d Voters: %d Treasury: %d TreasuryAddress: %x}", c.Coinbase, c.Burn, c.Voters, c.Treasury, c.TreasuryAddress)
+}
+func (c *FeeSplitConfig) Validate() error {
+	for _, weight := range []uint64{c.Coinbase, c.Burn, c.Voters, c.Treasury} {
+		if weight > MaxFeeSplitWeight {
+			return ErrFeeSplitWeight
+		}
+	}
+	if c.Coinbase+c.Burn+c.Voters+c.Treasury == 0 {
+		return ErrFeeSplitTotal
+	}
+	return nil
+}
+func (c *FeeSplitConfig) Split(fee *big.Int) (coinbase, burn, voters, treasury *big.Int) {
+	total := new(big.Int)
+	for _, weight := range []uint64{c.Coinbase, c.Burn, c.Voters, c.Treasury} {
+		total.Add(total, new(big.Int).SetUint64(weight))
+	}
+	if total.Sign() == 0 {
+		return new(big.Int).Set(fee), new(big.Int), new(big.Int), new(big.Int)
+	}
+	share := func(weight uint64) *big.Int {
+		r := new(big.Int).Mul(fee, new(big.Int).SetUint64(weight))
+		return r.Div(r, total)
+	}
+	burn, voters, treasury = share(c.Burn), share(c.Voters), share(c.Treasury)
+	coinbase = new(big.Int).Sub(fee, burn)
+	coinbase.Sub(coinbase, voters)
+	coinbase.Sub(coinbase, treasury)
+	return coinbase, burn, voters, treasury
+}
 func (c *ChainConfig) String() string {
 	var engine interface{}
 	switch {
@@ -79,7 +134,7 @@ func (c *ChainConfig) String() string {
 	default:
 		engine = "unknown"
 	}
-	return fmt.Sprintf("{ChainID: %v Homestead: %v DAO: %v DAOSupport: %v EIP150: %v EIP155: %v EIP158: %v Byzantium: %v Engine: %v}",
+	return fmt.Sprintf("{ChainID: %v Homestead: %v DAO: %v DAOSupport: %v EIP150: %v EIP155: %v EIP158: %v Byzantium: %v Dpos: %v Vesting: %v Delegation: %v Randao: %v ProducerCycle: %v FeeSplit: %v %v Governance: %v Alias: %v Engine: %v}",
 		c.ChainId,
 		c.HomesteadBlock,
 		c.DAOForkBlock,
@@ -88,6 +143,15 @@ func (c *ChainConfig) String() string {
 		c.EIP155Block,
 		c.EIP158Block,
 		c.ByzantiumBlock,
+		c.DposBlock,
+		c.VestingBlock,
+		c.DelegationBlock,
+		c.RandaoBlock,
+		c.ProducerCycleBlock,
+		c.FeeSplitBlock,
+		c.FeeSplit,
+		c.GovernanceBlock,
+		c.AliasBlock,
 		engine,
 	)
 }
@@ -109,6 +173,44 @@ func (c *ChainConfig) IsEIP158(num *big.Int) bool {
 func (c *ChainConfig) IsByzantium(num *big.Int) bool {
 	return false
 }
+func (c *ChainConfig) IsDpos(num *big.Int) bool {
+	return isForked(c.DposBlock, num)
+}
+func (c *ChainConfig) IsVesting(num *big.Int) bool {
+	return isForked(c.VestingBlock, num)
+}
+func (c *ChainConfig) IsDelegation(num *big.Int) bool {
+	return isForked(c.DelegationBlock, num)
+}
+func (c *ChainConfig) IsRandao(num *big.Int) bool {
+	return isForked(c.RandaoBlock, num)
+}
+func (c *ChainConfig) IsProducerCycle(num *big.Int) bool {
+	return isForked(c.ProducerCycleBlock, num)
+}
+func (c *ChainConfig) Validate() error {
+	if c.FeeSplit != nil {
+		if err := c.FeeSplit.Validate(); err != nil {
+			return fmt.Errorf("invalid fee split %v: %v", c.FeeSplit, err)
+		}
+	}
+	return nil
+}
+func (c *ChainConfig) GetRoundLength() uint64 {
+	if c == nil || c.RoundLength == 0 {
+		return common.LEADER_NUMBER
+	}
+	return c.RoundLength
+}
+func (c *ChainConfig) IsFeeSplit(num *big.Int) bool {
+	return c.FeeSplit != nil && isForked(c.FeeSplitBlock, num)
+}
+func (c *ChainConfig) IsGovernance(num *big.Int) bool {
+	return isForked(c.GovernanceBlock, num)
+}
+func (c *ChainConfig) IsAlias(num *big.Int) bool {
+	return isForked(c.AliasBlock, num)
+}
 func (c *ChainConfig) GasTable(num *big.Int) GasTable {
 	if num == nil {
 		return GasTableHomestead
@@ -160,6 +262,30 @@ func (c *ChainConfig) checkCompatible(newcfg *ChainConfig, head *big.Int) *Confi
 	if isForkIncompatible(c.ByzantiumBlock, newcfg.ByzantiumBlock, head) {
 		return newCompatError("Byzantium fork block", c.ByzantiumBlock, newcfg.ByzantiumBlock)
 	}
+	if isForkIncompatible(c.DposBlock, newcfg.DposBlock, head) {
+		return newCompatError("Dpos fork block", c.DposBlock, newcfg.DposBlock)
+	}
+	if isForkIncompatible(c.VestingBlock, newcfg.VestingBlock, head) {
+		return newCompatError("Vesting fork block", c.VestingBlock, newcfg.VestingBlock)
+	}
+	if isForkIncompatible(c.DelegationBlock, newcfg.DelegationBlock, head) {
+		return newCompatError("Delegation fork block", c.DelegationBlock, newcfg.DelegationBlock)
+	}
+	if isForkIncompatible(c.RandaoBlock, newcfg.RandaoBlock, head) {
+		return newCompatError("Randao fork block", c.RandaoBlock, newcfg.RandaoBlock)
+	}
+	if isForkIncompatible(c.ProducerCycleBlock, newcfg.ProducerCycleBlock, head) {
+		return newCompatError("ProducerCycle fork block", c.ProducerCycleBlock, newcfg.ProducerCycleBlock)
+	}
+	if isForkIncompatible(c.FeeSplitBlock, newcfg.FeeSplitBlock, head) {
+		return newCompatError("FeeSplit fork block", c.FeeSplitBlock, newcfg.FeeSplitBlock)
+	}
+	if isForkIncompatible(c.GovernanceBlock, newcfg.GovernanceBlock, head) {
+		return newCompatError("Governance fork block", c.GovernanceBlock, newcfg.GovernanceBlock)
+	}
+	if isForkIncompatible(c.AliasBlock, newcfg.AliasBlock, head) {
+		return newCompatError("Alias fork block", c.AliasBlock, newcfg.AliasBlock)
+	}
 	return nil
 }
 func isForkIncompatible(s1, s2, head *big.Int) bool {
@@ -208,11 +334,19 @@ type Rules struct {
 	ChainId                                   *big.Int
 	IsHomestead, IsEIP150, IsEIP155, IsEIP158 bool
 	IsByzantium                               bool
+	IsDpos                                    bool
+	IsVesting                                 bool
+	IsDelegation                              bool
+	IsRandao                                  bool
+	IsProducerCycle                           bool
+	IsFeeSplit                                bool
+	IsGovernance                              bool
+	IsAlias                                   bool
 }
 func (c *ChainConfig) Rules(num *big.Int) Rules {
 	chainId := c.ChainId
 	if chainId == nil {
 		chainId = new(big.Int)
 	}
-	return Rules{ChainId: new(big.Int).Set(chainId), IsHomestead: c.IsHomestead(num), IsEIP150: c.IsEIP150(num), IsEIP155: c.IsEIP155(num), IsEIP158: c.IsEIP158(num), IsByzantium: c.IsByzantium(num)}
+	return Rules{ChainId: new(big.Int).Set(chainId), IsHomestead: c.IsHomestead(num), IsEIP150: c.IsEIP150(num), IsEIP155: c.IsEIP155(num), IsEIP158: c.IsEIP158(num), IsByzantium: c.IsByzantium(num), IsDpos: c.IsDpos(num), IsVesting: c.IsVesting(num), IsDelegation: c.IsDelegation(num), IsRandao: c.IsRandao(num), IsProducerCycle: c.IsProducerCycle(num), IsFeeSplit: c.IsFeeSplit(num), IsGovernance: c.IsGovernance(num), IsAlias: c.IsAlias(num)}
 }
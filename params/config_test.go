@@ -1,8 +1,10 @@
 package params
 import (
+	"math"
 	"math/big"
 	"reflect"
 	"testing"
+	"github.com/DEL-ORG/del/common"
 )
 func TestCheckCompatible(t *testing.T) {
 	type test struct {
@@ -60,3 +62,71 @@ func TestCheckCompatible(t *testing.T) {
 		}
 	}
 }
+func TestFeeSplit(t *testing.T) {
+	tests := []struct {
+		split                            FeeSplitConfig
+		fee                              int64
+		coinbase, burn, voters, treasury int64
+	}{
+		{FeeSplitConfig{}, 1000, 1000, 0, 0, 0},
+		{FeeSplitConfig{Coinbase: 1}, 1000, 1000, 0, 0, 0},
+		{FeeSplitConfig{Coinbase: 50, Burn: 20, Voters: 20, Treasury: 10}, 1000, 500, 200, 200, 100},
+		{FeeSplitConfig{Burn: 1}, 1000, 0, 1000, 0, 0},
+		{FeeSplitConfig{Coinbase: 1, Burn: 1, Voters: 1}, 1000, 334, 333, 333, 0},
+		{FeeSplitConfig{Coinbase: math.MaxUint64, Burn: math.MaxUint64, Voters: math.MaxUint64, Treasury: math.MaxUint64}, 1000, 250, 250, 250, 250},
+		{FeeSplitConfig{Burn: math.MaxUint64, Voters: 1}, 1000, 1, 999, 0, 0},
+	}
+	for i, tt := range tests {
+		coinbase, burn, voters, treasury := tt.split.Split(big.NewInt(tt.fee))
+		if coinbase.Int64() != tt.coinbase || burn.Int64() != tt.burn || voters.Int64() != tt.voters || treasury.Int64() != tt.treasury {
+			t.Errorf("test %d: split mismatch: have %v/%v/%v/%v, want %d/%d/%d/%d", i, coinbase, burn, voters, treasury, tt.coinbase, tt.burn, tt.voters, tt.treasury)
+		}
+	}
+	config := &ChainConfig{FeeSplitBlock: big.NewInt(10)}
+	if config.IsFeeSplit(big.NewInt(10)) {
+		t.Errorf("fee split active without a split configuration")
+	}
+	config.FeeSplit = &FeeSplitConfig{Burn: 1}
+	if config.IsFeeSplit(big.NewInt(9)) || !config.IsFeeSplit(big.NewInt(10)) {
+		t.Errorf("fee split fork mismatch")
+	}
+}
+func TestFeeSplitValidate(t *testing.T) {
+	tests := []struct {
+		split FeeSplitConfig
+		err   error
+	}{
+		{FeeSplitConfig{Coinbase: 50, Burn: 10, Voters: 30, Treasury: 10}, nil},
+		{FeeSplitConfig{Burn: MaxFeeSplitWeight}, nil},
+		{FeeSplitConfig{}, ErrFeeSplitTotal},
+		{FeeSplitConfig{Coinbase: MaxFeeSplitWeight + 1}, ErrFeeSplitWeight},
+		{FeeSplitConfig{Coinbase: math.MaxUint64, Burn: 1}, ErrFeeSplitWeight},
+	}
+	for i, tt := range tests {
+		if err := tt.split.Validate(); err != tt.err {
+			t.Errorf("test %d: validate mismatch: have %v, want %v", i, err, tt.err)
+		}
+	}
+	config := &ChainConfig{FeeSplit: &FeeSplitConfig{Treasury: MaxFeeSplitWeight + 1}}
+	if err := config.Validate(); err == nil {
+		t.Errorf("chain config with an out of range fee split validated")
+	}
+	if err := (&ChainConfig{}).Validate(); err != nil {
+		t.Errorf("chain config without a fee split: have %v, want nil", err)
+	}
+}
+func TestRoundLength(t *testing.T) {
+	tests := []struct {
+		config *ChainConfig
+		length uint64
+	}{
+		{nil, common.LEADER_NUMBER},
+		{&ChainConfig{}, common.LEADER_NUMBER},
+		{&ChainConfig{RoundLength: 12}, 12},
+	}
+	for i, tt := range tests {
+		if length := tt.config.GetRoundLength(); length != tt.length {
+			t.Errorf("test %d: round length mismatch: have %d, want %d", i, length, tt.length)
+		}
+	}
+}
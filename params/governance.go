@@ -0,0 +1,62 @@
+package params
+import (
+	"errors"
+	"math/big"
+)
+const (
+	GovGasLimitTarget = "gasLimitTarget"
+	GovVoteMoneyLimit = "voteMoneyLimit"
+	GovFeeCoinbase    = "feeCoinbase"
+	GovFeeBurn        = "feeBurn"
+	GovFeeVoters      = "feeVoters"
+	GovFeeTreasury    = "feeTreasury"
+)
+var GovernanceParams = []string{GovGasLimitTarget, GovVoteMoneyLimit, GovFeeCoinbase, GovFeeBurn, GovFeeVoters, GovFeeTreasury}
+var (
+	ErrUnknownGovernanceParam = errors.New("unknown governance parameter")
+	ErrGovernanceParamValue   = errors.New("governance parameter value out of range")
+)
+func IsGovernanceParam(name string) bool {
+	for _, param := range GovernanceParams {
+		if param == name {
+			return true
+		}
+	}
+	return false
+}
+func ValidateGovernanceParam(name string, value *big.Int) error {
+	if !IsGovernanceParam(name) {
+		return ErrUnknownGovernanceParam
+	}
+	switch name {
+	case GovGasLimitTarget:
+		if value.Sign() <= 0 || !value.IsUint64() {
+			return ErrGovernanceParamValue
+		}
+	case GovVoteMoneyLimit:
+		if value.Sign() <= 0 {
+			return ErrGovernanceParamValue
+		}
+	default:
+		if value.Sign() < 0 || value.Cmp(new(big.Int).SetUint64(MaxFeeSplitWeight)) > 0 {
+			return ErrGovernanceParamValue
+		}
+	}
+	return nil
+}
+func ValidateFeeSplitParams(values map[string]*big.Int) error {
+	total := new(big.Int)
+	for _, name := range []string{GovFeeCoinbase, GovFeeBurn, GovFeeVoters, GovFeeTreasury} {
+		if err := ValidateGovernanceParam(name, values[name]); err != nil {
+			return err
+		}
+		total.Add(total, values[name])
+	}
+	if total.Sign() == 0 {
+		return ErrFeeSplitTotal
+	}
+	return nil
+}
+func (c *FeeSplitConfig) WithWeights(coinbase, burn, voters, treasury uint64) *FeeSplitConfig {
+	return &FeeSplitConfig{Coinbase: coinbase, Burn: burn, Voters: voters, Treasury: treasury, TreasuryAddress: c.TreasuryAddress}
+}
@@ -0,0 +1,46 @@
+package params
+import (
+	"math/big"
+	"testing"
+)
+func TestValidateGovernanceParam(t *testing.T) {
+	tests := []struct {
+		name  string
+		value *big.Int
+		err   error
+	}{
+		{"unknown", big.NewInt(1), ErrUnknownGovernanceParam},
+		{GovGasLimitTarget, big.NewInt(0), ErrGovernanceParamValue},
+		{GovGasLimitTarget, big.NewInt(8000000), nil},
+		{GovVoteMoneyLimit, big.NewInt(-1), ErrGovernanceParamValue},
+		{GovFeeBurn, big.NewInt(0), nil},
+		{GovFeeBurn, big.NewInt(MaxFeeSplitWeight), nil},
+		{GovFeeBurn, big.NewInt(MaxFeeSplitWeight + 1), ErrGovernanceParamValue},
+		{GovFeeVoters, big.NewInt(-1), ErrGovernanceParamValue},
+		{GovFeeTreasury, new(big.Int).Lsh(big.NewInt(1), 64), ErrGovernanceParamValue},
+	}
+	for i, tt := range tests {
+		if err := ValidateGovernanceParam(tt.name, tt.value); err != tt.err {
+			t.Errorf("test %d: %s=%v: have %v, want %v", i, tt.name, tt.value, err, tt.err)
+		}
+	}
+}
+func TestValidateFeeSplitParams(t *testing.T) {
+	weights := func(coinbase, burn, voters, treasury int64) map[string]*big.Int {
+		return map[string]*big.Int{GovFeeCoinbase: big.NewInt(coinbase), GovFeeBurn: big.NewInt(burn), GovFeeVoters: big.NewInt(voters), GovFeeTreasury: big.NewInt(treasury)}
+	}
+	tests := []struct {
+		weights map[string]*big.Int
+		err     error
+	}{
+		{weights(50, 10, 30, 10), nil},
+		{weights(0, 0, 0, 1), nil},
+		{weights(0, 0, 0, 0), ErrFeeSplitTotal},
+		{weights(MaxFeeSplitWeight+1, 0, 0, 0), ErrGovernanceParamValue},
+	}
+	for i, tt := range tests {
+		if err := ValidateFeeSplitParams(tt.weights); err != tt.err {
+			t.Errorf("test %d: have %v, want %v", i, err, tt.err)
+		}
+	}
+}
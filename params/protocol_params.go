@@ -3,6 +3,7 @@ import (
 	"math/big"
 	"math"
 	"errors"
+	"github.com/DEL-ORG/del/common"
 )
 var (
 	TargetGasLimit uint64 = GenesisGasLimit 
@@ -56,6 +57,18 @@ const (
 	Bn256ScalarMulGas       uint64 = 40000  
 	Bn256PairingBaseGas     uint64 = 100000 
 	Bn256PairingPerPointGas uint64 = 80000  
+	DposStateBaseGas        uint64 = 700
+	DposStateVotesPerBlockGas uint64 = 200
+	DposStatePerProducerGas uint64 = 20
+	DposVoteBaseGas         uint64 = 9000
+	DposVotePerTicketGas    uint64 = 5000
+	VestingScheduleGas      uint64 = 20000
+	VestingTrancheGas       uint64 = 5000
+	DelegationBaseGas       uint64 = 20000
+	DelegationPerTicketGas  uint64 = 20000
+	GovernanceBaseGas       uint64 = 20000
+	GovernancePerTicketGas  uint64 = 20000
+	AliasGas                uint64 = 40000
 )
 var (
 	DifficultyBoundDivisor = big.NewInt(1024)   
@@ -86,3 +99,16 @@ func IntrinsicGas(data []byte) (uint64, error) {
 	}
 	return gas, nil
 }
+func VestingGas(schedules []common.VestingSchedule) uint64 {
+	gas := uint64(len(schedules)) * VestingScheduleGas
+	for _, schedule := range schedules {
+		gas += uint64(len(schedule.Tranches)) * VestingTrancheGas
+	}
+	return gas
+}
+func DelegationGas(tickets common.DataProtocolTickets) uint64 {
+	return DelegationBaseGas + uint64(len(tickets))*DelegationPerTicketGas
+}
+func GovernanceGas(tickets common.DataProtocolTickets) uint64 {
+	return GovernanceBaseGas + uint64(len(tickets))*GovernancePerTicketGas
+}
@@ -0,0 +1,15 @@
+package restapi
+import "github.com/DEL-ORG/del/rpc"
+var DefaultConfig = Config{
+	Host: "localhost",
+	Port: 7003,
+	Cors: []string{"*"},
+}
+type Config struct {
+	Host string `toml:",omitempty"`
+	Port int `toml:",omitempty"`
+	Cors []string `toml:",omitempty"`
+	MaxRewards int `toml:",omitempty"`
+	JWTSecret string `toml:"-"`
+	Policy rpc.Policy `toml:"-"`
+}
@@ -0,0 +1,156 @@
+package restapi
+import (
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/common/hexutil"
+)
+type Amount big.Int
+func NewAmount(x *big.Int) *Amount {
+	if x == nil {
+		return nil
+	}
+	return (*Amount)(new(big.Int).Set(x))
+}
+func (a *Amount) MarshalText() ([]byte, error) {
+	return []byte((*big.Int)(a).String()), nil
+}
+type Block struct {
+	Number       uint64         `json:"number"`
+	Hash         common.Hash    `json:"hash"`
+	ParentHash   common.Hash    `json:"parentHash"`
+	Round        uint64         `json:"round"`
+	Timestamp    uint64         `json:"timestamp"`
+	Coinbase     common.Address `json:"coinbase"`
+	GasLimit     uint64         `json:"gasLimit"`
+	GasUsed      uint64         `json:"gasUsed"`
+	Size         uint64         `json:"size"`
+	Transactions []common.Hash  `json:"transactions"`
+}
+type Account struct {
+	Address    common.Address `json:"address"`
+	Block      uint64         `json:"block"`
+	Balance    *Amount        `json:"balance"`
+	Freeze     *Amount        `json:"freeze"`
+	VoteFreeze *Amount        `json:"voteFreeze"`
+	Nonce      uint64         `json:"nonce"`
+	Alias      string         `json:"alias,omitempty"`
+}
+type Producer struct {
+	Rank    int            `json:"rank"`
+	Address common.Address `json:"address"`
+	Vote    *Amount        `json:"vote"`
+}
+type Producers struct {
+	Block     uint64     `json:"block"`
+	Producers []Producer `json:"producers"`
+}
+type Round struct {
+	Number     uint64     `json:"number"`
+	FirstBlock uint64     `json:"firstBlock"`
+	LastBlock  uint64     `json:"lastBlock"`
+	Complete   bool       `json:"complete"`
+	Producers  []Producer `json:"producers"`
+}
+type Transaction struct {
+	Hash        common.Hash     `json:"hash"`
+	Pending     bool            `json:"pending"`
+	BlockHash   *common.Hash    `json:"blockHash,omitempty"`
+	BlockNumber *uint64         `json:"blockNumber,omitempty"`
+	Index       *uint64         `json:"index,omitempty"`
+	From        common.Address  `json:"from"`
+	To          *common.Address `json:"to,omitempty"`
+	Value       *Amount         `json:"value"`
+	Nonce       uint64          `json:"nonce"`
+	Gas         uint64          `json:"gas"`
+	GasPrice    *Amount         `json:"gasPrice"`
+	GasUsed     *uint64         `json:"gasUsed,omitempty"`
+	Status      *uint           `json:"status,omitempty"`
+	Input       hexutil.Bytes   `json:"input"`
+}
+type Reward struct {
+	Number uint64    `json:"number"`
+	Time   time.Time `json:"time"`
+	Amount *Amount   `json:"amount"`
+}
+type Rewards struct {
+	Address   common.Address `json:"address"`
+	DayReward *Amount        `json:"dayReward"`
+	Rewards   []Reward       `json:"rewards"`
+}
+type Error struct {
+	Error string `json:"error"`
+}
+var schemaTypes = map[string]reflect.Type{
+	"block":       reflect.TypeOf(Block{}),
+	"account":     reflect.TypeOf(Account{}),
+	"producers":   reflect.TypeOf(Producers{}),
+	"round":       reflect.TypeOf(Round{}),
+	"transaction": reflect.TypeOf(Transaction{}),
+	"rewards":     reflect.TypeOf(Rewards{}),
+	"error":       reflect.TypeOf(Error{}),
+}
+var (
+	amountType  = reflect.TypeOf(Amount{})
+	addressType = reflect.TypeOf(common.Address{})
+	hashType    = reflect.TypeOf(common.Hash{})
+	bytesType   = reflect.TypeOf(hexutil.Bytes{})
+	timeType    = reflect.TypeOf(time.Time{})
+)
+func Schema(name string) (map[string]interface{}, bool) {
+	typ, ok := schemaTypes[name]
+	if !ok {
+		return nil, false
+	}
+	schema := jsonSchema(typ)
+	schema["$schema"] = "http://json-schema.org/draft-07/schema#"
+	schema["title"] = typ.Name()
+	return schema, true
+}
+func jsonSchema(typ reflect.Type) map[string]interface{} {
+	for typ.Kind() == reflect.Ptr {
+		typ = typ.Elem()
+	}
+	switch typ {
+	case amountType:
+		return map[string]interface{}{"type": "string", "pattern": "^[0-9]+$"}
+	case addressType:
+		return map[string]interface{}{"type": "string", "pattern": "^0x[0-9a-fA-F]{40}$"}
+	case hashType:
+		return map[string]interface{}{"type": "string", "pattern": "^0x[0-9a-fA-F]{64}$"}
+	case bytesType:
+		return map[string]interface{}{"type": "string", "pattern": "^0x([0-9a-fA-F]{2})*$"}
+	case timeType:
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	switch typ.Kind() {
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{"type": "array", "items": jsonSchema(typ.Elem())}
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		required := []string{}
+		for i := 0; i < typ.NumField(); i++ {
+			field := typ.Field(i)
+			tag := strings.Split(field.Tag.Get("json"), ",")
+			if tag[0] == "" || tag[0] == "-" {
+				continue
+			}
+			properties[tag[0]] = jsonSchema(field.Type)
+			if len(tag) == 1 || tag[1] != "omitempty" {
+				required = append(required, tag[0])
+			}
+		}
+		return map[string]interface{}{"type": "object", "properties": properties, "required": required}
+	}
+	return map[string]interface{}{}
+}
@@ -0,0 +1,355 @@
+package restapi
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/common/hexutil"
+	"github.com/DEL-ORG/del/core"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/internal/ethapi"
+	"github.com/DEL-ORG/del/log"
+	"github.com/DEL-ORG/del/p2p"
+	"github.com/DEL-ORG/del/rpc"
+	"github.com/rs/cors"
+)
+const (
+	defaultRewards = 10
+	requestTimeout = 30 * time.Second
+	restNamespace  = "rest"
+)
+var (
+	errNotFound       = errors.New("not found")
+	errInvalidBlock   = errors.New("invalid block number")
+	errInvalidRound   = errors.New("invalid round number")
+	errInvalidAddress = errors.New("invalid address")
+	errInvalidHash    = errors.New("invalid transaction hash")
+	errInvalidCount   = errors.New("invalid count")
+)
+type Server struct {
+	config   *Config
+	backend  ethapi.Backend
+	chain    *ethapi.PublicBlockChainAPI
+	secret   []byte
+	listener net.Listener
+}
+func New(config *Config, backend ethapi.Backend) (*Server, error) {
+	if backend == nil {
+		return nil, errors.New("REST server requires an Ethereum service")
+	}
+	var secret []byte
+	if config.JWTSecret != "" {
+		var err error
+		if secret, err = rpc.LoadJWTSecret(config.JWTSecret); err != nil {
+			return nil, err
+		}
+	}
+	return &Server{
+		config:  config,
+		backend: backend,
+		chain:   ethapi.NewPublicBlockChainAPI(backend),
+		secret:  secret,
+	}, nil
+}
+func (s *Server) Protocols() []p2p.Protocol { return nil }
+func (s *Server) APIs() []rpc.API { return nil }
+func (s *Server) Start(server *p2p.Server) error {
+	listener, err := net.Listen("tcp", fmt.Sprintf("%s:%d", s.config.Host, s.config.Port))
+	if err != nil {
+		return err
+	}
+	s.listener = listener
+	go http.Serve(listener, s.Handler())
+	log.Info("REST endpoint opened", "url", fmt.Sprintf("http://%s", listener.Addr()), "cors", strings.Join(s.config.Cors, ","), "auth", len(s.secret) > 0, "policy", s.config.Policy)
+	return nil
+}
+func (s *Server) Stop() error {
+	if s.listener == nil {
+		return nil
+	}
+	err := s.listener.Close()
+	log.Info("REST endpoint closed", "url", fmt.Sprintf("http://%s", s.listener.Addr()))
+	s.listener = nil
+	return err
+}
+func (s *Server) Handler() http.Handler {
+	var handler http.Handler = http.HandlerFunc(s.serveHTTP)
+	handler = rpc.NewPolicyHandler(s.config.Policy, restNamespace, handler)
+	handler = rpc.NewJWTHandler(s.secret, handler)
+	if len(s.config.Cors) == 0 {
+		return handler
+	}
+	return cors.New(cors.Options{
+		AllowedOrigins: s.config.Cors,
+		AllowedMethods: []string{http.MethodGet},
+		MaxAge:         600,
+		AllowedHeaders: []string{"*"},
+	}).Handler(handler)
+}
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	ctx, cancel := context.WithTimeout(r.Context(), requestTimeout)
+	defer cancel()
+	var (
+		result interface{}
+		err    error
+		query  = r.URL.Query()
+	)
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	switch {
+	case len(parts) == 2 && parts[0] == "blocks":
+		result, err = s.block(ctx, parts[1])
+	case len(parts) == 2 && parts[0] == "accounts":
+		result, err = s.account(ctx, parts[1], query.Get("block"))
+	case len(parts) == 3 && parts[0] == "accounts" && parts[2] == "rewards":
+		result, err = s.rewards(ctx, parts[1], query.Get("count"))
+	case len(parts) == 1 && parts[0] == "producers":
+		result, err = s.producers(ctx, query.Get("block"))
+	case len(parts) == 2 && parts[0] == "rounds":
+		result, err = s.round(ctx, parts[1])
+	case len(parts) == 2 && parts[0] == "txs":
+		result, err = s.transaction(ctx, parts[1])
+	case len(parts) == 2 && parts[0] == "schemas":
+		var ok bool
+		if result, ok = Schema(parts[1]); !ok {
+			err = errNotFound
+		}
+	default:
+		err = errNotFound
+	}
+	switch err {
+	case nil:
+		writeJSON(w, http.StatusOK, result)
+	case errNotFound:
+		writeError(w, http.StatusNotFound, err)
+	case errInvalidBlock, errInvalidRound, errInvalidAddress, errInvalidHash, errInvalidCount:
+		writeError(w, http.StatusBadRequest, err)
+	default:
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+func writeError(w http.ResponseWriter, status int, err error) {
+	writeJSON(w, status, &Error{Error: err.Error()})
+}
+func parseBlockNumber(s string) (rpc.BlockNumber, error) {
+	switch s {
+	case "", "latest":
+		return rpc.LatestBlockNumber, nil
+	case "pending":
+		return rpc.PendingBlockNumber, nil
+	}
+	number, err := strconv.ParseUint(s, 0, 63)
+	if err != nil {
+		return 0, errInvalidBlock
+	}
+	return rpc.BlockNumber(number), nil
+}
+func parseAddress(s string) (common.Address, error) {
+	if !common.IsHexAddress(s) {
+		return common.Address{}, errInvalidAddress
+	}
+	return common.HexToAddress(s), nil
+}
+func newProducers(producers types.Producers) []Producer {
+	result := make([]Producer, 0, len(producers))
+	for i, producer := range producers {
+		result = append(result, Producer{Rank: i + 1, Address: producer.Addr, Vote: NewAmount(producer.Vote)})
+	}
+	return result
+}
+func (s *Server) block(ctx context.Context, id string) (*Block, error) {
+	var (
+		block *types.Block
+		err   error
+	)
+	if strings.HasPrefix(id, "0x") && len(id) == 2+2*common.HashLength {
+		hash, herr := hexutil.Decode(id)
+		if herr != nil {
+			return nil, errInvalidBlock
+		}
+		block, err = s.backend.GetBlock(ctx, common.BytesToHash(hash))
+	} else {
+		number, perr := parseBlockNumber(id)
+		if perr != nil {
+			return nil, perr
+		}
+		block, err = s.backend.BlockByNumber(ctx, number)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if block == nil {
+		return nil, errNotFound
+	}
+	txs := make([]common.Hash, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		txs = append(txs, tx.Hash())
+	}
+	return &Block{
+		Number:       block.NumberU64(),
+		Hash:         block.Hash(),
+		ParentHash:   block.ParentHash(),
+		Round:        common.GetRoundNumberByBlockNumber(block.NumberU64(), s.backend.ChainConfig().GetRoundLength()),
+		Timestamp:    block.Time().Uint64(),
+		Coinbase:     block.Coinbase(),
+		GasLimit:     block.GasLimit(),
+		GasUsed:      block.GasUsed(),
+		Size:         uint64(block.Size()),
+		Transactions: txs,
+	}, nil
+}
+func (s *Server) account(ctx context.Context, hex string, blockNr string) (*Account, error) {
+	addr, err := parseAddress(hex)
+	if err != nil {
+		return nil, err
+	}
+	number, err := parseBlockNumber(blockNr)
+	if err != nil {
+		return nil, err
+	}
+	state, header, err := s.backend.StateAndHeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if state == nil || header == nil {
+		return nil, errNotFound
+	}
+	voteFreeze, err := s.backend.GetVoteFreeze(ctx, addr, rpc.BlockNumber(header.Number.Int64()))
+	if err != nil {
+		return nil, err
+	}
+	account := &Account{
+		Address:    addr,
+		Block:      header.Number.Uint64(),
+		Balance:    NewAmount(state.GetBalance(addr)),
+		Freeze:     NewAmount(state.GetFreeze(addr)),
+		VoteFreeze: NewAmount(voteFreeze),
+		Nonce:      state.GetNonce(addr),
+	}
+	if alias := core.LookupAlias(state, addr, header.Number.Uint64()); alias != nil {
+		account.Alias = alias.Name
+	}
+	return account, state.Error()
+}
+func (s *Server) rewards(ctx context.Context, hex string, countStr string) (*Rewards, error) {
+	addr, err := parseAddress(hex)
+	if err != nil {
+		return nil, err
+	}
+	count := int64(defaultRewards)
+	if countStr != "" {
+		if count, err = strconv.ParseInt(countStr, 10, 64); err != nil || count <= 0 {
+			return nil, errInvalidCount
+		}
+	}
+	if s.config.MaxRewards > 0 && count > int64(s.config.MaxRewards) {
+		count = int64(s.config.MaxRewards)
+	}
+	day, err := s.backend.Get24HReward(addr)
+	if err != nil {
+		return nil, err
+	}
+	rewards, err := s.chain.GetVoterReward(ctx, count, []common.Address{addr})
+	if err != nil {
+		return nil, err
+	}
+	result := &Rewards{Address: addr, DayReward: NewAmount(day), Rewards: make([]Reward, 0, len(rewards))}
+	for _, reward := range rewards {
+		result.Rewards = append(result.Rewards, Reward{Number: reward.Number, Time: reward.Time, Amount: NewAmount(reward.Amount)})
+	}
+	return result, nil
+}
+func (s *Server) producers(ctx context.Context, blockNr string) (*Producers, error) {
+	number, err := parseBlockNumber(blockNr)
+	if err != nil {
+		return nil, err
+	}
+	header, err := s.backend.HeaderByNumber(ctx, number)
+	if err != nil {
+		return nil, err
+	}
+	if header == nil {
+		return nil, errNotFound
+	}
+	producers, err := s.backend.GetProducers(ctx, rpc.BlockNumber(header.Number.Int64()), false)
+	if err != nil {
+		return nil, err
+	}
+	return &Producers{Block: header.Number.Uint64(), Producers: newProducers(producers)}, nil
+}
+func (s *Server) round(ctx context.Context, id string) (*Round, error) {
+	number, err := strconv.ParseUint(id, 10, 63)
+	if err != nil {
+		return nil, errInvalidRound
+	}
+	var (
+		first = common.GetBeginBlockNumberByRoundNumber(number, s.backend.ChainConfig().GetRoundLength())
+		last  = common.GetEndBlockNumberByRoundNumber(number, s.backend.ChainConfig().GetRoundLength())
+		head  = s.backend.CurrentBlock().NumberU64()
+	)
+	if first > head {
+		return nil, errNotFound
+	}
+	producers, err := s.backend.GetProducers(ctx, rpc.BlockNumber(first), false)
+	if err != nil {
+		return nil, err
+	}
+	return &Round{
+		Number:     number,
+		FirstBlock: first,
+		LastBlock:  last,
+		Complete:   last <= head,
+		Producers:  newProducers(producers),
+	}, nil
+}
+func (s *Server) transaction(ctx context.Context, hex string) (*Transaction, error) {
+	raw, err := hexutil.Decode(hex)
+	if err != nil || len(raw) != common.HashLength {
+		return nil, errInvalidHash
+	}
+	hash := common.BytesToHash(raw)
+	tx, blockHash, blockNumber, index := core.GetTransaction(s.backend.ChainDb(), hash)
+	pending := tx == nil
+	if pending {
+		if tx = s.backend.GetPoolTransaction(hash); tx == nil {
+			return nil, errNotFound
+		}
+	}
+	var signer types.Signer = types.FrontierSigner{}
+	if tx.Protected() {
+		signer = types.NewEIP155Signer(tx.ChainId())
+	}
+	from, _ := types.Sender(signer, tx)
+	result := &Transaction{
+		Hash:     hash,
+		Pending:  pending,
+		From:     from,
+		To:       tx.To(),
+		Value:    NewAmount(tx.Value()),
+		Nonce:    tx.Nonce(),
+		Gas:      tx.Gas(),
+		GasPrice: NewAmount(tx.GasPrice()),
+		Input:    hexutil.Bytes(tx.Data()),
+	}
+	if !pending {
+		result.BlockHash, result.BlockNumber, result.Index = &blockHash, &blockNumber, &index
+		if receipt, _, _, _ := core.GetReceipt(s.backend.ChainDb(), hash); receipt != nil {
+			result.GasUsed, result.Status = &receipt.GasUsed, &receipt.Status
+		}
+	}
+	return result, nil
+}
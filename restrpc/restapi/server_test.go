@@ -0,0 +1,272 @@
+package restapi
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+	"github.com/DEL-ORG/del/common"
+	"github.com/DEL-ORG/del/core"
+	"github.com/DEL-ORG/del/core/state"
+	"github.com/DEL-ORG/del/core/types"
+	"github.com/DEL-ORG/del/crypto"
+	"github.com/DEL-ORG/del/ethdb"
+	"github.com/DEL-ORG/del/internal/ethapi"
+	"github.com/DEL-ORG/del/params"
+	"github.com/DEL-ORG/del/rpc"
+)
+func TestAmountJSON(t *testing.T) {
+	amount, _ := new(big.Int).SetString("123456789012345678901234567890", 10)
+	blob, err := json.Marshal(&Account{Balance: NewAmount(amount)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var decoded map[string]interface{}
+	if err := json.Unmarshal(blob, &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if decoded["balance"] != amount.String() {
+		t.Errorf("balance mismatch: have %v, want %v", decoded["balance"], amount)
+	}
+}
+func TestSchema(t *testing.T) {
+	schema, ok := Schema("account")
+	if !ok {
+		t.Fatal("account schema missing")
+	}
+	properties := schema["properties"].(map[string]interface{})
+	if balance := properties["balance"].(map[string]interface{}); balance["type"] != "string" {
+		t.Errorf("balance type mismatch: have %v, want string", balance["type"])
+	}
+	required := schema["required"].([]string)
+	for _, name := range required {
+		if name == "alias" {
+			t.Errorf("optional alias marked as required")
+		}
+	}
+	if len(required) != 6 {
+		t.Errorf("required field count mismatch: have %d, want 6", len(required))
+	}
+	if _, ok := Schema("unknown"); ok {
+		t.Errorf("unknown schema found")
+	}
+}
+func TestServeInvalidRequests(t *testing.T) {
+	srv := httptest.NewServer((&Server{config: &Config{}}).Handler())
+	defer srv.Close()
+	tests := []struct {
+		method string
+		path   string
+		status int
+	}{
+		{http.MethodGet, "/", http.StatusNotFound},
+		{http.MethodGet, "/unknown/1", http.StatusNotFound},
+		{http.MethodPost, "/blocks/1", http.StatusMethodNotAllowed},
+		{http.MethodGet, "/blocks/abc", http.StatusBadRequest},
+		{http.MethodGet, "/accounts/0x1234", http.StatusBadRequest},
+		{http.MethodGet, "/accounts/0x1234/rewards", http.StatusBadRequest},
+		{http.MethodGet, "/accounts/0x0000000000000000000000000000000000000001?block=x", http.StatusBadRequest},
+		{http.MethodGet, "/producers?block=-1", http.StatusBadRequest},
+		{http.MethodGet, "/rounds/latest", http.StatusBadRequest},
+		{http.MethodGet, "/txs/0x12", http.StatusBadRequest},
+		{http.MethodGet, "/schemas/block", http.StatusOK},
+		{http.MethodGet, "/schemas/unknown", http.StatusNotFound},
+	}
+	for i, tt := range tests {
+		req, _ := http.NewRequest(tt.method, srv.URL+tt.path, nil)
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Errorf("test %d: invalid JSON response: %v", i, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.status {
+			t.Errorf("test %d: %s %s status mismatch: have %d, want %d", i, tt.method, tt.path, resp.StatusCode, tt.status)
+		}
+	}
+}
+type testBackend struct {
+	ethapi.Backend
+	config    *params.ChainConfig
+	db        ethdb.Database
+	block     *types.Block
+	state     *state.StateDB
+	producers types.Producers
+	freeze    *big.Int
+	pending   *types.Transaction
+}
+func (b *testBackend) ChainConfig() *params.ChainConfig { return b.config }
+func (b *testBackend) ChainDb() ethdb.Database { return b.db }
+func (b *testBackend) CurrentBlock() *types.Block { return b.block }
+func (b *testBackend) BlockByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Block, error) {
+	if number == rpc.LatestBlockNumber || uint64(number) == b.block.NumberU64() {
+		return b.block, nil
+	}
+	return nil, nil
+}
+func (b *testBackend) GetBlock(ctx context.Context, hash common.Hash) (*types.Block, error) {
+	if hash == b.block.Hash() {
+		return b.block, nil
+	}
+	return nil, nil
+}
+func (b *testBackend) HeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*types.Header, error) {
+	block, err := b.BlockByNumber(ctx, number)
+	if block == nil {
+		return nil, err
+	}
+	return block.Header(), nil
+}
+func (b *testBackend) StateAndHeaderByNumber(ctx context.Context, number rpc.BlockNumber) (*state.StateDB, *types.Header, error) {
+	header, err := b.HeaderByNumber(ctx, number)
+	if header == nil {
+		return nil, nil, err
+	}
+	return b.state, header, nil
+}
+func (b *testBackend) GetVoteFreeze(ctx context.Context, addr common.Address, number rpc.BlockNumber) (*big.Int, error) {
+	return b.freeze, nil
+}
+func (b *testBackend) GetProducers(ctx context.Context, number rpc.BlockNumber, hidden bool) (types.Producers, error) {
+	return b.producers, nil
+}
+func (b *testBackend) GetPoolTransaction(hash common.Hash) *types.Transaction {
+	if b.pending != nil && b.pending.Hash() == hash {
+		return b.pending
+	}
+	return nil
+}
+func newTestBackend(t *testing.T) (*testBackend, common.Address, *types.Transaction) {
+	key, _ := crypto.GenerateKey()
+	sender := crypto.PubkeyToAddress(key.PublicKey)
+	signer := types.NewEIP155Signer(big.NewInt(1))
+	tx, err := types.SignTx(types.NewTransaction(0, common.Address{0x02}, big.NewInt(1000), 21000, big.NewInt(1), nil), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pending, err := types.SignTx(types.NewTransaction(1, common.Address{0x02}, big.NewInt(2000), 21000, big.NewInt(1), nil), signer, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db, _ := ethdb.NewMemDatabase()
+	receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, GasUsed: 21000, TxHash: tx.Hash()}
+	block := types.NewBlock(&types.Header{Number: big.NewInt(1), Time: big.NewInt(1500000000), GasLimit: 8000000, GasUsed: 21000, Coinbase: common.Address{0x03}}, []*types.Transaction{tx}, nil, []*types.Receipt{receipt}, nil, nil)
+	if err := core.WriteBlock(db, block); err != nil {
+		t.Fatal(err)
+	}
+	if err := core.WriteTxLookupEntries(db, block); err != nil {
+		t.Fatal(err)
+	}
+	if err := core.WriteBlockReceipts(db, block.Hash(), block.NumberU64(), types.Receipts{receipt}); err != nil {
+		t.Fatal(err)
+	}
+	statedb, _ := state.New(common.Hash{}, state.NewDatabase(db))
+	statedb.SetBalance(sender, big.NewInt(5000))
+	statedb.SetFreeze(sender, big.NewInt(300))
+	statedb.SetNonce(sender, 1)
+	config := *params.TestChainConfig
+	config.RoundLength = 4
+	return &testBackend{
+		config:    &config,
+		db:        db,
+		block:     block,
+		state:     statedb,
+		producers: types.Producers{{Addr: common.Address{0x04}, Vote: big.NewInt(700)}, {Addr: common.Address{0x05}, Vote: big.NewInt(600)}},
+		freeze:    big.NewInt(200),
+		pending:   pending,
+	}, sender, tx
+}
+func TestServeRequests(t *testing.T) {
+	backend, sender, tx := newTestBackend(t)
+	server, err := New(&Config{}, backend)
+	if err != nil {
+		t.Fatal(err)
+	}
+	srv := httptest.NewServer(server.Handler())
+	defer srv.Close()
+	block := backend.block
+	tests := []struct {
+		path   string
+		status int
+		want   map[string]interface{}
+	}{
+		{"/blocks/1", http.StatusOK, map[string]interface{}{"number": 1.0, "hash": block.Hash(), "round": 1.0, "timestamp": 1500000000.0, "gasUsed": 21000.0, "transactions": []common.Hash{tx.Hash()}}},
+		{"/blocks/" + block.Hash().Hex(), http.StatusOK, map[string]interface{}{"number": 1.0, "coinbase": common.Address{0x03}}},
+		{"/blocks/2", http.StatusNotFound, nil},
+		{"/accounts/" + sender.Hex(), http.StatusOK, map[string]interface{}{"block": 1.0, "balance": "5000", "freeze": "300", "voteFreeze": "200", "nonce": 1.0}},
+		{"/producers", http.StatusOK, map[string]interface{}{"block": 1.0, "producers": []interface{}{
+			map[string]interface{}{"rank": 1.0, "address": common.Address{0x04}, "vote": "700"},
+			map[string]interface{}{"rank": 2.0, "address": common.Address{0x05}, "vote": "600"},
+		}}},
+		{"/rounds/1", http.StatusOK, map[string]interface{}{"number": 1.0, "firstBlock": 1.0, "lastBlock": 4.0, "complete": false}},
+		{"/rounds/2", http.StatusNotFound, nil},
+		{"/txs/" + tx.Hash().Hex(), http.StatusOK, map[string]interface{}{"pending": false, "blockHash": block.Hash(), "blockNumber": 1.0, "index": 0.0, "from": sender, "value": "1000", "gasUsed": 21000.0, "status": 1.0}},
+		{"/txs/" + backend.pending.Hash().Hex(), http.StatusOK, map[string]interface{}{"pending": true, "from": sender, "value": "2000", "nonce": 1.0}},
+		{"/txs/" + common.Hash{0x01}.Hex(), http.StatusNotFound, nil},
+	}
+	for i, tt := range tests {
+		resp, err := http.Get(srv.URL + tt.path)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+			t.Errorf("test %d: invalid JSON response: %v", i, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.status {
+			t.Errorf("test %d: %s status mismatch: have %d, want %d (%v)", i, tt.path, resp.StatusCode, tt.status, body)
+			continue
+		}
+		for field, want := range tt.want {
+			if have, _ := json.Marshal(body[field]); string(have) != mustMarshal(want) {
+				t.Errorf("test %d: %s field %s mismatch: have %s, want %s", i, tt.path, field, have, mustMarshal(want))
+			}
+		}
+	}
+}
+func TestServeAuthAndPolicy(t *testing.T) {
+	backend, _, _ := newTestBackend(t)
+	secret := make([]byte, 32)
+	server := &Server{config: &Config{Policy: rpc.Policy{Deny: []string{"rest_accounts"}}}, backend: backend, chain: ethapi.NewPublicBlockChainAPI(backend), secret: secret}
+	srv := httptest.NewServer(server.Handler())
+	defer srv.Close()
+	claims := &rpc.AuthClaims{Namespaces: []string{"rest"}}
+	claims.IssuedAt = time.Now().Unix()
+	token, err := rpc.NewAuthToken(secret, claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		token  string
+		path   string
+		status int
+	}{
+		{"", "/blocks/1", http.StatusUnauthorized},
+		{token, "/blocks/1", http.StatusOK},
+		{token, "/accounts/0x0000000000000000000000000000000000000001", http.StatusForbidden},
+	}
+	for i, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+tt.path, nil)
+		if tt.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.status {
+			t.Errorf("test %d: %s status mismatch: have %d, want %d", i, tt.path, resp.StatusCode, tt.status)
+		}
+	}
+}
+func mustMarshal(v interface{}) string {
+	blob, _ := json.Marshal(v)
+	return string(blob)
+}
@@ -0,0 +1,128 @@
+package rpc
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+	"github.com/dgrijalva/jwt-go"
+)
+const (
+	minJWTSecretLength = 32
+	authIssuedWindow   = 60 * time.Second
+)
+var (
+	errMissingToken   = errors.New("missing bearer token")
+	errSigningMethod  = errors.New("unexpected token signing method")
+	errJWTSecretShort = fmt.Errorf("JWT secret shorter than %d bytes", minJWTSecretLength)
+	errTokenLifetime  = errors.New("token has neither an expiry nor an issue time")
+	errTokenStale     = fmt.Errorf("token issued more than %v from now", authIssuedWindow)
+	errTokenExpired   = errors.New("token is expired")
+	errTokenNotActive = errors.New("token is not valid yet")
+)
+type authClaimsKey struct{}
+type AuthClaims struct {
+	jwt.StandardClaims
+	Namespaces []string `json:"namespaces,omitempty"`
+}
+func (c *AuthClaims) Valid() error {
+	now := time.Now()
+	if !c.VerifyExpiresAt(now.Unix(), false) {
+		return errTokenExpired
+	}
+	if !c.VerifyNotBefore(now.Unix(), false) {
+		return errTokenNotActive
+	}
+	if c.ExpiresAt == 0 && c.IssuedAt == 0 {
+		return errTokenLifetime
+	}
+	if c.IssuedAt != 0 {
+		age := now.Sub(time.Unix(c.IssuedAt, 0))
+		if age < -authIssuedWindow || (c.ExpiresAt == 0 && age > authIssuedWindow) {
+			return errTokenStale
+		}
+	}
+	return nil
+}
+func (c *AuthClaims) Allows(namespace string) bool {
+	if len(c.Namespaces) == 0 || namespace == MetadataApi {
+		return true
+	}
+	for _, allowed := range c.Namespaces {
+		if allowed == "*" || allowed == namespace {
+			return true
+		}
+	}
+	return false
+}
+func AuthClaimsFromContext(ctx context.Context) (*AuthClaims, bool) {
+	claims, ok := ctx.Value(authClaimsKey{}).(*AuthClaims)
+	return claims, ok
+}
+func LoadJWTSecret(path string) ([]byte, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(string(data)), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid JWT secret in %s: %v", path, err)
+	}
+	if len(secret) < minJWTSecretLength {
+		return nil, errJWTSecretShort
+	}
+	return secret, nil
+}
+func NewAuthToken(secret []byte, claims *AuthClaims) (string, error) {
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+}
+func ParseAuthToken(secret []byte, token string) (*AuthClaims, error) {
+	claims := new(AuthClaims)
+	_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		if token.Method != jwt.SigningMethodHS256 {
+			return nil, errSigningMethod
+		}
+		return secret, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+type jwtHandler struct {
+	secret []byte
+	next   http.Handler
+}
+func (h *jwtHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodOptions {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "Bearer ") {
+		http.Error(w, errMissingToken.Error(), http.StatusUnauthorized)
+		return
+	}
+	claims, err := ParseAuthToken(h.secret, strings.TrimPrefix(auth, "Bearer "))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	h.next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), authClaimsKey{}, claims)))
+}
+func NewJWTHandler(secret []byte, next http.Handler) http.Handler {
+	if len(secret) == 0 {
+		return next
+	}
+	return &jwtHandler{secret, next}
+}
+func requestContext(r *http.Request) context.Context {
+	ctx := context.WithValue(context.Background(), clientKey{}, clientAddress(r))
+	if claims, ok := AuthClaimsFromContext(r.Context()); ok {
+		ctx = context.WithValue(ctx, authClaimsKey{}, claims)
+	}
+	return ctx
+}
@@ -0,0 +1,112 @@
+package rpc
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+	"github.com/dgrijalva/jwt-go"
+)
+func TestLoadJWTSecret(t *testing.T) {
+	dir, err := ioutil.TempDir("", "jwtsecret")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	tests := []struct {
+		content string
+		length  int
+		fail    bool
+	}{
+		{"0x" + strings.Repeat("ab", 32) + "\n", 32, false},
+		{strings.Repeat("01", 48), 48, false},
+		{strings.Repeat("ab", 16), 0, true},
+		{"not hex", 0, true},
+	}
+	for i, tt := range tests {
+		path := filepath.Join(dir, "secret")
+		if err := ioutil.WriteFile(path, []byte(tt.content), 0600); err != nil {
+			t.Fatal(err)
+		}
+		secret, err := LoadJWTSecret(path)
+		if (err != nil) != tt.fail || len(secret) != tt.length {
+			t.Errorf("test %d: have %d bytes, err %v", i, len(secret), err)
+		}
+	}
+}
+func TestHTTPAuthentication(t *testing.T) {
+	secret := bytes.Repeat([]byte{0x42}, minJWTSecretLength)
+	server := NewServer()
+	if err := server.RegisterName("test", new(Service)); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.RegisterName("admin", new(Service)); err != nil {
+		t.Fatal(err)
+	}
+	httpsrv := httptest.NewServer(NewHTTPServer(nil, []string{"*"}, secret, server).Handler)
+	defer httpsrv.Close()
+	token := func(key []byte, claims *AuthClaims) string {
+		signed, err := NewAuthToken(key, claims)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return "Bearer " + signed
+	}
+	fresh := func(namespaces ...string) *AuthClaims {
+		return &AuthClaims{StandardClaims: jwt.StandardClaims{IssuedAt: time.Now().Unix()}, Namespaces: namespaces}
+	}
+	expired := &AuthClaims{StandardClaims: jwt.StandardClaims{ExpiresAt: time.Now().Add(-time.Minute).Unix()}}
+	stale := &AuthClaims{StandardClaims: jwt.StandardClaims{IssuedAt: time.Now().Add(-2 * authIssuedWindow).Unix()}}
+	expiring := &AuthClaims{StandardClaims: jwt.StandardClaims{IssuedAt: time.Now().Add(-2 * authIssuedWindow).Unix(), ExpiresAt: time.Now().Add(time.Hour).Unix()}}
+	skewed := &AuthClaims{StandardClaims: jwt.StandardClaims{IssuedAt: time.Now().Add(authIssuedWindow / 2).Unix()}}
+	future := &AuthClaims{StandardClaims: jwt.StandardClaims{IssuedAt: time.Now().Add(2 * authIssuedWindow).Unix()}}
+	skewedExpiring := &AuthClaims{StandardClaims: jwt.StandardClaims{IssuedAt: time.Now().Add(authIssuedWindow / 2).Unix(), ExpiresAt: time.Now().Add(time.Hour).Unix()}}
+	futureExpiring := &AuthClaims{StandardClaims: jwt.StandardClaims{IssuedAt: time.Now().Add(2 * authIssuedWindow).Unix(), ExpiresAt: time.Now().Add(time.Hour).Unix()}}
+	tests := []struct {
+		auth   string
+		method string
+		status int
+		denied bool
+	}{
+		{"", "test_rets", http.StatusUnauthorized, false},
+		{"Bearer garbage", "test_rets", http.StatusUnauthorized, false},
+		{token(bytes.Repeat([]byte{0x01}, minJWTSecretLength), fresh()), "test_rets", http.StatusUnauthorized, false},
+		{token(secret, expired), "test_rets", http.StatusUnauthorized, false},
+		{token(secret, &AuthClaims{}), "test_rets", http.StatusUnauthorized, false},
+		{token(secret, stale), "test_rets", http.StatusUnauthorized, false},
+		{token(secret, expiring), "test_rets", http.StatusOK, false},
+		{token(secret, skewed), "test_rets", http.StatusOK, false},
+		{token(secret, future), "test_rets", http.StatusUnauthorized, false},
+		{token(secret, skewedExpiring), "test_rets", http.StatusOK, false},
+		{token(secret, futureExpiring), "test_rets", http.StatusUnauthorized, false},
+		{token(secret, fresh()), "admin_rets", http.StatusOK, false},
+		{token(secret, fresh("test")), "test_rets", http.StatusOK, false},
+		{token(secret, fresh("test")), "admin_rets", http.StatusOK, true},
+		{token(secret, fresh("test")), "rpc_modules", http.StatusOK, false},
+	}
+	for i, tt := range tests {
+		body := `{"jsonrpc":"2.0","id":1,"method":"` + tt.method + `","params":[]}`
+		req, _ := http.NewRequest(http.MethodPost, httpsrv.URL, strings.NewReader(body))
+		req.Header.Set("content-type", contentType)
+		if tt.auth != "" {
+			req.Header.Set("Authorization", tt.auth)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("test %d: request failed: %v", i, err)
+		}
+		reply, _ := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if resp.StatusCode != tt.status {
+			t.Errorf("test %d: status mismatch: have %d, want %d", i, resp.StatusCode, tt.status)
+			continue
+		}
+		if denied := strings.Contains(string(reply), "-32001"); tt.status == http.StatusOK && denied != tt.denied {
+			t.Errorf("test %d: namespace denial mismatch: have %s", i, reply)
+		}
+	}
+}
@@ -23,3 +23,28 @@ func (e *callbackError) Error() string { return e.message }
 type shutdownError struct{}
 func (e *shutdownError) ErrorCode() int { return -32000 }
 func (e *shutdownError) Error() string { return "server is shutting down" }
+type namespaceDeniedError struct{ namespace string }
+func (e *namespaceDeniedError) ErrorCode() int { return -32001 }
+func (e *namespaceDeniedError) Error() string {
+	return fmt.Sprintf("access to the %s namespace is not permitted", e.namespace)
+}
+type methodDeniedError struct{ method string }
+func (e *methodDeniedError) ErrorCode() int { return -32001 }
+func (e *methodDeniedError) Error() string {
+	return fmt.Sprintf("the method %s is not permitted", e.method)
+}
+type rateLimitedError struct{ client string }
+func (e *rateLimitedError) ErrorCode() int { return -32005 }
+func (e *rateLimitedError) Error() string {
+	return fmt.Sprintf("request rate limit exceeded for %s", e.client)
+}
+type batchTooLargeError struct{ size, limit int }
+func (e *batchTooLargeError) ErrorCode() int { return -32600 }
+func (e *batchTooLargeError) Error() string {
+	return fmt.Sprintf("batch of %d requests exceeds the limit of %d", e.size, e.limit)
+}
+type requestTimeoutError struct{ method string }
+func (e *requestTimeoutError) ErrorCode() int { return -32002 }
+func (e *requestTimeoutError) Error() string {
+	return fmt.Sprintf("the method %s timed out", e.method)
+}
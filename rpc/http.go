@@ -106,8 +106,9 @@ type httpReadWriteNopCloser struct {
 func (t *httpReadWriteNopCloser) Close() error {
 	return nil
 }
-func NewHTTPServer(cors []string, vhosts []string, srv *Server) *http.Server {
-	handler := newCorsHandler(srv, cors)
+func NewHTTPServer(cors []string, vhosts []string, secret []byte, srv *Server) *http.Server {
+	handler := NewJWTHandler(secret, srv)
+	handler = newCorsHandler(handler, cors)
 	handler = newVHostHandler(vhosts, handler)
 	return &http.Server{Handler: handler}
 }
@@ -122,7 +123,7 @@ func (srv *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	codec := NewJSONCodec(&httpReadWriteNopCloser{r.Body, w})
 	defer codec.Close()
 	w.Header().Set("content-type", contentType)
-	srv.ServeSingleRequest(codec, OptionMethodInvocation)
+	srv.serveRequest(requestContext(r), codec, true, OptionMethodInvocation)
 }
 func validateRequest(r *http.Request) (int, error) {
 	if r.Method == http.MethodPut || r.Method == http.MethodDelete {
@@ -139,7 +140,7 @@ func validateRequest(r *http.Request) (int, error) {
 	}
 	return 0, nil
 }
-func newCorsHandler(srv *Server, allowedOrigins []string) http.Handler {
+func newCorsHandler(srv http.Handler, allowedOrigins []string) http.Handler {
 	if len(allowedOrigins) == 0 {
 		return srv
 	}
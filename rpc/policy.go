@@ -0,0 +1,204 @@
+package rpc
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"github.com/DEL-ORG/del/log"
+)
+const (
+	maxRateLimitedClients = 4096
+	defaultMaxConcurrent  = 64
+)
+type Policy struct {
+	Allow         []string      `toml:",omitempty"`
+	Deny          []string      `toml:",omitempty"`
+	RateLimit     float64       `toml:",omitempty"`
+	RateBurst     int           `toml:",omitempty"`
+	MaxBatchSize  int           `toml:",omitempty"`
+	Timeout       time.Duration `toml:",omitempty"`
+	MaxConcurrent int           `toml:",omitempty"`
+}
+type clientKey struct{}
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+type serverPolicy struct {
+	Policy
+	lock    sync.Mutex
+	buckets map[string]*tokenBucket
+	calls   chan struct{}
+}
+func newServerPolicy(policy Policy) *serverPolicy {
+	if policy.RateLimit > 0 && policy.RateBurst < 1 {
+		policy.RateBurst = 1
+	}
+	if policy.MaxConcurrent < 1 {
+		policy.MaxConcurrent = defaultMaxConcurrent
+	}
+	return &serverPolicy{Policy: policy, buckets: make(map[string]*tokenBucket), calls: make(chan struct{}, policy.MaxConcurrent)}
+}
+func matchMethod(patterns []string, method string) bool {
+	for _, pattern := range patterns {
+		if pattern == "*" || pattern == method {
+			return true
+		}
+		if strings.HasSuffix(pattern, serviceMethodSeparator+"*") && strings.HasPrefix(method, strings.TrimSuffix(pattern, "*")) {
+			return true
+		}
+	}
+	return false
+}
+func (p *serverPolicy) permits(method string) bool {
+	if matchMethod(p.Deny, method) {
+		return false
+	}
+	return len(p.Allow) == 0 || matchMethod(p.Allow, method)
+}
+func (p *serverPolicy) take(client string, now time.Time) bool {
+	if p.RateLimit <= 0 || client == "" {
+		return true
+	}
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	burst := float64(p.RateBurst)
+	bucket, ok := p.buckets[client]
+	if !ok {
+		if len(p.buckets) >= maxRateLimitedClients {
+			p.prune(now)
+		}
+		if len(p.buckets) >= maxRateLimitedClients {
+			p.evictOldest()
+		}
+		bucket = &tokenBucket{tokens: burst, last: now}
+		p.buckets[client] = bucket
+	}
+	bucket.tokens += now.Sub(bucket.last).Seconds() * p.RateLimit
+	if bucket.tokens > burst {
+		bucket.tokens = burst
+	}
+	bucket.last = now
+	if bucket.tokens < 1 {
+		return false
+	}
+	bucket.tokens--
+	return true
+}
+func (p *serverPolicy) prune(now time.Time) {
+	for client, bucket := range p.buckets {
+		if bucket.tokens+now.Sub(bucket.last).Seconds()*p.RateLimit >= float64(p.RateBurst) {
+			delete(p.buckets, client)
+		}
+	}
+}
+func (p *serverPolicy) evictOldest() {
+	var (
+		oldest string
+		last   time.Time
+	)
+	for client, bucket := range p.buckets {
+		if oldest == "" || bucket.last.Before(last) {
+			oldest, last = client, bucket.last
+		}
+	}
+	delete(p.buckets, oldest)
+}
+func (p *serverPolicy) check(ctx context.Context, method string) Error {
+	if !p.permits(method) {
+		return &methodDeniedError{method}
+	}
+	client, _ := ctx.Value(clientKey{}).(string)
+	if !p.take(client, time.Now()) {
+		return &rateLimitedError{client}
+	}
+	return nil
+}
+func (p *serverPolicy) call(ctx context.Context, method string, fn reflect.Value, arguments []reflect.Value) ([]reflect.Value, Error) {
+	if p.Timeout <= 0 {
+		return fn.Call(arguments), nil
+	}
+	select {
+	case p.calls <- struct{}{}:
+	case <-ctx.Done():
+		return nil, &requestTimeoutError{method}
+	}
+	done := make(chan []reflect.Value, 1)
+	failed := make(chan struct{})
+	go func() {
+		defer func() {
+			if err := recover(); err != nil {
+				log.Error("RPC method panicked", "err", err)
+				close(failed)
+			}
+			<-p.calls
+		}()
+		done <- fn.Call(arguments)
+	}()
+	select {
+	case reply := <-done:
+		return reply, nil
+	case <-failed:
+		return nil, &callbackError{fmt.Sprintf("the method %s crashed", method)}
+	case <-ctx.Done():
+		return nil, &requestTimeoutError{method}
+	}
+}
+func (s *Server) SetPolicy(policy Policy) {
+	s.policy = newServerPolicy(policy)
+}
+type policyHandler struct {
+	policy    *serverPolicy
+	namespace string
+	next      http.Handler
+}
+func NewPolicyHandler(policy Policy, namespace string, next http.Handler) http.Handler {
+	return &policyHandler{newServerPolicy(policy), namespace, next}
+}
+func (h *policyHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodOptions {
+		h.next.ServeHTTP(w, r)
+		return
+	}
+	if claims, ok := AuthClaimsFromContext(r.Context()); ok && !claims.Allows(h.namespace) {
+		http.Error(w, (&namespaceDeniedError{h.namespace}).Error(), http.StatusForbidden)
+		return
+	}
+	method := h.namespace + serviceMethodSeparator + strings.SplitN(strings.Trim(r.URL.Path, "/"), "/", 2)[0]
+	ctx := context.WithValue(r.Context(), clientKey{}, clientAddress(r))
+	if err := h.policy.check(ctx, method); err != nil {
+		status := http.StatusForbidden
+		if _, ok := err.(*rateLimitedError); ok {
+			status = http.StatusTooManyRequests
+		}
+		http.Error(w, err.Error(), status)
+		return
+	}
+	if h.policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, h.policy.Timeout)
+		defer cancel()
+		select {
+		case h.policy.calls <- struct{}{}:
+			defer func() { <-h.policy.calls }()
+		case <-ctx.Done():
+			http.Error(w, (&requestTimeoutError{method}).Error(), http.StatusServiceUnavailable)
+			return
+		}
+	}
+	h.next.ServeHTTP(w, r.WithContext(ctx))
+}
+func clientAddress(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+func (p Policy) String() string {
+	return fmt.Sprintf("allow=%v deny=%v rate=%v burst=%d batch=%d timeout=%v concurrent=%d", p.Allow, p.Deny, p.RateLimit, p.RateBurst, p.MaxBatchSize, p.Timeout, p.MaxConcurrent)
+}
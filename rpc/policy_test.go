@@ -0,0 +1,231 @@
+package rpc
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+	"github.com/dgrijalva/jwt-go"
+)
+func TestPolicyPermits(t *testing.T) {
+	tests := []struct {
+		allow, deny []string
+		method      string
+		want        bool
+	}{
+		{nil, nil, "eth_getLastTxs", true},
+		{nil, []string{"eth_getLastTxs"}, "eth_getLastTxs", false},
+		{nil, []string{"eth_getLastTxs"}, "eth_blockNumber", true},
+		{[]string{"eth_*"}, nil, "eth_blockNumber", true},
+		{[]string{"eth_*"}, nil, "net_version", false},
+		{[]string{"*"}, []string{"eth_*"}, "eth_blockNumber", false},
+		{[]string{"eth_*"}, []string{"eth_getVotersState"}, "eth_getVotersState", false},
+		{[]string{"eth_*"}, nil, "ethx_blockNumber", false},
+	}
+	for i, tt := range tests {
+		p := newServerPolicy(Policy{Allow: tt.allow, Deny: tt.deny})
+		if have := p.permits(tt.method); have != tt.want {
+			t.Errorf("test %d: permits(%s) mismatch: have %v, want %v", i, tt.method, have, tt.want)
+		}
+	}
+}
+func TestPolicyRateLimit(t *testing.T) {
+	p := newServerPolicy(Policy{RateLimit: 2, RateBurst: 3})
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		if !p.take("a", now) {
+			t.Fatalf("request %d within burst rejected", i)
+		}
+	}
+	if p.take("a", now) {
+		t.Errorf("request beyond burst accepted")
+	}
+	if !p.take("b", now) {
+		t.Errorf("request from another client rejected")
+	}
+	if !p.take("a", now.Add(500*time.Millisecond)) {
+		t.Errorf("request after refill rejected")
+	}
+	if p.take("a", now.Add(500*time.Millisecond)) {
+		t.Errorf("request beyond refill accepted")
+	}
+	if !p.take("", now) {
+		t.Errorf("request without client identity rejected")
+	}
+}
+func policyRequest(t *testing.T, srv *httptest.Server, body string) []jsonErrResponse {
+	resp, err := http.Post(srv.URL, contentType, strings.NewReader(body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+	buf := new(bytes.Buffer)
+	buf.ReadFrom(resp.Body)
+	var responses []jsonErrResponse
+	if strings.HasPrefix(strings.TrimSpace(buf.String()), "[") {
+		if err := json.Unmarshal(buf.Bytes(), &responses); err != nil {
+			t.Fatal(err)
+		}
+		return responses
+	}
+	var response jsonErrResponse
+	if err := json.Unmarshal(buf.Bytes(), &response); err != nil {
+		t.Fatal(err)
+	}
+	return []jsonErrResponse{response}
+}
+func TestHTTPPolicy(t *testing.T) {
+	server := NewServer()
+	defer server.Stop()
+	if err := server.RegisterName("test", new(Service)); err != nil {
+		t.Fatal(err)
+	}
+	server.SetPolicy(Policy{Deny: []string{"test_echo"}, RateLimit: 1, RateBurst: 3, MaxBatchSize: 2, Timeout: 50 * time.Millisecond})
+	srv := httptest.NewServer(NewHTTPServer(nil, []string{"*"}, nil, server).Handler)
+	defer srv.Close()
+	tests := []struct {
+		body string
+		code int
+	}{
+		{`{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x",1]}`, -32001},
+		{`{"jsonrpc":"2.0","id":1,"method":"test_sleep","params":[1000000000]}`, -32002},
+		{`[{"jsonrpc":"2.0","id":1,"method":"test_noArgsRets"},{"jsonrpc":"2.0","id":2,"method":"test_noArgsRets"},{"jsonrpc":"2.0","id":3,"method":"test_noArgsRets"}]`, -32600},
+		{`{"jsonrpc":"2.0","id":1,"method":"test_noArgsRets"}`, 0},
+		{`{"jsonrpc":"2.0","id":1,"method":"test_noArgsRets"}`, 0},
+		{`{"jsonrpc":"2.0","id":1,"method":"test_noArgsRets"}`, -32005},
+	}
+	for i, tt := range tests {
+		responses := policyRequest(t, srv, tt.body)
+		if len(responses) != 1 {
+			t.Errorf("test %d: response count mismatch: have %d, want 1", i, len(responses))
+			continue
+		}
+		if code := responses[0].Error.Code; code != tt.code {
+			t.Errorf("test %d: error code mismatch: have %d, want %d (%s)", i, code, tt.code, responses[0].Error.Message)
+		}
+	}
+}
+func TestPolicySubscribe(t *testing.T) {
+	tests := []struct {
+		allow, deny []string
+		denied      bool
+	}{
+		{nil, []string{"eth_subscribe"}, true},
+		{nil, []string{"eth_someSubscription"}, false},
+		{[]string{"eth_subscribe"}, nil, false},
+		{[]string{"eth_someSubscription"}, nil, true},
+	}
+	for i, tt := range tests {
+		server := NewServer()
+		if err := server.RegisterName("eth", new(NotificationTestService)); err != nil {
+			t.Fatal(err)
+		}
+		server.SetPolicy(Policy{Allow: tt.allow, Deny: tt.deny})
+		client := DialInProc(server)
+		sub, err := client.Subscribe(context.Background(), "eth", make(chan int), "someSubscription", 0, 0)
+		if tt.denied {
+			if rpcErr, ok := err.(Error); !ok || rpcErr.ErrorCode() != -32001 {
+				t.Errorf("test %d: subscribe error mismatch: have %v, want method denied", i, err)
+			}
+		} else if err != nil {
+			t.Errorf("test %d: subscribe failed: %v", i, err)
+		} else {
+			sub.Unsubscribe()
+		}
+		client.Close()
+		server.Stop()
+	}
+}
+func TestPolicyEviction(t *testing.T) {
+	p := newServerPolicy(Policy{RateLimit: 1, RateBurst: 1})
+	now := time.Now()
+	for i := 0; i < maxRateLimitedClients; i++ {
+		if !p.take(fmt.Sprintf("client-%d", i), now.Add(time.Duration(i)*time.Microsecond)) {
+			t.Fatalf("first request of client %d rejected", i)
+		}
+	}
+	later := now.Add(time.Duration(maxRateLimitedClients) * time.Microsecond)
+	if !p.take("newcomer", later) {
+		t.Errorf("request from a new client rejected with a full table")
+	}
+	if len(p.buckets) != maxRateLimitedClients {
+		t.Errorf("bucket count mismatch: have %d, want %d", len(p.buckets), maxRateLimitedClients)
+	}
+	if _, ok := p.buckets["client-0"]; ok {
+		t.Errorf("oldest bucket not evicted")
+	}
+	if p.take("client-1", later) {
+		t.Errorf("drained client regained tokens through eviction")
+	}
+}
+func TestPolicyCallConcurrency(t *testing.T) {
+	p := newServerPolicy(Policy{Timeout: 20 * time.Millisecond, MaxConcurrent: 1})
+	release := make(chan struct{})
+	blocked := reflect.ValueOf(func() { <-release })
+	quick := reflect.ValueOf(func() {})
+	call := func(fn reflect.Value) Error {
+		ctx, cancel := context.WithTimeout(context.Background(), p.Timeout)
+		defer cancel()
+		_, err := p.call(ctx, "test_call", fn, nil)
+		return err
+	}
+	if err := call(blocked); err == nil {
+		t.Fatalf("blocked call did not time out")
+	}
+	if err := call(quick); err == nil {
+		t.Errorf("call admitted while the abandoned call still runs")
+	}
+	close(release)
+	for i := 0; ; i++ {
+		if err := call(quick); err == nil {
+			break
+		} else if i == 10 {
+			t.Fatalf("call rejected after the abandoned call returned: %v", err)
+		}
+	}
+}
+func TestPolicyHandler(t *testing.T) {
+	secret := bytes.Repeat([]byte{0x42}, minJWTSecretLength)
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if _, ok := r.Context().Deadline(); !ok {
+			t.Errorf("%s: request context without deadline", r.URL.Path)
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	handler := NewJWTHandler(secret, NewPolicyHandler(Policy{Deny: []string{"rest_txs"}, RateLimit: 1, RateBurst: 2, Timeout: time.Second}, "rest", next))
+	srv := httptest.NewServer(handler)
+	defer srv.Close()
+	open, _ := NewAuthToken(secret, &AuthClaims{StandardClaims: jwt.StandardClaims{IssuedAt: time.Now().Unix()}})
+	scoped, _ := NewAuthToken(secret, &AuthClaims{StandardClaims: jwt.StandardClaims{IssuedAt: time.Now().Unix()}, Namespaces: []string{"eth"}})
+	tests := []struct {
+		token  string
+		path   string
+		status int
+	}{
+		{"", "/blocks/1", http.StatusUnauthorized},
+		{scoped, "/blocks/1", http.StatusForbidden},
+		{open, "/txs/0x01", http.StatusForbidden},
+		{open, "/blocks/1", http.StatusOK},
+		{open, "/rounds/1", http.StatusOK},
+		{open, "/producers", http.StatusTooManyRequests},
+	}
+	for i, tt := range tests {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+tt.path, nil)
+		if tt.token != "" {
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatalf("test %d: %v", i, err)
+		}
+		resp.Body.Close()
+		if resp.StatusCode != tt.status {
+			t.Errorf("test %d: %s status mismatch: have %d, want %d", i, tt.path, resp.StatusCode, tt.status)
+		}
+	}
+}
@@ -70,7 +70,7 @@ func (s *Server) RegisterName(name string, rcvr interface{}) error {
 	s.services[svc.name] = svc
 	return nil
 }
-func (s *Server) serveRequest(codec ServerCodec, singleShot bool, options CodecOption) error {
+func (s *Server) serveRequest(parent context.Context, codec ServerCodec, singleShot bool, options CodecOption) error {
 	var pend sync.WaitGroup
 	defer func() {
 		if err := recover(); err != nil {
@@ -83,7 +83,7 @@ func (s *Server) serveRequest(codec ServerCodec, singleShot bool, options CodecO
 		s.codecs.Remove(codec)
 		s.codecsMu.Unlock()
 	}()
-	ctx, cancel := context.WithCancel(context.Background())
+	ctx, cancel := context.WithCancel(parent)
 	defer cancel()
 	if options&OptionSubscriptions == OptionSubscriptions {
 		ctx = context.WithValue(ctx, notifierKey{}, newNotifier(codec))
@@ -118,6 +118,13 @@ func (s *Server) serveRequest(codec ServerCodec, singleShot bool, options CodecO
 			}
 			return nil
 		}
+		if batch && s.policy != nil && s.policy.MaxBatchSize > 0 && len(reqs) > s.policy.MaxBatchSize {
+			codec.Write(codec.CreateErrorResponse(nil, &batchTooLargeError{len(reqs), s.policy.MaxBatchSize}))
+			if singleShot {
+				return nil
+			}
+			continue
+		}
 		if singleShot {
 			if batch {
 				s.execBatch(ctx, codec, reqs)
@@ -139,11 +146,14 @@ func (s *Server) serveRequest(codec ServerCodec, singleShot bool, options CodecO
 	return nil
 }
 func (s *Server) ServeCodec(codec ServerCodec, options CodecOption) {
+	s.serveCodec(context.Background(), codec, options)
+}
+func (s *Server) serveCodec(ctx context.Context, codec ServerCodec, options CodecOption) {
 	defer codec.Close()
-	s.serveRequest(codec, false, options)
+	s.serveRequest(ctx, codec, false, options)
 }
 func (s *Server) ServeSingleRequest(codec ServerCodec, options CodecOption) {
-	s.serveRequest(codec, true, options)
+	s.serveRequest(context.Background(), codec, true, options)
 }
 func (s *Server) Stop() {
 	if atomic.CompareAndSwapInt32(&s.run, 1, 0) {
@@ -165,10 +175,24 @@ func (s *Server) createSubscription(ctx context.Context, c ServerCodec, req *ser
 	}
 	return reply[0].Interface().(*Subscription).ID, nil
 }
+func (req *serverRequest) policyMethod() string {
+	if req.callb.isSubscribe {
+		return req.svcname + subscribeMethodSuffix
+	}
+	return req.svcname + serviceMethodSeparator + formatName(req.callb.method.Name)
+}
 func (s *Server) handle(ctx context.Context, codec ServerCodec, req *serverRequest) (interface{}, func()) {
 	if req.err != nil {
 		return codec.CreateErrorResponse(&req.id, req.err), nil
 	}
+	if claims, ok := AuthClaimsFromContext(ctx); ok && !req.isUnsubscribe && !claims.Allows(req.svcname) {
+		return codec.CreateErrorResponse(&req.id, &namespaceDeniedError{req.svcname}), nil
+	}
+	if s.policy != nil && !req.isUnsubscribe {
+		if err := s.policy.check(ctx, req.policyMethod()); err != nil {
+			return codec.CreateErrorResponse(&req.id, err), nil
+		}
+	}
 	if req.isUnsubscribe { 
 		if len(req.args) >= 1 && req.args[0].Kind() == reflect.String {
 			notifier, supported := NotifierFromContext(ctx)
@@ -200,6 +224,11 @@ func (s *Server) handle(ctx context.Context, codec ServerCodec, req *serverReque
 			len(req.callb.argTypes), len(req.args))}
 		return codec.CreateErrorResponse(&req.id, rpcErr), nil
 	}
+	if s.policy != nil && s.policy.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.policy.Timeout)
+		defer cancel()
+	}
 	arguments := []reflect.Value{req.callb.rcvr}
 	if req.callb.hasCtx {
 		arguments = append(arguments, reflect.ValueOf(ctx))
@@ -207,7 +236,15 @@ func (s *Server) handle(ctx context.Context, codec ServerCodec, req *serverReque
 	if len(req.args) > 0 {
 		arguments = append(arguments, req.args...)
 	}
-	reply := req.callb.method.Func.Call(arguments)
+	var reply []reflect.Value
+	if s.policy != nil {
+		var err Error
+		if reply, err = s.policy.call(ctx, req.policyMethod(), req.callb.method.Func, arguments); err != nil {
+			return codec.CreateErrorResponse(&req.id, err), nil
+		}
+	} else {
+		reply = req.callb.method.Func.Call(arguments)
+	}
 	if len(reply) == 0 {
 		return codec.CreateResponse(req.id, nil), nil
 	}
@@ -44,6 +44,7 @@ type Server struct {
 	run      int32
 	codecsMu sync.Mutex
 	codecs   *set.Set
+	policy   *serverPolicy
 }
 type rpcRequest struct {
 	service  string
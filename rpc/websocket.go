@@ -17,12 +17,12 @@ func (srv *Server) WebsocketHandler(allowedOrigins []string) http.Handler {
 	return websocket.Server{
 		Handshake: wsHandshakeValidator(allowedOrigins),
 		Handler: func(conn *websocket.Conn) {
-			srv.ServeCodec(NewJSONCodec(conn), OptionMethodInvocation|OptionSubscriptions)
+			srv.serveCodec(requestContext(conn.Request()), NewJSONCodec(conn), OptionMethodInvocation|OptionSubscriptions)
 		},
 	}
 }
-func NewWSServer(allowedOrigins []string, srv *Server) *http.Server {
-	return &http.Server{Handler: srv.WebsocketHandler(allowedOrigins)}
+func NewWSServer(allowedOrigins []string, secret []byte, srv *Server) *http.Server {
+	return &http.Server{Handler: NewJWTHandler(secret, srv.WebsocketHandler(allowedOrigins))}
 }
 func wsHandshakeValidator(allowedOrigins []string) func(*websocket.Config, *http.Request) error {
 	origins := set.New()